@@ -27,7 +27,20 @@ type options struct {
 	EnableCORS         bool
 	GracefulTimeoutSec int
 	TotalTimeoutSec    int
+	CallerTimeoutSec   int
 	MaxBlocksBehind    int
+	GasOutlierFactor   float64
+	GasPricePercentile float64
+	MethodRequirements map[string]int
+	RaceMethods        []string
+	EthRPCWeights      []int
+	ResponseDebug      bool
+	LogResolutions     bool
+	RedactMethods      []string
+	EnforceChainID     bool
+	ChainID            uint64
+	BreakerThreshold   int
+	BreakerCooldownSec int
 	EthRPCURLs         []string
 	flag.LoggerFlag
 }
@@ -69,12 +82,90 @@ func NewRootCommand(opts *options) *cobra.Command {
 		10,
 		"set request timeout in seconds",
 	)
+	rootCmd.PersistentFlags().IntVar(
+		&opts.CallerTimeoutSec,
+		"caller-timeout",
+		0,
+		"set timeout in seconds for a single RPC node, 0 disables it",
+	)
 	rootCmd.PersistentFlags().IntVarP(
 		&opts.TotalTimeoutSec,
 		"max-blocks-behind", "b",
 		10,
 		"determines how far one node can be behind the last known block",
 	)
+	rootCmd.PersistentFlags().Float64Var(
+		&opts.GasOutlierFactor,
+		"gas-outlier-factor",
+		0,
+		"discards gas prices that are more than this many times higher or lower than the median, 0 disables it",
+	)
+	rootCmd.PersistentFlags().Float64Var(
+		&opts.GasPricePercentile,
+		"gas-price-percentile",
+		0,
+		"percentile of gas prices returned by eth_gasPrice and eth_maxPriorityFeePerGas, 0 uses the median",
+	)
+	rootCmd.PersistentFlags().StringToIntVar(
+		&opts.MethodRequirements,
+		"method-requirements",
+		map[string]int{},
+		"minimum number of same responses for specific methods, e.g. eth_getBalance=3,eth_gasPrice=1",
+	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&opts.RaceMethods,
+		"race-methods",
+		[]string{},
+		"methods for which the first successful response is returned without comparing it with others",
+	)
+	rootCmd.PersistentFlags().IntSliceVar(
+		&opts.EthRPCWeights,
+		"eth-rpc-weights",
+		[]int{},
+		"voting weights of RPC nodes in the same order as --eth-rpc, by default all nodes have a weight of 1",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&opts.ResponseDebug,
+		"response-debug",
+		false,
+		"include responses of all RPC nodes in the error data if they cannot be resolved",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&opts.LogResolutions,
+		"log-resolutions",
+		false,
+		"log how the response to every request was resolved",
+	)
+	rootCmd.PersistentFlags().StringSliceVar(
+		&opts.RedactMethods,
+		"redact-methods",
+		[]string{"eth_sendRawTransaction"},
+		"methods whose arguments are never logged",
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&opts.EnforceChainID,
+		"enforce-chain-id",
+		false,
+		"exclude RPC nodes connected to a different chain",
+	)
+	rootCmd.PersistentFlags().Uint64Var(
+		&opts.ChainID,
+		"chain-id",
+		0,
+		"expected chain ID used with --enforce-chain-id, 0 uses the chain ID returned by the majority of RPC nodes",
+	)
+	rootCmd.PersistentFlags().IntVar(
+		&opts.BreakerThreshold,
+		"circuit-breaker-threshold",
+		0,
+		"number of consecutive failures after which an RPC node is temporarily ejected, 0 disables it",
+	)
+	rootCmd.PersistentFlags().IntVar(
+		&opts.BreakerCooldownSec,
+		"circuit-breaker-cooldown",
+		30,
+		"time in seconds after which an ejected RPC node is called again",
+	)
 	rootCmd.PersistentFlags().StringSliceVar(
 		&opts.EthRPCURLs,
 		"eth-rpc",
@@ -40,13 +40,46 @@ func NewRunCmd(opts *options) *cobra.Command {
 		RunE: func(_ *cobra.Command, _ []string) error {
 			ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
 			log := opts.Logger()
-			var server, err = rpcsplitter.NewServer(
+			weights, err := callerWeights(opts.EthRPCURLs, opts.EthRPCWeights)
+			if err != nil {
+				return err
+			}
+			totalWeight := 0
+			for _, url := range opts.EthRPCURLs {
+				if w, ok := weights[url]; ok {
+					totalWeight += w
+				} else {
+					totalWeight++
+				}
+			}
+			serverOpts := []rpcsplitter.Option{
 				rpcsplitter.WithEndpoints(opts.EthRPCURLs),
-				rpcsplitter.WithTotalTimeout(time.Duration(opts.TotalTimeoutSec)*time.Second),
-				rpcsplitter.WithGracefulTimeout(time.Duration(opts.GracefulTimeoutSec)*time.Second),
-				rpcsplitter.WithRequirements(minimumRequiredResponses(len(opts.EthRPCURLs)), opts.MaxBlocksBehind),
+				rpcsplitter.WithTotalTimeout(time.Duration(opts.TotalTimeoutSec) * time.Second),
+				rpcsplitter.WithGracefulTimeout(time.Duration(opts.GracefulTimeoutSec) * time.Second),
+				rpcsplitter.WithCallerTimeout(time.Duration(opts.CallerTimeoutSec) * time.Second),
+				rpcsplitter.WithRequirements(minimumRequiredResponses(totalWeight), opts.MaxBlocksBehind),
+				rpcsplitter.WithMethodRequirements(opts.MethodRequirements),
+				rpcsplitter.WithRaceMethods(opts.RaceMethods),
+				rpcsplitter.WithCallerWeights(weights),
+				rpcsplitter.WithResponseDebug(opts.ResponseDebug),
+				rpcsplitter.WithResolutionLogging(opts.LogResolutions),
+				rpcsplitter.WithRedactedMethods(opts.RedactMethods),
+				rpcsplitter.WithGasOutlierFactor(opts.GasOutlierFactor),
 				rpcsplitter.WithLogger(opts.Logger()),
-			)
+			}
+			if opts.BreakerThreshold > 0 {
+				serverOpts = append(serverOpts, rpcsplitter.WithCircuitBreaker(
+					opts.BreakerThreshold,
+					time.Duration(opts.BreakerCooldownSec)*time.Second,
+				))
+			}
+			if opts.GasPricePercentile > 0 {
+				serverOpts = append(serverOpts, rpcsplitter.WithGasPriceStrategy(opts.GasPricePercentile))
+			}
+			if opts.EnforceChainID {
+				serverOpts = append(serverOpts, rpcsplitter.WithEnforceChainID(opts.ChainID))
+			}
+			server, err := rpcsplitter.NewServer(serverOpts...)
 			if err != nil {
 				return err
 			}
@@ -57,11 +90,7 @@ func NewRunCmd(opts *options) *cobra.Command {
 				ReadTimeout: 10 * time.Second,
 			})
 
-			srv.Use(&middleware.Recover{
-				Recover: func(err interface{}) {
-					log.WithField("panic", fmt.Sprintf("%s", err)).Error("Server handler crashed")
-				},
-			})
+			srv.Use(&middleware.Recover{Log: log})
 
 			srv.Use(&middleware.Logger{Log: log})
 
@@ -96,3 +125,26 @@ func minimumRequiredResponses(endpoints int) int {
 	}
 	return endpoints - 1
 }
+
+// callerWeights maps the weights given in the same order as the endpoints to
+// the endpoint URLs. Weights are matched by position rather than by URL,
+// because URLs may contain characters used as separators in flag values.
+func callerWeights(urls []string, weights []int) (map[string]int, error) {
+	if len(weights) == 0 {
+		return nil, nil
+	}
+	if len(weights) != len(urls) {
+		return nil, fmt.Errorf(
+			"number of RPC node weights (%d) does not match the number of RPC nodes (%d)",
+			len(weights), len(urls),
+		)
+	}
+	m := make(map[string]int, len(urls))
+	for i, url := range urls {
+		if w, ok := m[url]; ok && w != weights[i] {
+			return nil, fmt.Errorf("different weights specified for RPC node %s", url)
+		}
+		m[url] = weights[i]
+	}
+	return m, nil
+}
@@ -16,14 +16,29 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
 	"github.com/spf13/cobra"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
+const (
+	priceFormatJSON = "json"
+	priceFormatHex  = "hex"
+)
+
+var errNoTerminal = errors.New("terminal is not available, use --passphrase-env to provide the passphrase")
+
 func NewPriceCmd(opts *options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "price",
@@ -35,68 +50,211 @@ func NewPriceCmd(opts *options) *cobra.Command {
 	cmd.AddCommand(
 		NewPriceSignCmd(opts),
 		NewPriceVerifyCmd(),
+		NewPriceRecoverCmd(),
 	)
 
 	return cmd
 }
 
 func NewPriceSignCmd(opts *options) *cobra.Command {
-	return &cobra.Command{
-		Use:   "sign key [json_message]",
-		Args:  cobra.MinimumNArgs(1),
+	var (
+		failFast      bool
+		format        string
+		keystore      string
+		passphraseEnv string
+	)
+	cmd := &cobra.Command{
+		Use:   "sign [key] [json_file]",
+		Args:  cobra.MaximumNArgs(2),
 		Short: "signs given JSON price message and returns JSON with VRS fields",
-		Long:  ``,
+		Long: `Signs JSON price messages read from a file or from stdin.
+
+The input may contain a single JSON message or newline-delimited JSON
+messages. In the latter case, every message is signed separately and signed
+messages are printed one per line. Errors are reported on stderr with the
+line number, and the remaining messages are still signed unless the
+--fail-fast flag is used.
+
+The --format flag controls the output. The "json" format prints the whole
+signed message, the "hex" format prints only the 65-byte signature.
+
+The key is looked up by name in the configuration. Alternatively, the key
+may be loaded from an Ethereum keystore file using the --keystore flag, in
+which case the key name argument must be omitted. The passphrase is then
+read from the environment variable named by the --passphrase-env flag or,
+if the flag is not used, prompted for on the terminal.`,
 		RunE: func(_ *cobra.Command, args []string) error {
-			srv, err := PrepareServices(opts)
-			if err != nil {
-				return err
+			if format != priceFormatJSON && format != priceFormatHex {
+				return fmt.Errorf("unsupported format: %s", format)
 			}
 
 			// Key:
-			key, ok := srv.Keys[args[0]]
-			if !ok {
-				return fmt.Errorf("unable to find key %s", args[0])
+			var (
+				key      wallet.Key
+				inputPos int
+			)
+			if keystore != "" {
+				if len(args) > 1 {
+					return errors.New("key name cannot be used together with --keystore")
+				}
+				var passphrase string
+				if passphraseEnv != "" {
+					p, ok := os.LookupEnv(passphraseEnv)
+					if !ok {
+						return fmt.Errorf("environment variable %s is not set", passphraseEnv)
+					}
+					passphrase = p
+				} else {
+					p, err := readPassphrase("Passphrase: ")
+					if err != nil {
+						return fmt.Errorf("unable to read passphrase: %w", err)
+					}
+					passphrase = p
+				}
+				k, err := wallet.NewKeyFromJSON(keystore, passphrase)
+				if err != nil {
+					return fmt.Errorf("unable to load key from keystore: %w", err)
+				}
+				key = k
+			} else {
+				if len(args) == 0 {
+					return errors.New("key name or --keystore must be provided")
+				}
+				srv, err := PrepareServices(opts)
+				if err != nil {
+					return err
+				}
+				k, ok := srv.Keys[args[0]]
+				if !ok {
+					return fmt.Errorf("unable to find key %s", args[0])
+				}
+				key = k
+				inputPos = 1
 			}
 
-			// Read JSON and parse it:
-			input, err := readInput(args, 1)
-			if err != nil {
-				return err
-			}
-			msg := &messages.Price{}
-			err = msg.Unmarshall(input)
+			// Read JSON:
+			input, err := readInput(args, inputPos)
 			if err != nil {
 				return err
 			}
 
-			// Sign price:
-			err = msg.Price.Sign(key)
-			if err != nil {
-				return err
-			}
+			return signPriceMessages(key, input, format, failFast, os.Stdout, os.Stderr)
+		},
+	}
 
-			// Marshall to JSON:
-			signedMsg, err := msg.Marshall()
-			if err != nil {
-				return err
-			}
+	cmd.Flags().BoolVar(
+		&failFast,
+		"fail-fast",
+		false,
+		"stop on the first message that cannot be signed",
+	)
+	cmd.Flags().StringVar(
+		&format,
+		"format",
+		priceFormatJSON,
+		"output format (json|hex)",
+	)
+	cmd.Flags().StringVar(
+		&keystore,
+		"keystore",
+		"",
+		"path to the Ethereum keystore file with the signing key",
+	)
+	cmd.Flags().StringVar(
+		&passphraseEnv,
+		"passphrase-env",
+		"",
+		"name of the environment variable with the keystore passphrase, if not set, the passphrase is prompted for",
+	)
 
-			fmt.Printf("%s\n", string(signedMsg))
+	return cmd
+}
 
-			return nil
-		},
+// signPriceMessages signs a single JSON price message or newline-delimited
+// JSON price messages and writes signed messages to out, one per line.
+// Errors for individual messages are written to errOut with the line number,
+// unless failFast is set, in which case the first error is returned.
+func signPriceMessages(key wallet.Key, input []byte, format string, failFast bool, out, errOut io.Writer) error {
+	// Single message:
+	if json.Valid(input) {
+		signedMsg, err := signPriceMessage(key, input, format)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s\n", string(signedMsg))
+		return nil
+	}
+
+	// Newline-delimited messages:
+	var line, failed int
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	scanner.Buffer(nil, len(input)+1)
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		signedMsg, err := signPriceMessage(key, scanner.Bytes(), format)
+		if err != nil {
+			if failFast {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			fmt.Fprintf(errOut, "line %d: %s\n", line, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(out, "%s\n", string(signedMsg))
 	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to sign %d messages", failed)
+	}
+	return nil
+}
+
+// signPriceMessage parses the JSON price message, signs it using the given
+// key and returns the signed message in the given format.
+func signPriceMessage(key wallet.Key, input []byte, format string) ([]byte, error) {
+	msg := &messages.Price{}
+	if err := msg.Unmarshall(input); err != nil {
+		return nil, err
+	}
+	if msg.Price == nil {
+		return nil, errors.New("missing price field")
+	}
+	if err := msg.Price.Sign(key); err != nil {
+		return nil, err
+	}
+	if format == priceFormatHex {
+		return []byte(msg.Price.Sig.String()), nil
+	}
+	return msg.Marshall()
 }
 
 func NewPriceVerifyCmd() *cobra.Command {
-	return &cobra.Command{
+	var expectSigner string
+	cmd := &cobra.Command{
 		Use:   "verify [json_message]",
 		Args:  cobra.MaximumNArgs(1),
 		Short: "verifies given JSON price message",
-		Long:  ``,
-		RunE: func(_ *cobra.Command, args []string) error {
+		Long: `Prints fields of the given JSON price message.
+
+If the --expect-signer flag is used, the command fails if the message was
+not signed by the given address.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
+			// Expected signer:
+			var expected types.Address
+			if expectSigner != "" {
+				expected, err = types.AddressFromHex(expectSigner)
+				if err != nil {
+					return fmt.Errorf("invalid expected signer address: %w", err)
+				}
+			}
+
 			// Read JSON and parse it:
 			input, err := readInput(args, 0)
 			if err != nil {
@@ -111,7 +269,74 @@ func NewPriceVerifyCmd() *cobra.Command {
 			// Print message parameters:
 			fields := msg.Price.Fields(crypto.ECRecoverer)
 			for _, k := range sortFields(fields) {
-				fmt.Printf("%-4s %s\n", k, fields[k])
+				fmt.Fprintf(cmd.OutOrStdout(), "%-4s %s\n", k, fields[k])
+			}
+
+			// Verify signer:
+			if expectSigner != "" {
+				from, err := msg.Price.From(crypto.ECRecoverer)
+				if err != nil {
+					return fmt.Errorf("unable to recover signer: %w", err)
+				}
+				if *from != expected {
+					return fmt.Errorf(
+						"signer mismatch: expected %s, recovered %s",
+						expected.Checksum(crypto.Keccak256),
+						from.Checksum(crypto.Keccak256),
+					)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&expectSigner,
+		"expect-signer",
+		"",
+		"fail if the message was not signed by the given address",
+	)
+
+	return cmd
+}
+
+func NewPriceRecoverCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "recover [json_file]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "prints addresses of signers of given JSON price messages",
+		Long: `Recovers signer addresses from JSON price messages read from a file or from stdin.
+
+The input may contain a single JSON message or multiple JSON messages, for
+example newline-delimited. For every message, the checksummed address of the
+signer is printed on a separate line.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Read JSON:
+			input, err := readInput(args, 0)
+			if err != nil {
+				return err
+			}
+
+			// Recover addresses:
+			dec := json.NewDecoder(bytes.NewReader(input))
+			for n := 1; dec.More(); n++ {
+				var raw json.RawMessage
+				if err := dec.Decode(&raw); err != nil {
+					return fmt.Errorf("message %d: %w", n, err)
+				}
+				msg := &messages.Price{}
+				if err := msg.Unmarshall(raw); err != nil {
+					return fmt.Errorf("message %d: %w", n, err)
+				}
+				if msg.Price == nil {
+					return fmt.Errorf("message %d: missing price field", n)
+				}
+				from, err := msg.Price.From(crypto.ECRecoverer)
+				if err != nil {
+					return fmt.Errorf("message %d: %w", n, err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), from.Checksum(crypto.Keccak256))
 			}
 
 			return nil
@@ -0,0 +1,245 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const (
+	testPriceMessage1 = `{"price":{"wat":"AAABBB","val":"1000000000000000000","age":1600000000}}`
+	testPriceMessage2 = `{"price":{"wat":"CCCDDD","val":"2000000000000000000","age":1600000000}}`
+)
+
+// recoverPriceSigner returns the signer of the JSON price message.
+func recoverPriceSigner(t *testing.T, input string) types.Address {
+	msg := &messages.Price{}
+	require.NoError(t, msg.Unmarshall([]byte(input)))
+	from, err := msg.Price.From(crypto.ECRecoverer)
+	require.NoError(t, err)
+	return *from
+}
+
+func TestSignPriceMessages_JSON(t *testing.T) {
+	key := wallet.NewRandomKey()
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	require.NoError(t, signPriceMessages(key, []byte(testPriceMessage1), priceFormatJSON, false, out, errOut))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 1)
+	assert.Equal(t, key.Address(), recoverPriceSigner(t, lines[0]))
+	assert.Empty(t, errOut.String())
+}
+
+func TestSignPriceMessages_NDJSON(t *testing.T) {
+	key := wallet.NewRandomKey()
+	input := testPriceMessage1 + "\n\n" + testPriceMessage2 + "\n"
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	// Every message is signed separately and empty lines are skipped.
+	require.NoError(t, signPriceMessages(key, []byte(input), priceFormatJSON, false, out, errOut))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "AAABBB")
+	assert.Contains(t, lines[1], "CCCDDD")
+	for _, line := range lines {
+		assert.Equal(t, key.Address(), recoverPriceSigner(t, line))
+	}
+	assert.Empty(t, errOut.String())
+}
+
+func TestSignPriceMessages_NDJSONErrors(t *testing.T) {
+	key := wallet.NewRandomKey()
+	input := testPriceMessage1 + "\n{}\ninvalid\n" + testPriceMessage2 + "\n"
+
+	t.Run("continue", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		errOut := &bytes.Buffer{}
+
+		// Invalid messages are reported with line numbers and the remaining
+		// messages are still signed.
+		err := signPriceMessages(key, []byte(input), priceFormatJSON, false, out, errOut)
+		require.EqualError(t, err, "unable to sign 2 messages")
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], "AAABBB")
+		assert.Contains(t, lines[1], "CCCDDD")
+		assert.Contains(t, errOut.String(), "line 2: missing price field")
+		assert.Contains(t, errOut.String(), "line 3: ")
+	})
+
+	t.Run("fail-fast", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		errOut := &bytes.Buffer{}
+
+		err := signPriceMessages(key, []byte(input), priceFormatJSON, true, out, errOut)
+		require.EqualError(t, err, "line 2: missing price field")
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "AAABBB")
+		assert.Empty(t, errOut.String())
+	})
+}
+
+func TestSignPriceMessages_Hex(t *testing.T) {
+	key := wallet.NewRandomKey()
+	input := testPriceMessage1 + "\n" + testPriceMessage2 + "\n"
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+
+	// Only signatures are printed, one per line.
+	require.NoError(t, signPriceMessages(key, []byte(input), priceFormatHex, false, out, errOut))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for i, msgJSON := range []string{testPriceMessage1, testPriceMessage2} {
+		sig, err := types.SignatureFromHex(lines[i])
+		require.NoError(t, err)
+		assert.Len(t, sig.Bytes(), 65)
+
+		msg := &messages.Price{}
+		require.NoError(t, msg.Unmarshall([]byte(msgJSON)))
+		msg.Price.Sig = sig
+		from, err := msg.Price.From(crypto.ECRecoverer)
+		require.NoError(t, err)
+		assert.Equal(t, key.Address(), *from)
+	}
+	assert.Empty(t, errOut.String())
+}
+
+func TestSignPriceMessage_MissingPrice(t *testing.T) {
+	_, err := signPriceMessage(wallet.NewRandomKey(), []byte(`{}`), priceFormatJSON)
+	assert.EqualError(t, err, "missing price field")
+}
+
+// runCmd executes the command with the given arguments and returns its
+// standard output.
+func runCmd(cmd *cobra.Command, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+// writeSignedPriceMessage signs the JSON price message using the given key
+// and writes it to a temporary file. It returns the path to the file.
+func writeSignedPriceMessage(t *testing.T, key wallet.Key, input string) string {
+	signed, err := signPriceMessage(key, []byte(input), priceFormatJSON)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "price.json")
+	require.NoError(t, os.WriteFile(path, signed, 0600))
+	return path
+}
+
+func TestPriceVerifyCmd_ExpectSigner(t *testing.T) {
+	key := wallet.NewRandomKey()
+	other := wallet.NewRandomKey()
+	path := writeSignedPriceMessage(t, key, testPriceMessage1)
+
+	t.Run("match", func(t *testing.T) {
+		out, err := runCmd(NewPriceVerifyCmd(), "--expect-signer", key.Address().String(), path)
+		require.NoError(t, err)
+		assert.Contains(t, out, "AAABBB")
+	})
+	t.Run("mismatch", func(t *testing.T) {
+		// Fields are printed even if the signer does not match.
+		out, err := runCmd(NewPriceVerifyCmd(), "--expect-signer", other.Address().String(), path)
+		require.EqualError(t, err, "signer mismatch: expected "+
+			other.Address().Checksum(crypto.Keccak256)+", recovered "+
+			key.Address().Checksum(crypto.Keccak256))
+		assert.Contains(t, out, "AAABBB")
+	})
+	t.Run("invalid-address", func(t *testing.T) {
+		_, err := runCmd(NewPriceVerifyCmd(), "--expect-signer", "0x1234", path)
+		assert.ErrorContains(t, err, "invalid expected signer address")
+	})
+}
+
+func TestPriceVerifyCmd_ExitCode(t *testing.T) {
+	// The test runs itself in a subprocess to check the exit code of the
+	// whole program.
+	if path := os.Getenv("TOOLBOX_TEST_VERIFY_PATH"); path != "" {
+		os.Args = []string{"toolbox", "price", "verify", "--expect-signer", os.Getenv("TOOLBOX_TEST_VERIFY_SIGNER"), path}
+		main()
+		return
+	}
+
+	key := wallet.NewRandomKey()
+	path := writeSignedPriceMessage(t, key, testPriceMessage1)
+	run := func(signer types.Address) error {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestPriceVerifyCmd_ExitCode$")
+		cmd.Env = append(
+			os.Environ(),
+			"TOOLBOX_TEST_VERIFY_PATH="+path,
+			"TOOLBOX_TEST_VERIFY_SIGNER="+signer.String(),
+		)
+		return cmd.Run()
+	}
+
+	require.NoError(t, run(key.Address()))
+
+	var exitErr *exec.ExitError
+	err := run(wallet.NewRandomKey().Address())
+	require.True(t, errors.As(err, &exitErr), "expected exit error, got %v", err)
+	assert.Equal(t, 1, exitErr.ExitCode())
+}
+
+func TestPriceRecoverCmd(t *testing.T) {
+	// Address of the private key equal to 1.
+	const address = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+	key := wallet.NewKeyFromBytes(types.MustHashFromHex("0x01", types.PadLeft).Bytes())
+	require.Equal(t, address, key.Address().Checksum(crypto.Keccak256))
+
+	var input []byte
+	for _, msg := range []string{testPriceMessage1, testPriceMessage2} {
+		signed, err := signPriceMessage(key, []byte(msg), priceFormatJSON)
+		require.NoError(t, err)
+		input = append(append(input, signed...), '\n')
+	}
+	path := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, os.WriteFile(path, input, 0600))
+
+	out, err := runCmd(NewPriceRecoverCmd(), path)
+	require.NoError(t, err)
+	assert.Equal(t, address+"\n"+address+"\n", out)
+}
+
+func TestPriceRecoverCmd_MissingPrice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{}`), 0600))
+
+	_, err := runCmd(NewPriceRecoverCmd(), path)
+	assert.EqualError(t, err, "message 1: missing price field")
+}
@@ -77,6 +77,7 @@ transport {
   libp2p {
     feeds           = var.feeds
     priv_key_seed   = try(env.CFG_LIBP2P_PK_SEED, "")
+    priv_key_file   = try(env.CFG_LIBP2P_PK_FILE, "")
     listen_addrs    = try(split(",", env.CFG_LIBP2P_LISTEN_ADDRS), ["/ip4/0.0.0.0/tcp/8000"])
     bootstrap_addrs = try(env.CFG_LIBP2P_BOOTSTRAP_ADDRS == "" ? [] : split(",", env.CFG_LIBP2P_BOOTSTRAP_ADDRS), [
       "/dns/spire-bootstrap1.makerops.services/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi",
@@ -84,7 +85,10 @@ transport {
     ])
     direct_peers_addrs = try(env.CFG_LIBP2P_DIRECT_PEERS_ADDRS == "" ? [] : split(",", env.CFG_LIBP2P_DIRECT_PEERS_ADDRS), [])
     blocked_addrs      = try(env.CFG_LIBP2P_BLOCKED_ADDRS == "" ? [] : split(",", env.CFG_LIBP2P_BLOCKED_ADDRS), [])
+    allowed_addrs      = try(env.CFG_LIBP2P_ALLOWED_ADDRS == "" ? [] : split(",", env.CFG_LIBP2P_ALLOWED_ADDRS), [])
     disable_discovery  = tobool(try(env.CFG_LIBP2P_DISABLE_DISCOVERY, false))
+    enable_mdns        = tobool(try(env.CFG_LIBP2P_ENABLE_MDNS, false))
+    rendezvous         = try(env.CFG_LIBP2P_RENDEZVOUS, "")
     ethereum_key       = try(env.CFG_ETH_FROM, "") == "" ? "" : "default"
   }
 
@@ -1,7 +1,7 @@
 gofernext {
   origin "coinbase" {
     origin = "generic_jq"
-    url    = "https://api.pro.coinbase.com/products/$${ucbase}-$${ucquote}/ticker"
+    url    = "https://api.exchange.coinbase.com/products/$${ucbase}-$${ucquote}/ticker"
     jq     = "{price: .price, time: .time, volume: .volume}"
   }
 
@@ -91,6 +91,53 @@ type teleportEVMListener struct {
 	// to.
 	ContractAddrs []types.Address `hcl:"contract_addrs"`
 
+	// EventSignatures is a list of signatures of events to listen to, e.g.
+	// "TeleportInitialized((bytes32,bytes32,bytes32,bytes32,uint128,uint80,uint48))".
+	// Events must have the same structure as the TeleportInitialized event.
+	// If empty, the TeleportInitialized event is used.
+	EventSignatures []string `hcl:"event_signatures,optional"`
+
+	// IncludeGUIDFields specifies whether the decoded TeleportGUID fields
+	// should be added to the event data.
+	IncludeGUIDFields bool `hcl:"include_guid_fields,optional"`
+
+	// BufferSize is the number of events that can be buffered before they
+	// are consumed.
+	BufferSize int `hcl:"buffer_size,optional"`
+
+	// BufferPolicy specifies what happens when the buffer is full. It can be
+	// "block", "drop_oldest" or "drop_newest". Default is "block".
+	BufferPolicy string `hcl:"buffer_policy,optional"`
+
+	// RetryBaseDelay is the initial delay, in seconds, between retries of
+	// failed requests to the node. The delay is doubled after each retry.
+	RetryBaseDelay uint32 `hcl:"retry_base_delay,optional"`
+
+	// RetryMaxDelay is the maximum delay, in seconds, between retries of
+	// failed requests to the node.
+	RetryMaxDelay uint32 `hcl:"retry_max_delay,optional"`
+
+	// MaxRetries is the maximum number of retries of a failed request to
+	// the node. If zero, requests are retried until they succeed.
+	MaxRetries int `hcl:"max_retries,optional"`
+
+	// Concurrency is the maximum number of block ranges fetched
+	// concurrently when catching up with the blockchain.
+	Concurrency int `hcl:"concurrency,optional"`
+
+	// DedupCacheSize is the number of recently seen logs remembered to
+	// avoid emitting the same event twice.
+	DedupCacheSize int `hcl:"dedup_cache_size,optional"`
+
+	// ReorgDepth is the number of recent blocks checked for chain
+	// reorganizations. If zero, reorg detection is disabled.
+	ReorgDepth uint64 `hcl:"reorg_depth,optional"`
+
+	// CheckpointFile is a path to a file in which the number of the last
+	// processed block is stored. If set, the event listener resumes from
+	// that block after a restart.
+	CheckpointFile string `hcl:"checkpoint_file,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
@@ -194,10 +241,30 @@ func (c *Config) teleportEVM(eps *[]publisher.EventProvider, d Dependencies) err
 				Subject:  cfg.Content.Attributes["ethereum_client"].Range.Ptr(),
 			}
 		}
+		bufferPolicy := teleportevm.BufferBlock
+		if cfg.BufferPolicy != "" {
+			bufferPolicy, err = teleportevm.ParseBufferPolicy(cfg.BufferPolicy)
+			if err != nil {
+				return &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   err.Error(),
+					Subject:  cfg.Content.Attributes["buffer_policy"].Range.Ptr(),
+				}
+			}
+		}
 		replayAfter := make([]time.Duration, len(cfg.ReplayAfter))
 		for i, r := range cfg.ReplayAfter {
 			replayAfter[i] = time.Second * time.Duration(r)
 		}
+		topics := make([]types.Hash, len(cfg.EventSignatures))
+		for i, sig := range cfg.EventSignatures {
+			topics[i] = teleportevm.EventTopic(sig)
+		}
+		var checkpoint teleportevm.Checkpoint
+		if cfg.CheckpointFile != "" {
+			checkpoint = teleportevm.NewFileCheckpoint(cfg.CheckpointFile)
+		}
 		var eventProvider publisher.EventProvider
 		eventProvider, err = teleportevm.New(teleportevm.Config{
 			Client:             geth.NewClient(client), //nolint:staticcheck // deprecated ethereum.Client
@@ -206,6 +273,17 @@ func (c *Config) teleportEVM(eps *[]publisher.EventProvider, d Dependencies) err
 			PrefetchPeriod:     time.Second * time.Duration(cfg.PrefetchPeriod),
 			BlockLimit:         cfg.BlockLimit,
 			BlockConfirmations: cfg.BlockConfirmations,
+			Topics:             topics,
+			IncludeGUIDFields:  cfg.IncludeGUIDFields,
+			BufferSize:         cfg.BufferSize,
+			BufferPolicy:       bufferPolicy,
+			RetryBaseDelay:     time.Second * time.Duration(cfg.RetryBaseDelay),
+			RetryMaxDelay:      time.Second * time.Duration(cfg.RetryMaxDelay),
+			MaxRetries:         cfg.MaxRetries,
+			Concurrency:        cfg.Concurrency,
+			DedupCacheSize:     cfg.DedupCacheSize,
+			ReorgDepth:         cfg.ReorgDepth,
+			Checkpoint:         checkpoint,
 			Logger:             d.Logger,
 		})
 		if err != nil {
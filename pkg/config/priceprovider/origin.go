@@ -17,6 +17,8 @@ package priceprovider
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/geth"
@@ -53,6 +55,18 @@ func parseParamsContracts(params map[string]any) origins.ContractAddresses {
 	return addrs
 }
 
+func parseParamsHeaders(params map[string]any) map[string]string {
+	headersMap, ok := params["headers"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	headers := make(map[string]string, len(headersMap))
+	for k, v := range headersMap {
+		headers[k], _ = v.(string)
+	}
+	return headers
+}
+
 func parseSingleParam(params map[string]any, name string) string {
 	if apiKey, ok := params[name].(string); ok {
 		return apiKey
@@ -60,15 +74,72 @@ func parseSingleParam(params map[string]any, name string) string {
 	return ""
 }
 
-//nolint:funlen,gocyclo,whitespace
+func parseBoolParam(params map[string]any, name string) bool {
+	if v, ok := params[name].(bool); ok {
+		return v
+	}
+	return false
+}
+
+// parseUintParam parses a non-negative integer param. The second returned
+// value is false if the param is not set.
+func parseUintParam(params map[string]any, name string) (int, bool, error) {
+	v, ok := params[name]
+	if !ok {
+		return 0, false, nil
+	}
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) || f > math.MaxInt32 {
+		return 0, false, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return int(f), true, nil
+}
+
+// NewHandler returns a handler for the given origin type. If the
+// "inverse_pairs" param is set, the handler also fetches inverse pairs
+// for pairs that are not listed by the origin. The "http_timeout" and
+// "http_retry" params override the timeout, in seconds, and the number of
+// attempts of HTTP requests made by the origin.
 func NewHandler(
 	origin string,
 	wp query.WorkerPool,
 	clients ethereum.ClientRegistry,
 	params map[string]any,
 ) (origins.Handler, error) {
+	timeout, hasTimeout, err := parseUintParam(params, "http_timeout")
+	if err != nil {
+		return nil, err
+	}
+	retry, hasRetry, err := parseUintParam(params, "http_retry")
+	if err != nil {
+		return nil, err
+	}
+	if hasTimeout || hasRetry {
+		wp = query.NewDefaultsWorkerPool(wp, time.Duration(timeout)*time.Second, retry)
+	}
+	handler, err := newHandler(origin, wp, clients, params)
+	if err != nil {
+		return nil, err
+	}
+	if parseBoolParam(params, "inverse_pairs") {
+		h, ok := handler.(*origins.BaseExchangeHandler)
+		if !ok {
+			return nil, fmt.Errorf("origin %s does not support inverse pairs", origin)
+		}
+		h.SetInversePairs(true)
+	}
+	return handler, nil
+}
+
+//nolint:funlen,gocyclo,whitespace
+func newHandler(
+	origin string,
+	wp query.WorkerPool,
+	clients ethereum.ClientRegistry,
+	params map[string]any,
+) (origins.Handler, error) {
 
-	aliases := parseParamsSymbolAliases(params)
+	aliases := origins.ExchangeSymbolAliases(origin, parseParamsSymbolAliases(params))
 	switch origin {
 	case "balancer":
 		contracts := parseParamsContracts(params)
@@ -236,6 +307,21 @@ func NewHandler(
 	case "upbit":
 		baseURL := parseSingleParam(params, "url")
 		return origins.NewBaseExchangeHandler(origins.Upbit{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
+	case "generic":
+		baseURL := parseSingleParam(params, "url")
+		pricePath := parseSingleParam(params, "price_path")
+		if baseURL == "" || pricePath == "" {
+			return nil, fmt.Errorf("generic origin requires the url and price_path params")
+		}
+		return origins.NewBaseExchangeHandler(origins.Generic{
+			WorkerPool: wp,
+			Headers:    parseParamsHeaders(params),
+			URL:        baseURL,
+			PricePath:  pricePath,
+			BidPath:    parseSingleParam(params, "bid_path"),
+			AskPath:    parseSingleParam(params, "ask_path"),
+			VolumePath: parseSingleParam(params, "volume_path"),
+		}, aliases), nil
 	case "ishares":
 		baseURL := parseSingleParam(params, "url")
 		return origins.NewBaseExchangeHandler(origins.IShares{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
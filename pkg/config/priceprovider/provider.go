@@ -66,6 +66,16 @@ type Config struct {
 	// RPCAgentAddr is the address of the RPC agent.
 	RPCAgentAddr string `hcl:"rpc_agent_addr,optional"`
 
+	// HTTPTimeout is the timeout for HTTP requests to origins, in seconds.
+	// If zero, the default timeout of 5 seconds is used.
+	HTTPTimeout uint32 `hcl:"http_timeout,optional"`
+
+	// HTTPRetry is the maximum number of attempts for HTTP requests to
+	// origins. Requests are retried only on transient errors, like network
+	// errors, timeouts, and 429 and 5xx status codes. If zero, the default
+	// value of 3 is used. It must not be negative.
+	HTTPRetry int `hcl:"http_retry,optional"`
+
 	// Origins is a configuration of price origins.
 	Origins []configOrigin `hcl:"origin,block"`
 
@@ -266,7 +276,17 @@ func (c *Config) PriceHook(d HookDependencies) (provider.PriceHook, error) {
 
 func (c *Config) buildOrigins(clients ethereumConfig.ClientRegistry) (*origins.Set, error) {
 	const defaultWorkerCount = 10
+	if c.HTTPRetry < 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "HTTP retry must not be negative",
+			Subject:  c.Content.Attributes["http_retry"].Range.Ptr(),
+		}
+	}
 	wp := query.NewHTTPWorkerPool(defaultWorkerCount)
+	wp.SetTimeout(time.Duration(c.HTTPTimeout) * time.Second)
+	wp.SetRetry(c.HTTPRetry)
 	originSet := origins.DefaultOriginSet(wp)
 	for _, origin := range c.Origins {
 		handler, err := NewHandler(origin.Type, wp, clients, origin.Params)
@@ -1,6 +1,7 @@
 package priceprovider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,6 +12,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
 )
 
 func TestConfig(t *testing.T) {
@@ -26,6 +28,8 @@ func TestConfig(t *testing.T) {
 				// Check RPC server configuration.
 				assert.Equal(t, "localhost:8080", cfg.RPCListenAddr)
 				assert.Equal(t, "localhost:8081", cfg.RPCAgentAddr)
+				assert.Equal(t, uint32(10), cfg.HTTPTimeout)
+				assert.Equal(t, 2, cfg.HTTPRetry)
 
 				// Check origins configuration.
 				require.Len(t, cfg.Origins, 1)
@@ -86,3 +90,34 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_InvalidHTTPRetry(t *testing.T) {
+	var cfg Config
+	require.NoError(t, config.LoadFiles(&cfg, []string{"./testdata/invalid-http-retry.hcl"}))
+	_, err := cfg.PriceProvider(Dependencies{Logger: null.New()}, true)
+	assert.ErrorContains(t, err, "HTTP retry must not be negative")
+}
+
+func TestNewHandler_HTTPParams(t *testing.T) {
+	wp := query.NewHTTPWorkerPool(1)
+	tests := []struct {
+		params  map[string]any
+		wantErr bool
+	}{
+		{params: map[string]any{"http_timeout": float64(10), "http_retry": float64(2)}},
+		{params: map[string]any{"http_retry": float64(0)}},
+		{params: map[string]any{"http_retry": float64(-1)}, wantErr: true},
+		{params: map[string]any{"http_retry": 1.5}, wantErr: true},
+		{params: map[string]any{"http_timeout": "10"}, wantErr: true},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			_, err := NewHandler("binance", wp, nil, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 rpc_listen_addr = "localhost:8080"
 rpc_agent_addr  = "localhost:8081"
+http_timeout    = 10
+http_retry      = 2
 
 origin "origin" {
   type   = "origin"
@@ -0,0 +1 @@
+http_retry = -1
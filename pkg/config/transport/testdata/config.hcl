@@ -1,11 +1,19 @@
 libp2p {
   feeds              = ["0x1234567890123456789012345678901234567890", "0x2345678901234567890123456789012345678901"]
   listen_addrs       = ["/ip4/0.0.0.0/tcp/6000"]
+  transports         = ["tcp", "quic"]
+  security           = ["noise"]
   priv_key_seed      = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
   bootstrap_addrs    = ["/ip4/0.0.0.0/tcp/7000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"]
   direct_peers_addrs = ["/ip4/0.0.0.0/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"]
   blocked_addrs      = ["/ip4/0.0.0.0/tcp/9000"]
+  allowed_addrs      = ["/ip4/10.0.0.0/ipcidr/8"]
   disable_discovery  = true
+  enable_mdns        = true
+  rendezvous         = "rendezvous"
+  conn_low_water     = 10
+  conn_high_water    = 20
+  conn_grace_period  = 30
   ethereum_key       = "key"
 }
 
@@ -61,11 +61,27 @@ type libP2PConfig struct {
 	// using the multiaddress format.
 	ListenAddrs []string `hcl:"listen_addrs"`
 
+	// Transports is the list of transports to use. Supported transports are
+	// "tcp", "quic", "websocket" and "webtransport". If empty, the default
+	// libp2p transports are used.
+	Transports []string `hcl:"transports,optional"`
+
+	// Security is the list of security protocols to use, in order of
+	// preference. Supported protocols are "noise" and "tls". If empty, the
+	// default libp2p security protocols are used.
+	Security []string `hcl:"security,optional"`
+
 	// PrivKeySeed is the random hex-encoded 32 bytes. It is used to generate
 	// a unique identity on the libp2p network. The value may be empty to
 	// generate a random seed.
 	PrivKeySeed string `hcl:"priv_key_seed,optional"`
 
+	// PrivKeyFile is the path to a file with the libp2p private key. If the
+	// file does not exist, a new key is generated and saved to the file, so
+	// that the node keeps the same peer ID across restarts. It cannot be used
+	// together with PrivKeySeed.
+	PrivKeyFile string `hcl:"priv_key_file,optional"`
+
 	// BootstrapAddrs is the list of bootstrap addresses for libp2p node
 	// encoded using the multiaddress format.
 	BootstrapAddrs []string `hcl:"bootstrap_addrs,optional"`
@@ -77,14 +93,45 @@ type libP2PConfig struct {
 	DirectPeersAddrs []string `hcl:"direct_peers_addrs,optional"`
 
 	// BlockedAddrs is the list of blocked addresses encoded using the
-	// multiaddress format.
+	// multiaddress format. Whole networks can be blocked using the ipcidr
+	// component, e.g. /ip4/10.0.0.0/ipcidr/8.
 	BlockedAddrs []string `hcl:"blocked_addrs,optional"`
 
+	// AllowedAddrs is the list of allowed addresses encoded using the
+	// multiaddress format. If not empty, connections to all other peers
+	// are rejected.
+	AllowedAddrs []string `hcl:"allowed_addrs,optional"`
+
 	// DisableDiscovery disables node discovery. If enabled, the IP address of
 	// a node will not be broadcast to other peers. This option must be used
 	// together with `directPeersAddrs`.
 	DisableDiscovery bool `hcl:"disable_discovery,optional"`
 
+	// EnableMDNS enables discovery of peers in the local network using mDNS.
+	// It is ignored if discovery is disabled.
+	EnableMDNS bool `hcl:"enable_mdns,optional"`
+
+	// Rendezvous is an optional rendezvous string. If set, the node
+	// advertises itself under this string using KAD-DHT and searches for
+	// other peers advertising the same string. It is ignored if discovery
+	// is disabled.
+	Rendezvous string `hcl:"rendezvous,optional"`
+
+	// ConnLowWater is the number of connections to which the connection
+	// manager trims connections when ConnHighWater is exceeded.
+	// If zero, the default value is used.
+	ConnLowWater int `hcl:"conn_low_water,optional"`
+
+	// ConnHighWater is the number of connections above which the connection
+	// manager starts to close connections. If zero, the default value is
+	// used.
+	ConnHighWater int `hcl:"conn_high_water,optional"`
+
+	// ConnGracePeriod is the time in seconds during which new connections
+	// are not closed by the connection manager. If zero, the default value
+	// is used.
+	ConnGracePeriod uint32 `hcl:"conn_grace_period,optional"`
+
 	// EthereumKey is the name of the Ethereum key to use for signing messages.
 	// Required if the transport is used for sending messages.
 	EthereumKey string `hcl:"ethereum_key,optional"`
@@ -202,9 +249,17 @@ func (c *Config) LibP2PBootstrap(d BootstrapDependencies) (transport.Transport,
 		Mode:             libp2p.BootstrapMode,
 		PeerPrivKey:      peerPrivKey,
 		ListenAddrs:      c.LibP2P.ListenAddrs,
+		Transports:       c.LibP2P.Transports,
+		Security:         c.LibP2P.Security,
 		BootstrapAddrs:   c.LibP2P.BootstrapAddrs,
 		DirectPeersAddrs: c.LibP2P.DirectPeersAddrs,
 		BlockedAddrs:     c.LibP2P.BlockedAddrs,
+		AllowedAddrs:     c.LibP2P.AllowedAddrs,
+		EnableMDNS:       c.LibP2P.EnableMDNS,
+		Rendezvous:       c.LibP2P.Rendezvous,
+		ConnLowWater:     c.LibP2P.ConnLowWater,
+		ConnHighWater:    c.LibP2P.ConnHighWater,
+		ConnGracePeriod:  time.Duration(c.LibP2P.ConnGracePeriod) * time.Second,
 		Logger:           d.Logger,
 		AppName:          "bootstrap",
 		AppVersion:       suite.Version,
@@ -344,11 +399,19 @@ func (c *Config) configureLibP2P(d Dependencies) (transport.Transport, error) {
 		Topics:           d.Messages,
 		MessagePrivKey:   messagePrivKey,
 		ListenAddrs:      c.LibP2P.ListenAddrs,
+		Transports:       c.LibP2P.Transports,
+		Security:         c.LibP2P.Security,
 		BootstrapAddrs:   c.LibP2P.BootstrapAddrs,
 		DirectPeersAddrs: c.LibP2P.DirectPeersAddrs,
 		BlockedAddrs:     c.LibP2P.BlockedAddrs,
+		AllowedAddrs:     c.LibP2P.AllowedAddrs,
 		AuthorAllowlist:  c.LibP2P.Feeds,
 		Discovery:        !c.LibP2P.DisableDiscovery,
+		EnableMDNS:       c.LibP2P.EnableMDNS,
+		Rendezvous:       c.LibP2P.Rendezvous,
+		ConnLowWater:     c.LibP2P.ConnLowWater,
+		ConnHighWater:    c.LibP2P.ConnHighWater,
+		ConnGracePeriod:  time.Duration(c.LibP2P.ConnGracePeriod) * time.Second,
 		Signer:           key,
 		Logger:           d.Logger,
 		AppName:          "spire",
@@ -367,6 +430,26 @@ func (c *Config) configureLibP2P(d Dependencies) (transport.Transport, error) {
 }
 
 func (c *Config) generatePrivKey() (crypto.PrivKey, error) {
+	if len(c.LibP2P.PrivKeyFile) != 0 {
+		if len(c.LibP2P.PrivKeySeed) != 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "The priv_key_seed and priv_key_file options cannot be used together",
+				Subject:  c.LibP2P.Content.Attributes["priv_key_file"].Range.Ptr(),
+			}
+		}
+		privKey, err := libp2p.LoadOrCreatePrivKey(c.LibP2P.PrivKeyFile)
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Runtime error",
+				Detail:   fmt.Sprintf("Failed to load LibP2P private key: %v", err),
+				Subject:  c.LibP2P.Content.Attributes["priv_key_file"].Range.Ptr(),
+			}
+		}
+		return privKey, nil
+	}
 	seedReader := rand.Reader
 	if len(c.LibP2P.PrivKeySeed) != 0 {
 		seed, err := hex.DecodeString(c.LibP2P.PrivKeySeed)
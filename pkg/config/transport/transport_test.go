@@ -30,11 +30,19 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, "0x1234567890123456789012345678901234567890", cfg.LibP2P.Feeds[0].String())
 				assert.Equal(t, "0x2345678901234567890123456789012345678901", cfg.LibP2P.Feeds[1].String())
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/6000"}, cfg.LibP2P.ListenAddrs)
+				assert.Equal(t, []string{"tcp", "quic"}, cfg.LibP2P.Transports)
+				assert.Equal(t, []string{"noise"}, cfg.LibP2P.Security)
 				assert.Equal(t, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", cfg.LibP2P.PrivKeySeed)
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/7000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"}, cfg.LibP2P.BootstrapAddrs)
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"}, cfg.LibP2P.DirectPeersAddrs)
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/9000"}, cfg.LibP2P.BlockedAddrs)
+				assert.Equal(t, []string{"/ip4/10.0.0.0/ipcidr/8"}, cfg.LibP2P.AllowedAddrs)
 				assert.Equal(t, true, cfg.LibP2P.DisableDiscovery)
+				assert.Equal(t, true, cfg.LibP2P.EnableMDNS)
+				assert.Equal(t, "rendezvous", cfg.LibP2P.Rendezvous)
+				assert.Equal(t, 10, cfg.LibP2P.ConnLowWater)
+				assert.Equal(t, 20, cfg.LibP2P.ConnHighWater)
+				assert.Equal(t, uint32(30), cfg.LibP2P.ConnGracePeriod)
 				assert.Equal(t, "key", cfg.LibP2P.EthereumKey)
 
 				// WebAPI
@@ -0,0 +1,266 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/defiweb/go-eth/abi"
+)
+
+// ArrayIterator decodes elements of an ABI-encoded dynamic array one at a
+// time. Unlike decoding the whole array using abi.DecodeValue, the data is
+// not converted into words up front. Only the words of the current element
+// are read from the data, so apart from the data itself, the memory usage
+// is bounded by the size of a single element. This allows processing large
+// arrays, e.g. logs fetched during historical backfills.
+//
+// Typical usage:
+//
+//	it, err := ethereum.NewArrayIterator(elemType, data)
+//	if err != nil {
+//		return err
+//	}
+//	for it.Next() {
+//		var v T
+//		if err := it.Decode(&v); err != nil {
+//			return err
+//		}
+//		// Process v.
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ArrayIterator struct {
+	typ    abi.Type
+	data   []byte    // Array elements, without the size word.
+	words  int       // Number of words in data.
+	static int       // Number of words of a static element, 0 if dynamic.
+	size   int       // Number of elements in the array.
+	idx    int       // Index of the next element.
+	head   int       // Index of the next word in the array head.
+	value  abi.Value // Current element.
+	err    error
+}
+
+// NewArrayIterator creates a new iterator over the elements of a dynamic
+// array of the given element type. The data must contain the array encoded
+// as the only value of a tuple, as returned by contract calls or stored in
+// event data. The data is not copied, so it must not be modified while the
+// iterator is in use.
+func NewArrayIterator(t abi.Type, data []byte) (*ArrayIterator, error) {
+	if len(data)%abi.WordLength != 0 {
+		return nil, errors.New("array iterator: data length is not a multiple of word length")
+	}
+	n := len(data) / abi.WordLength
+	if n == 0 {
+		return nil, errors.New("array iterator: empty data")
+	}
+	wordOffset, err := readOffset(readWord(data, 0), n)
+	if err != nil {
+		return nil, fmt.Errorf("array iterator: invalid array offset: %w", err)
+	}
+	size, err := readInt(readWord(data, wordOffset))
+	if err != nil {
+		return nil, fmt.Errorf("array iterator: invalid array length: %w", err)
+	}
+	if size > n-wordOffset-1 {
+		// Every element occupies at least one word in the array head.
+		return nil, fmt.Errorf("array iterator: array length %d exceeds data length", size)
+	}
+	static := 0
+	if v := t.Value(); !v.IsDynamic() {
+		// The size of a static value does not depend on its contents, so
+		// it can be determined by encoding the zero value.
+		w, err := v.EncodeABI()
+		if err != nil {
+			return nil, fmt.Errorf("array iterator: invalid element type: %w", err)
+		}
+		static = len(w)
+	}
+	return &ArrayIterator{
+		typ:    t,
+		data:   data[(wordOffset+1)*abi.WordLength:],
+		words:  n - wordOffset - 1,
+		static: static,
+		size:   size,
+	}, nil
+}
+
+// Len returns the number of elements in the array.
+func (it *ArrayIterator) Len() int {
+	return it.size
+}
+
+// Next decodes the next element of the array. It returns false when there
+// are no more elements or an error occurred. The error can be checked
+// using Err.
+func (it *ArrayIterator) Next() bool {
+	if it.err != nil || it.idx >= it.size {
+		it.value = nil
+		return false
+	}
+	if it.head >= it.words {
+		it.fail(errors.New("unexpected end of data"))
+		return false
+	}
+	var (
+		v   abi.Value
+		err error
+	)
+	if it.static > 0 {
+		v, err = it.decodeStatic()
+	} else {
+		v, err = it.decodeDynamic()
+	}
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+	it.value = v
+	it.idx++
+	return true
+}
+
+// Value returns the current element. It is only valid after a call to Next
+// that returned true.
+func (it *ArrayIterator) Value() abi.Value {
+	return it.value
+}
+
+// Decode maps the current element into the given value, in the same way as
+// abi.DecodeValue does.
+func (it *ArrayIterator) Decode(val any) error {
+	if it.value == nil {
+		return errors.New("array iterator: no current element")
+	}
+	return abi.Default.Mapper.Map(it.value, val)
+}
+
+// Err returns the error that occurred during decoding, if any.
+func (it *ArrayIterator) Err() error {
+	return it.err
+}
+
+func (it *ArrayIterator) fail(err error) {
+	it.err = fmt.Errorf("array iterator: element %d: %w", it.idx, err)
+	it.value = nil
+}
+
+// decodeStatic decodes a static element stored directly in the array head.
+func (it *ArrayIterator) decodeStatic() (abi.Value, error) {
+	if it.head+it.static > it.words {
+		return nil, errors.New("unexpected end of data")
+	}
+	v := it.typ.Value()
+	if _, err := decodeABI(v, it.wordRange(it.head, it.head+it.static)); err != nil {
+		return nil, err
+	}
+	it.head += it.static
+	return v, nil
+}
+
+// decodeDynamic decodes a dynamic element whose offset is stored in the
+// array head.
+//
+// The size of a dynamic element is not known before it is decoded. Encoders
+// store elements in order, so the element is first decoded from the words
+// up to the offset of the next element. Only if that fails, which may happen
+// for valid but unusually laid out data, the element is decoded again from
+// all remaining words.
+func (it *ArrayIterator) decodeDynamic() (abi.Value, error) {
+	start, err := readOffset(readWord(it.data, it.head), it.words)
+	if err != nil {
+		return nil, fmt.Errorf("invalid offset: %w", err)
+	}
+	end := it.words
+	if it.idx+1 < it.size && it.head+1 < it.words {
+		next, err := readOffset(readWord(it.data, it.head+1), it.words)
+		if err == nil && next > start {
+			end = next
+		}
+	}
+	v := it.typ.Value()
+	if _, err := decodeABI(v, it.wordRange(start, end)); err != nil {
+		if end == it.words {
+			return nil, err
+		}
+		v = it.typ.Value()
+		if _, err := decodeABI(v, it.wordRange(start, it.words)); err != nil {
+			return nil, err
+		}
+	}
+	it.head++
+	return v, nil
+}
+
+// wordRange converts the words in the range [from, to) of the array data.
+func (it *ArrayIterator) wordRange(from, to int) abi.Words {
+	return abi.BytesToWords(it.data[from*abi.WordLength : to*abi.WordLength])
+}
+
+// readWord reads the i-th word from the given data.
+func readWord(data []byte, i int) (w abi.Word) {
+	copy(w[:], data[i*abi.WordLength:])
+	return w
+}
+
+// decodeABI decodes the value from the given words. The abi package does
+// not validate lengths of nested values against the int range and may
+// panic on malformed data, so panics are converted into errors.
+func decodeABI(v abi.Value, w abi.Words) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed data: %v", r)
+		}
+	}()
+	return v.DecodeABI(w)
+}
+
+// readOffset reads a byte offset from the given word and converts it to
+// a word index. The offset must be a multiple of the word length and must
+// point within the first n words.
+func readOffset(w abi.Word, n int) (int, error) {
+	offset, err := readInt(w)
+	if err != nil {
+		return 0, err
+	}
+	if offset%abi.WordLength != 0 {
+		return 0, fmt.Errorf("offset %d is not a multiple of word length", offset)
+	}
+	if offset/abi.WordLength >= n {
+		return 0, fmt.Errorf("offset %d exceeds data length", offset)
+	}
+	return offset / abi.WordLength, nil
+}
+
+// readInt reads a non-negative integer that fits in int32 from the given
+// word.
+func readInt(w abi.Word) (int, error) {
+	for _, b := range w[:abi.WordLength-4] {
+		if b != 0 {
+			return 0, errors.New("value out of range")
+		}
+	}
+	v := binary.BigEndian.Uint32(w[abi.WordLength-4:])
+	if v > math.MaxInt32 {
+		return 0, errors.New("value out of range")
+	}
+	return int(v), nil
+}
@@ -0,0 +1,274 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"bytes"
+	"math/big"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// word returns a 32-byte word with the given bytes padded on the left.
+func word(b ...byte) []byte {
+	return append(make([]byte, abi.WordLength-len(b)), b...)
+}
+
+// padRight returns a 32-byte word with the given bytes padded on the right.
+func padRight(b ...byte) []byte {
+	return append(b, make([]byte, abi.WordLength-len(b))...)
+}
+
+func words(ws ...[]byte) []byte {
+	return bytes.Join(ws, nil)
+}
+
+func collect[T any](t *testing.T, it *ArrayIterator) []T {
+	var res []T
+	for it.Next() {
+		var v T
+		require.NoError(t, it.Decode(&v))
+		res = append(res, v)
+	}
+	return res
+}
+
+func TestArrayIterator(t *testing.T) {
+	t.Run("static-elements", func(t *testing.T) {
+		data := abi.MustEncodeValues(abi.MustParseType("(uint256[])"), []uint64{1, 2, 3})
+		it, err := NewArrayIterator(abi.MustParseType("uint256"), data)
+		require.NoError(t, err)
+		assert.Equal(t, 3, it.Len())
+		assert.Equal(t, []uint64{1, 2, 3}, collect[uint64](t, it))
+		assert.NoError(t, it.Err())
+		assert.False(t, it.Next())
+	})
+	t.Run("dynamic-elements", func(t *testing.T) {
+		data := abi.MustEncodeValues(abi.MustParseType("(string[])"), []string{"foo", "", "bar"})
+		it, err := NewArrayIterator(abi.MustParseType("string"), data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"foo", "", "bar"}, collect[string](t, it))
+		assert.NoError(t, it.Err())
+	})
+	t.Run("multi-word-static-elements", func(t *testing.T) {
+		type elem struct {
+			Amount *big.Int      `abi:"amount"`
+			Owner  types.Address `abi:"owner"`
+		}
+		src := []elem{
+			{Amount: big.NewInt(1), Owner: types.MustAddressFromHex("0x1111111111111111111111111111111111111111")},
+			{Amount: big.NewInt(2), Owner: types.MustAddressFromHex("0x2222222222222222222222222222222222222222")},
+		}
+		typ := "(uint256 amount, address owner)"
+		data := abi.MustEncodeValues(abi.MustParseType("("+typ+"[])"), src)
+		it, err := NewArrayIterator(abi.MustParseType(typ), data)
+		require.NoError(t, err)
+		assert.Equal(t, src, collect[elem](t, it))
+		assert.NoError(t, it.Err())
+	})
+	t.Run("empty-array", func(t *testing.T) {
+		data := abi.MustEncodeValues(abi.MustParseType("(uint256[])"), []uint64{})
+		it, err := NewArrayIterator(abi.MustParseType("uint256"), data)
+		require.NoError(t, err)
+		assert.Equal(t, 0, it.Len())
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+	})
+	t.Run("decode-without-next", func(t *testing.T) {
+		data := abi.MustEncodeValues(abi.MustParseType("(uint256[])"), []uint64{1})
+		it, err := NewArrayIterator(abi.MustParseType("uint256"), data)
+		require.NoError(t, err)
+		var v uint64
+		assert.Error(t, it.Decode(&v))
+	})
+}
+
+// allocated returns the number of bytes allocated on the heap while
+// calling fn.
+func allocated(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestArrayIterator_BoundedMemory(t *testing.T) {
+	const limit = 64 * 1024
+	t.Run("static-elements", func(t *testing.T) {
+		src := make([]uint64, 100_000)
+		for i := range src {
+			src[i] = uint64(i)
+		}
+		data := abi.MustEncodeValues(abi.MustParseType("(uint256[])"), src)
+		var it *ArrayIterator
+		n := allocated(func() {
+			var err error
+			it, err = NewArrayIterator(abi.MustParseType("uint256"), data)
+			require.NoError(t, err)
+			for i := 0; i < 10; i++ {
+				require.True(t, it.Next())
+			}
+		})
+		assert.Less(t, n, uint64(limit))
+		assert.Greater(t, len(data), 10*limit)
+		var v uint64
+		require.NoError(t, it.Decode(&v))
+		assert.Equal(t, uint64(9), v)
+	})
+	t.Run("dynamic-elements", func(t *testing.T) {
+		src := make([]string, 10_000)
+		for i := range src {
+			src[i] = strings.Repeat("x", 100)
+		}
+		data := abi.MustEncodeValues(abi.MustParseType("(string[])"), src)
+		var it *ArrayIterator
+		n := allocated(func() {
+			var err error
+			it, err = NewArrayIterator(abi.MustParseType("string"), data)
+			require.NoError(t, err)
+			for i := 0; i < 10; i++ {
+				require.True(t, it.Next())
+			}
+		})
+		assert.Less(t, n, uint64(limit))
+		assert.Greater(t, len(data), 10*limit)
+		// The remaining elements must still be decoded correctly.
+		assert.Len(t, collect[string](t, it), len(src)-10)
+		assert.NoError(t, it.Err())
+	})
+	t.Run("out-of-order-elements", func(t *testing.T) {
+		// Tails of the elements are stored in the reverse order, so the
+		// first element cannot be decoded from the words up to the offset
+		// of the next one.
+		data := words(
+			word(0x20), word(0x02),
+			word(0x80), word(0x40),
+			word(0x01), padRight('b'),
+			word(0x01), padRight('a'),
+		)
+		it, err := NewArrayIterator(abi.MustParseType("string"), data)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, collect[string](t, it))
+		assert.NoError(t, it.Err())
+	})
+}
+
+func TestNewArrayIterator_Malformed(t *testing.T) {
+	max := bytes.Repeat([]byte{0xff}, abi.WordLength)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "truncated-word", data: word(0x20)[:31]},
+		{name: "offset-only", data: word(0x20)},
+		{name: "offset-not-word-aligned", data: words(word(0x21), word(0x01), word(0x01))},
+		{name: "offset-out-of-range", data: words(word(0x40), word(0x01), word(0x01))},
+		{name: "offset-overflow", data: words(max, word(0x01), word(0x01))},
+		{name: "offset-exceeds-int32", data: words(word(0x80, 0, 0, 0), word(0x01))},
+		{name: "length-exceeds-data", data: words(word(0x20), word(0x03), word(0x01), word(0x02))},
+		{name: "length-overflow", data: words(word(0x20), max, word(0x01))},
+		{name: "length-exceeds-int32", data: words(word(0x20), word(0x01, 0, 0, 0, 0), word(0x01))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArrayIterator(abi.MustParseType("uint256"), tt.data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestArrayIterator_MalformedElements(t *testing.T) {
+	max := bytes.Repeat([]byte{0xff}, abi.WordLength)
+	tests := []struct {
+		name  string
+		typ   string
+		data  []byte
+		valid int // Number of elements decoded before the error.
+	}{
+		{
+			// The second element of a static multi-word tuple is cut off.
+			name:  "truncated-static-element",
+			typ:   "(uint256,uint256)",
+			data:  words(word(0x20), word(0x02), word(0x01), word(0x02), word(0x03)),
+			valid: 1,
+		},
+		{
+			name:  "element-offset-not-word-aligned",
+			typ:   "string",
+			data:  words(word(0x20), word(0x01), word(0x21), word(0x00)),
+			valid: 0,
+		},
+		{
+			name:  "element-offset-out-of-range",
+			typ:   "string",
+			data:  words(word(0x20), word(0x01), word(0x40), word(0x00)),
+			valid: 0,
+		},
+		{
+			name:  "element-offset-overflow",
+			typ:   "string",
+			data:  words(word(0x20), word(0x01), max, word(0x00)),
+			valid: 0,
+		},
+		{
+			name:  "element-length-exceeds-data",
+			typ:   "string",
+			data:  words(word(0x20), word(0x01), word(0x20), word(0x40), word('a')),
+			valid: 0,
+		},
+		{
+			name:  "element-length-overflow",
+			typ:   "string",
+			data:  words(word(0x20), word(0x01), word(0x20), max),
+			valid: 0,
+		},
+		{
+			name: "second-element-malformed",
+			typ:  "string",
+			data: words(
+				word(0x20), word(0x02),
+				word(0x40), word(0x80),
+				word(0x01), word('a'),
+				max,
+			),
+			valid: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := NewArrayIterator(abi.MustParseType(tt.typ), tt.data)
+			require.NoError(t, err)
+			n := 0
+			for it.Next() {
+				n++
+			}
+			assert.Equal(t, tt.valid, n)
+			assert.Error(t, it.Err())
+			assert.Nil(t, it.Value())
+			// The iterator must stay in the failed state.
+			assert.False(t, it.Next())
+		})
+	}
+}
@@ -0,0 +1,169 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+)
+
+// ErrorRegistry is a registry of custom errors used to decode errors
+// returned by contract calls. Errors are matched by their four-byte
+// selectors.
+//
+// ErrorRegistry is safe for concurrent use.
+type ErrorRegistry struct {
+	mu     sync.RWMutex
+	errors map[abi.FourBytes]*abi.Error
+}
+
+// NewErrorRegistry creates a new, empty error registry.
+func NewErrorRegistry() *ErrorRegistry {
+	return &ErrorRegistry{errors: make(map[abi.FourBytes]*abi.Error)}
+}
+
+// Register registers the given custom errors.
+//
+// Registering an error with the same signature as an already registered
+// error replaces it. If the selector of an error collides with the selector
+// of a registered error with a different signature, or with the selector of
+// the standard Error(string) or Panic(uint256) errors, an error is returned
+// and none of the given errors are registered.
+func (r *ErrorRegistry) Register(errs ...*abi.Error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	added := make(map[abi.FourBytes]*abi.Error, len(errs))
+	for _, e := range errs {
+		selector := e.FourBytes()
+		if selector == abi.Revert.FourBytes() || selector == abi.Panic.FourBytes() {
+			return fmt.Errorf("error registry: %s collides with the standard %s error", e.Signature(), selector.Hex())
+		}
+		for _, prev := range []*abi.Error{r.errors[selector], added[selector]} {
+			if prev != nil && prev.Signature() != e.Signature() {
+				return fmt.Errorf(
+					"error registry: selector %s of %s collides with %s",
+					selector.Hex(), e.Signature(), prev.Signature(),
+				)
+			}
+		}
+		added[selector] = e
+	}
+	for selector, e := range added {
+		r.errors[selector] = e
+	}
+	return nil
+}
+
+// RegisterSignatures parses the given error signatures and registers them.
+// See abi.ParseError for the signature format.
+func (r *ErrorRegistry) RegisterSignatures(signatures ...string) error {
+	errs := make([]*abi.Error, len(signatures))
+	for i, s := range signatures {
+		e, err := abi.ParseError(s)
+		if err != nil {
+			return fmt.Errorf("error registry: %w", err)
+		}
+		errs[i] = e
+	}
+	return r.Register(errs...)
+}
+
+// Error returns the registered error with the given selector, or nil if
+// there is no such error.
+func (r *ErrorRegistry) Error(selector abi.FourBytes) *abi.Error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.errors[selector]
+}
+
+// DecodeError decodes the error data returned by contract calls and returns
+// a human-readable description of the error.
+//
+// The standard Error(string) and Panic(uint256) errors are decoded as
+// described in DecodeReason. Registered custom errors are decoded into the
+// error name followed by its arguments, e.g.
+// "InsufficientBalance(available: 1, required: 2)". If the selector is not
+// registered or the arguments cannot be decoded, the raw selector and the
+// undecoded data are returned, e.g. "unknown error 0x12345678: 0x...".
+// If the data is shorter than four bytes, it returns an empty string.
+func (r *ErrorRegistry) DecodeError(data []byte) string {
+	if reason := DecodeReason(data); reason != "" {
+		return reason
+	}
+	if len(data) < 4 {
+		return ""
+	}
+	var selector abi.FourBytes
+	copy(selector[:], data[:4])
+	if e := r.Error(selector); e != nil {
+		if s, err := formatError(e, data[4:]); err == nil {
+			return s
+		}
+	}
+	return fmt.Sprintf("unknown error %s: %s", selector.Hex(), hexutil.BytesToHex(data[4:]))
+}
+
+// formatError decodes the error arguments and formats them into a
+// human-readable string.
+func formatError(e *abi.Error, args []byte) (string, error) {
+	elems := e.Inputs().Elements()
+	vals := make([]any, len(elems))
+	ptrs := make([]any, len(elems))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	// abi.Error.DecodeValues is not used because it rejects data with
+	// a matching selector.
+	if err := abi.DecodeValues(e.Inputs(), args, ptrs...); err != nil {
+		return "", err
+	}
+	strs := make([]string, len(elems))
+	for i, elem := range elems {
+		strs[i] = formatErrorValue(vals[i])
+		if elem.Name != "" {
+			strs[i] = elem.Name + ": " + strs[i]
+		}
+	}
+	return e.Name() + "(" + strings.Join(strs, ", ") + ")", nil
+}
+
+// formatErrorValue formats a decoded error argument.
+func formatErrorValue(v any) string {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.BytesToHex(b)
+	}
+	switch v := v.(type) {
+	case []byte:
+		return hexutil.BytesToHex(v)
+	case *big.Int:
+		return v.String()
+	case types.Address:
+		return v.String()
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
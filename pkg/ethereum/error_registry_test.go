@@ -0,0 +1,130 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func errorData(e *abi.Error, args ...any) []byte {
+	return append(e.FourBytes().Bytes(), abi.MustEncodeValues(e.Inputs(), args...)...)
+}
+
+func TestErrorRegistry_DecodeError(t *testing.T) {
+	insufficientBalance := abi.MustParseError("error InsufficientBalance(uint256 available, uint256 required)")
+	unauthorized := abi.MustParseError("error Unauthorized(address caller, bytes32 role)")
+
+	r := NewErrorRegistry()
+	require.NoError(t, r.Register(insufficientBalance, unauthorized))
+
+	caller := types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	role := [32]byte{0xab}
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{
+			name: "custom-error",
+			data: errorData(insufficientBalance, big.NewInt(1), big.NewInt(2)),
+			want: "InsufficientBalance(available: 1, required: 2)",
+		},
+		{
+			name: "custom-error-address-and-bytes",
+			data: errorData(unauthorized, caller, role),
+			want: "Unauthorized(caller: " + caller.String() + ", role: 0xab00000000000000000000000000000000000000000000000000000000000000)",
+		},
+		{
+			name: "revert",
+			data: revertData("foo"),
+			want: "revert: foo",
+		},
+		{
+			name: "panic",
+			data: panicData(big.NewInt(0x11)),
+			want: "panic: arithmetic overflow (0x11)",
+		},
+		{
+			name: "unknown-selector",
+			data: []byte{0x12, 0x34, 0x56, 0x78, 0x01, 0x02},
+			want: "unknown error 0x12345678: 0x0102",
+		},
+		{
+			name: "unknown-selector-without-data",
+			data: []byte{0x12, 0x34, 0x56, 0x78},
+			want: "unknown error 0x12345678: 0x",
+		},
+		{
+			name: "undecodable-arguments",
+			data: errorData(insufficientBalance, big.NewInt(1), big.NewInt(2))[:36],
+			want: "unknown error " + insufficientBalance.FourBytes().Hex() + ": 0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{
+			name: "too-short",
+			data: []byte{0x12, 0x34},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.DecodeError(tt.data))
+		})
+	}
+}
+
+func TestErrorRegistry_Register(t *testing.T) {
+	t.Run("same-signature", func(t *testing.T) {
+		r := NewErrorRegistry()
+		require.NoError(t, r.RegisterSignatures("error Foo(uint256 a)"))
+		// Argument names are not part of the signature, so this replaces
+		// the previous error.
+		require.NoError(t, r.RegisterSignatures("error Foo(uint256 b)"))
+		e := abi.MustParseError("error Foo(uint256)")
+		assert.Equal(t, "Foo(b: 1)", r.DecodeError(errorData(e, big.NewInt(1))))
+	})
+	t.Run("selector-collision", func(t *testing.T) {
+		// Both signatures have the same selector 0x23b872dd.
+		r := NewErrorRegistry()
+		require.NoError(t, r.RegisterSignatures("transferFrom(address,address,uint256)"))
+		err := r.RegisterSignatures("gasprice_bit_ether(int128)")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "0x23b872dd")
+		assert.Equal(t, "transferFrom", r.Error(abi.FourBytes{0x23, 0xb8, 0x72, 0xdd}).Name())
+	})
+	t.Run("selector-collision-in-batch", func(t *testing.T) {
+		r := NewErrorRegistry()
+		err := r.RegisterSignatures("error Bar(uint8)", "transferFrom(address,address,uint256)", "gasprice_bit_ether(int128)")
+		require.Error(t, err)
+		// None of the errors are registered if one of them collides.
+		assert.Nil(t, r.Error(abi.MustParseError("error Bar(uint8)").FourBytes()))
+		assert.Nil(t, r.Error(abi.FourBytes{0x23, 0xb8, 0x72, 0xdd}))
+	})
+	t.Run("standard-error-collision", func(t *testing.T) {
+		r := NewErrorRegistry()
+		assert.Error(t, r.RegisterSignatures("error Error(string)"))
+		assert.Error(t, r.RegisterSignatures("error Panic(uint256)"))
+	})
+	t.Run("invalid-signature", func(t *testing.T) {
+		r := NewErrorRegistry()
+		assert.Error(t, r.RegisterSignatures("error Foo(uint257)"))
+	})
+}
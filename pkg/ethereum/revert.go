@@ -0,0 +1,84 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/defiweb/go-eth/abi"
+)
+
+// panicReasons maps panic codes used by the Solidity compiler to their
+// descriptions.
+//
+// https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized function",
+}
+
+// DecodePanicCode decodes the Panic(uint256) data returned by contract calls
+// and returns the panic code. If the data is not a valid panic message or
+// the code does not fit in uint64, ok is false.
+func DecodePanicCode(data []byte) (code uint64, ok bool) {
+	// abi.DecodePanic pads incomplete words with zeros, so truncated data
+	// would be decoded as a generic panic.
+	if len(data) < len(abi.Panic.FourBytes())+abi.WordLength {
+		return 0, false
+	}
+	c := abi.DecodePanic(data)
+	if c == nil || !c.IsUint64() {
+		return 0, false
+	}
+	return c.Uint64(), true
+}
+
+// DecodeReason decodes the Error(string) or Panic(uint256) data returned by
+// contract calls and returns a human-readable reason, e.g.
+// "revert: insufficient balance" or "panic: arithmetic overflow (0x11)".
+// If the data is neither a valid revert nor a valid panic message, it
+// returns an empty string.
+func DecodeReason(data []byte) string {
+	switch {
+	case abi.IsRevert(data):
+		// abi.DecodeRevert cannot be used here because it does not
+		// distinguish between invalid data and an empty message.
+		var msg string
+		if err := abi.DecodeValues(abi.Revert.Inputs(), data[4:], &msg); err != nil {
+			return ""
+		}
+		return "revert: " + msg
+	case abi.IsPanic(data):
+		code, ok := DecodePanicCode(data)
+		if !ok {
+			return ""
+		}
+		reason, ok := panicReasons[code]
+		if !ok {
+			reason = "unknown panic"
+		}
+		return fmt.Sprintf("panic: %s (0x%02x)", reason, code)
+	}
+	return ""
+}
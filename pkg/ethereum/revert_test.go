@@ -0,0 +1,78 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/stretchr/testify/assert"
+)
+
+func revertData(msg string) []byte {
+	return append(abi.Revert.FourBytes().Bytes(), abi.MustEncodeValues(abi.Revert.Inputs(), msg)...)
+}
+
+func panicData(code *big.Int) []byte {
+	return append(abi.Panic.FourBytes().Bytes(), abi.MustEncodeValues(abi.Panic.Inputs(), code)...)
+}
+
+func TestDecodePanicCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		code   uint64
+		wantOk bool
+	}{
+		{name: "overflow", data: panicData(big.NewInt(0x11)), code: 0x11, wantOk: true},
+		{name: "generic", data: panicData(big.NewInt(0)), code: 0, wantOk: true},
+		{name: "too-large", data: panicData(new(big.Int).Lsh(big.NewInt(1), 64))},
+		{name: "revert", data: revertData("foo")},
+		{name: "truncated", data: panicData(big.NewInt(0x11))[:20]},
+		{name: "empty", data: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := DecodePanicCode(tt.data)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestDecodeReason(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "revert", data: revertData("insufficient balance"), want: "revert: insufficient balance"},
+		{name: "revert-empty-message", data: revertData(""), want: "revert: "},
+		{name: "revert-truncated", data: revertData("foo")[:36], want: ""},
+		{name: "panic", data: panicData(big.NewInt(0x11)), want: "panic: arithmetic overflow (0x11)"},
+		{name: "panic-division-by-zero", data: panicData(big.NewInt(0x12)), want: "panic: division or modulo by zero (0x12)"},
+		{name: "panic-unknown-code", data: panicData(big.NewInt(0x99)), want: "panic: unknown panic (0x99)"},
+		{name: "panic-truncated", data: panicData(big.NewInt(0x11))[:8], want: ""},
+		{name: "unknown-selector", data: []byte{0x12, 0x34, 0x56, 0x78}, want: ""},
+		{name: "empty", data: nil, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DecodeReason(tt.data))
+		})
+	}
+}
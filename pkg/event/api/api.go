@@ -71,9 +71,10 @@ type Config struct {
 }
 
 type jsonEvent struct {
-	Timestamp  int64                    `json:"timestamp"`
-	Data       map[string]string        `json:"data"`
-	Signatures map[string]jsonSignature `json:"signatures"`
+	Timestamp            int64                      `json:"timestamp"`
+	Data                 map[string]string          `json:"data"`
+	Signatures           map[string]jsonSignature   `json:"signatures"`
+	AdditionalSignatures map[string][]jsonSignature `json:"additionalSignatures,omitempty"`
 }
 
 type jsonSignature struct {
@@ -196,6 +197,17 @@ func mapEvents(es []*messages.Event) []*jsonEvent {
 				Signature: hex.EncodeToString(v.Signature),
 			}
 		}
+		for k, v := range e.AdditionalSignatures {
+			if j.AdditionalSignatures == nil {
+				j.AdditionalSignatures = map[string][]jsonSignature{}
+			}
+			for _, s := range v {
+				j.AdditionalSignatures[k] = append(j.AdditionalSignatures[k], jsonSignature{
+					Signer:    hex.EncodeToString(s.Signer),
+					Signature: hex.EncodeToString(s.Signature),
+				})
+			}
+		}
 		r = append(r, j)
 	}
 	return r
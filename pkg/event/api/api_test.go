@@ -90,6 +90,9 @@ func TestEventAPI(t *testing.T) {
 		MessageDate: time.Unix(4, 0),
 		Data:        map[string][]byte{"data_key": []byte("val")},
 		Signatures:  map[string]messages.EventSignature{"sig_key": {Signer: []byte("val"), Signature: []byte("val")}},
+		AdditionalSignatures: map[string][]messages.EventSignature{
+			"sig_key": {{Signer: []byte("val2"), Signature: []byte("val2")}},
+		},
 	}))
 	require.NoError(t, loc.Broadcast(messages.EventV1MessageName, &messages.Event{
 		Type:        "event2", // different type
@@ -117,7 +120,7 @@ func TestEventAPI(t *testing.T) {
 	// Test idx2 with 0x prefix:
 	res, err = http.Get(fmt.Sprintf("http://%s?type=event1&index=0x%x", api.srv.Addr().String(), "idx2"))
 	assert.NoError(t, err)
-	assert.JSONEq(t, `[{"timestamp":3,"data":{"data_key":"76616c"},"signatures":{"sig_key":{"signer":"76616c","signature":"76616c"}}}]`, read(res))
+	assert.JSONEq(t, `[{"timestamp":3,"data":{"data_key":"76616c"},"signatures":{"sig_key":{"signer":"76616c","signature":"76616c"}},"additionalSignatures":{"sig_key":[{"signer":"76616c32","signature":"76616c32"}]}}]`, read(res))
 
 	// Test for empty response:
 	res, err = http.Get(fmt.Sprintf("http://%s?type=event2&index=0xdeadbeef", api.srv.Addr().String()))
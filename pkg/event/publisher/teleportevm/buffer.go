@@ -0,0 +1,142 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+// BufferPolicy specifies what happens when the events buffer is full.
+//
+// Blocking is the safe choice for most consumers. A blocked fetch routine
+// only falls behind the chain and catches up once the consumer reads the
+// buffered events. The last processed block and the checkpoint are updated
+// only after events are sent to the buffer, so no events are lost, even
+// across restarts.
+//
+// Dropping keeps the fetch routine up to date with the chain at the cost of
+// losing events. Dropped events are treated as processed, so they are not
+// fetched again. It should be used only if the consumer can recover missed
+// events by other means.
+//
+// If logs are fetched again, e.g. after a chain reorganization, the two
+// dropping policies behave differently. With BufferDropNewest, a dropped
+// event was never sent to the buffer, so it is not marked as seen by the
+// deduplication cache and is emitted again. With BufferDropOldest, an
+// evicted event had already been sent to the buffer and marked as seen, so
+// it is not emitted again while it remains in the deduplication cache.
+type BufferPolicy int
+
+const (
+	// BufferBlock blocks fetching of new logs until the consumer reads
+	// events from the buffer. No events are lost, but scanning is stalled.
+	BufferBlock BufferPolicy = iota
+
+	// BufferDropOldest removes the oldest event from the buffer to make
+	// room for the new one. Removed events remain marked as seen by the
+	// deduplication cache.
+	BufferDropOldest
+
+	// BufferDropNewest discards the new event if the buffer is full.
+	BufferDropNewest
+)
+
+// ParseBufferPolicy parses the buffer policy name. Valid names are "block",
+// "drop_oldest" and "drop_newest".
+func ParseBufferPolicy(s string) (BufferPolicy, error) {
+	switch s {
+	case "block":
+		return BufferBlock, nil
+	case "drop_oldest":
+		return BufferDropOldest, nil
+	case "drop_newest":
+		return BufferDropNewest, nil
+	}
+	return 0, fmt.Errorf("unknown buffer policy: %s", s)
+}
+
+// String implements the fmt.Stringer interface.
+func (p BufferPolicy) String() string {
+	switch p {
+	case BufferBlock:
+		return "block"
+	case BufferDropOldest:
+		return "drop_oldest"
+	case BufferDropNewest:
+		return "drop_newest"
+	}
+	return fmt.Sprintf("BufferPolicy(%d)", int(p))
+}
+
+// Dropped returns the total number of events dropped because the buffer
+// was full.
+func (ep *EventProvider) Dropped() uint64 {
+	return atomic.LoadUint64(&ep.dropped)
+}
+
+// publish sends the event to the eventCh channel according to the buffer
+// policy. The first returned value reports whether the event was sent to
+// the channel, the second one is false if the context was canceled.
+func (ep *EventProvider) publish(ctx context.Context, evt *messages.Event) (sent, ok bool) {
+	switch ep.bufferPolicy {
+	case BufferDropNewest:
+		select {
+		case ep.eventCh <- evt:
+			sent = true
+		default:
+			ep.drop(evt)
+		}
+		return sent, ctx.Err() == nil
+	case BufferDropOldest:
+		for ctx.Err() == nil {
+			select {
+			case ep.eventCh <- evt:
+				return true, true
+			default:
+			}
+			select {
+			case old := <-ep.eventCh:
+				ep.drop(old)
+			default:
+			}
+		}
+		return false, false
+	default:
+		select {
+		case ep.eventCh <- evt:
+			return true, true
+		case <-ctx.Done():
+			return false, false
+		}
+	}
+}
+
+// drop records that the event was dropped.
+func (ep *EventProvider) drop(evt *messages.Event) {
+	ep.log.
+		WithFields(log.Fields{
+			"policy":  ep.bufferPolicy.String(),
+			"dropped": atomic.AddUint64(&ep.dropped, 1),
+			"index":   hex.EncodeToString(evt.Index),
+		}).
+		Warn("Events buffer is full, event dropped")
+}
@@ -0,0 +1,109 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+func TestParseBufferPolicy(t *testing.T) {
+	for _, p := range []BufferPolicy{BufferBlock, BufferDropOldest, BufferDropNewest} {
+		parsed, err := ParseBufferPolicy(p.String())
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed)
+	}
+	_, err := ParseBufferPolicy("foo")
+	assert.Error(t, err)
+}
+
+func Test_teleportEventProvider_publish(t *testing.T) {
+	tests := []struct {
+		policy      BufferPolicy
+		wantIndexes []byte
+		wantDropped uint64
+	}{
+		{policy: BufferDropOldest, wantIndexes: []byte{2, 3}, wantDropped: 1},
+		{policy: BufferDropNewest, wantIndexes: []byte{1, 2}, wantDropped: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			ep, err := New(Config{
+				Addresses:    []types.Address{teleportTestAddress},
+				Interval:     time.Second,
+				BlockLimit:   1,
+				BufferSize:   2,
+				BufferPolicy: tt.policy,
+			})
+			require.NoError(t, err)
+
+			for i := byte(1); i <= 3; i++ {
+				sent, ok := ep.publish(context.Background(), &messages.Event{Index: []byte{i}})
+				assert.Equal(t, tt.policy == BufferDropOldest || i < 3, sent)
+				assert.True(t, ok)
+			}
+
+			assert.Equal(t, tt.wantDropped, ep.Dropped())
+			for _, idx := range tt.wantIndexes {
+				assert.Equal(t, []byte{idx}, (<-ep.Events()).Index)
+			}
+		})
+	}
+}
+
+func Test_teleportEventProvider_publish_Block(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+
+	ep, err := New(Config{
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 1,
+		BufferSize: 1,
+	})
+	require.NoError(t, err)
+
+	sent, ok := ep.publish(ctx, &messages.Event{Index: []byte{1}})
+	assert.True(t, sent)
+	assert.True(t, ok)
+
+	// The buffer is full, so the publish method must block until the context
+	// is canceled.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancelFunc()
+	}()
+	sent, ok = ep.publish(ctx, &messages.Event{Index: []byte{2}})
+	assert.False(t, sent)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), ep.Dropped())
+}
+
+func TestNew_BufferPolicyWithoutSize(t *testing.T) {
+	_, err := New(Config{
+		Addresses:    []types.Address{teleportTestAddress},
+		Interval:     time.Second,
+		BlockLimit:   1,
+		BufferPolicy: BufferDropOldest,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checkpoint stores the number of the last processed block, so the
+// EventProvider can resume from it after a restart.
+type Checkpoint interface {
+	// Load returns the last saved block number. If no block number has been
+	// saved yet, it returns zero.
+	Load() (uint64, error)
+
+	// Save stores the given block number.
+	Save(block uint64) error
+}
+
+// MemoryCheckpoint is a Checkpoint implementation that stores the block
+// number in memory.
+type MemoryCheckpoint struct {
+	mu    sync.Mutex
+	block uint64
+}
+
+// NewMemoryCheckpoint returns a new instance of the MemoryCheckpoint struct.
+func NewMemoryCheckpoint() *MemoryCheckpoint {
+	return &MemoryCheckpoint{}
+}
+
+// Load implements the Checkpoint interface.
+func (c *MemoryCheckpoint) Load() (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.block, nil
+}
+
+// Save implements the Checkpoint interface.
+func (c *MemoryCheckpoint) Save(block uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.block = block
+	return nil
+}
+
+// FileCheckpoint is a Checkpoint implementation that stores the block number
+// in a file. The file is replaced atomically on every save.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpoint returns a new instance of the FileCheckpoint struct.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load implements the Checkpoint interface.
+func (c *FileCheckpoint) Load() (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	block, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid checkpoint file %s: %w", c.path, err)
+	}
+	return block, nil
+}
+
+// Save implements the Checkpoint interface.
+func (c *FileCheckpoint) Save(block uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	f, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(strconv.FormatUint(block, 10) + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path)
+}
@@ -0,0 +1,65 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCheckpoint(t *testing.T) {
+	c := NewMemoryCheckpoint()
+	block, err := c.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), block)
+
+	require.NoError(t, c.Save(42))
+	block, err = c.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), block)
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	c := NewFileCheckpoint(path)
+
+	// Missing file means that no block was saved yet.
+	block, err := c.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), block)
+
+	require.NoError(t, c.Save(42))
+	require.NoError(t, c.Save(43))
+	block, err = NewFileCheckpoint(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(43), block)
+
+	// Temporary files must be removed.
+	files, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestFileCheckpoint_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, os.WriteFile(path, []byte("foo"), 0o600))
+	_, err := NewFileCheckpoint(path).Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"github.com/defiweb/go-eth/types"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// defaultDedupCacheSize is the default number of recently seen events
+// remembered to avoid emitting the same event twice.
+const defaultDedupCacheSize = 10000
+
+// dedupKey identifies an event emitted in a specific block.
+type dedupKey struct {
+	id        string
+	blockHash types.Hash
+}
+
+// dedup remembers recently seen events. It is safe for concurrent use.
+type dedup struct {
+	seen *lru.Cache[dedupKey, struct{}]
+}
+
+func newDedup(size int) (*dedup, error) {
+	seen, err := lru.New[dedupKey, struct{}](size)
+	if err != nil {
+		return nil, err
+	}
+	return &dedup{seen: seen}, nil
+}
+
+// has reports whether the event emitted by the log was already seen.
+// Events are identified by their ID, which is derived from the configured
+// EventKeyFunc and the event type of the decoder, and by the hash of the
+// block, so logs fetched again after a chain reorganization are not
+// considered duplicates. Logs without a transaction hash or log index are
+// never considered duplicates.
+func (d *dedup) has(l types.Log, id []byte) bool {
+	key, ok := newDedupKey(l, id)
+	return ok && d.seen.Contains(key)
+}
+
+// add marks the event emitted by the log as seen. It should be called only
+// after the event is sent to the events buffer, so events dropped because
+// the buffer was full are emitted again if the log is fetched again.
+func (d *dedup) add(l types.Log, id []byte) {
+	if key, ok := newDedupKey(l, id); ok {
+		d.seen.Add(key, struct{}{})
+	}
+}
+
+func newDedupKey(l types.Log, id []byte) (dedupKey, bool) {
+	if l.TransactionHash == nil || l.LogIndex == nil {
+		return dedupKey{}, false
+	}
+	key := dedupKey{id: string(id)}
+	if l.BlockHash != nil {
+		key.blockHash = *l.BlockHash
+	}
+	return key, true
+}
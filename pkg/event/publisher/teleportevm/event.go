@@ -16,6 +16,7 @@
 package teleportevm
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"time"
@@ -27,8 +28,60 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
-// logToMessage converts a TeleportGUID event to a transport message.
-func logToMessage(l types.Log) (*messages.Event, error) {
+// EventKeyFunc derives a key that uniquely identifies the event emitted by
+// the given log. The key is used to derive the event ID, which is used by
+// the event store to deduplicate events.
+//
+// The key must be different for distinct events and the same for the same
+// event fetched more than once, otherwise events may be dropped or stored
+// multiple times.
+type EventKeyFunc func(l types.Log) []byte
+
+// TeleportEventKey is the default EventKeyFunc for TeleportGUID events.
+// The key is derived from the TeleportGUID, the transaction hash and the
+// log index.
+func TeleportEventKey(l types.Log) []byte {
+	var txHash types.Hash
+	var logIndex uint64
+	if l.TransactionHash != nil {
+		txHash = *l.TransactionHash
+	}
+	if l.LogIndex != nil {
+		logIndex = *l.LogIndex
+	}
+	key := make([]byte, 0, len(l.Data)+types.HashLength+8)
+	key = append(key, l.Data...)
+	key = append(key, txHash.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, logIndex)
+	return key
+}
+
+// LogDecoder converts logs with a specific topic0 into events.
+type LogDecoder interface {
+	// EventType returns the type of events produced by the decoder. It is
+	// used as the event type and to namespace event IDs, so events of
+	// different types never collide in the event store.
+	EventType() string
+
+	// Decode converts a log to an event. The Type and ID fields of the
+	// returned event are set by the EventProvider.
+	Decode(l types.Log) (*messages.Event, error)
+}
+
+// TeleportDecoder is a LogDecoder for TeleportInitialized events.
+type TeleportDecoder struct {
+	// IncludeFields specifies whether the decoded TeleportGUID fields should
+	// be added to the event data. See TeleportGUID.fields for details.
+	IncludeFields bool
+}
+
+// EventType implements the LogDecoder interface.
+func (TeleportDecoder) EventType() string {
+	return TeleportEventType
+}
+
+// Decode implements the LogDecoder interface.
+func (d TeleportDecoder) Decode(l types.Log) (*messages.Event, error) {
 	guid, err := unpackTeleportGUID(l.Data)
 	if err != nil {
 		return nil, err
@@ -41,12 +94,12 @@ func logToMessage(l types.Log) (*messages.Event, error) {
 		"hash":  hash.Bytes(), // Hash to be used to calculate a signature.
 		"event": l.Data,       // Event data.
 	}
+	if d.IncludeFields {
+		for k, v := range guid.fields() {
+			data[k] = v
+		}
+	}
 	return &messages.Event{
-		Type: TeleportEventType,
-		// ID is additionally hashed to ensure that it is not similar to
-		// any other field, so it will not be misused. This field is intended
-		// to be used only be the event store.
-		ID:          crypto.Keccak256(l.TransactionHash.Bytes(), new(big.Int).SetUint64(*l.TransactionIndex).Bytes()).Bytes(),
 		Index:       l.TransactionHash.Bytes(),
 		EventDate:   time.Unix(guid.Timestamp, 0),
 		MessageDate: time.Now(),
@@ -55,9 +108,60 @@ func logToMessage(l types.Log) (*messages.Event, error) {
 	}, nil
 }
 
-// teleportGUID as defined in:
+// decodeLog converts a log to a transport message using the given decoder.
+// It also returns the key used to deduplicate the event, which is derived
+// from the event type and the event key, see eventID.
+//
+// If keyFn is nil, the TeleportEventKey function is used to derive the
+// deduplication key. In that case, events of the TeleportEventType type
+// keep the ID used before event keys were configurable, see
+// legacyTeleportEventID.
+func decodeLog(l types.Log, dec LogDecoder, keyFn EventKeyFunc) (*messages.Event, []byte, error) {
+	evt, err := dec.Decode(l)
+	if err != nil {
+		return nil, nil, err
+	}
+	evt.Type = dec.EventType()
+	if keyFn == nil {
+		keyFn = TeleportEventKey
+		if evt.Type == TeleportEventType {
+			key := eventID(evt.Type, keyFn(l))
+			evt.ID = legacyTeleportEventID(l)
+			return evt, key, nil
+		}
+	}
+	evt.ID = eventID(evt.Type, keyFn(l))
+	return evt, evt.ID, nil
+}
+
+// eventID derives the event ID from the event type and the event key.
+//
+// ID is additionally hashed to ensure that it is not similar to any other
+// field, so it will not be misused. This field is intended to be used only
+// by the event store.
+func eventID(typ string, key []byte) []byte {
+	return crypto.Keccak256([]byte(typ), []byte{0}, key).Bytes()
+}
+
+// legacyTeleportEventID derives the event ID from the transaction hash and
+// the transaction index. The event store identifies events by their ID, so
+// changing it would cause nodes running different versions to store the
+// same event twice.
+func legacyTeleportEventID(l types.Log) []byte {
+	var txHash types.Hash
+	txIndex := new(big.Int)
+	if l.TransactionHash != nil {
+		txHash = *l.TransactionHash
+	}
+	if l.TransactionIndex != nil {
+		txIndex.SetUint64(*l.TransactionIndex)
+	}
+	return crypto.Keccak256(txHash.Bytes(), txIndex.Bytes()).Bytes()
+}
+
+// TeleportGUID as defined in:
 // https://github.com/makerdao/dss-teleport/blob/master/src/TeleportGUID.sol
-type teleportGUID struct {
+type TeleportGUID struct {
 	SourceDomain types.Hash `abi:"sourceDomain"`
 	TargetDomain types.Hash `abi:"targetDomain"`
 	Receiver     types.Hash `abi:"receiver"`
@@ -67,10 +171,35 @@ type teleportGUID struct {
 	Timestamp    int64      `abi:"timestamp"`
 }
 
+// DecodeGUID decodes the ABI encoded TeleportGUID, as emitted in the data of
+// the TeleportInitialized event.
+func DecodeGUID(data []byte) (TeleportGUID, error) {
+	guid, err := unpackTeleportGUID(data)
+	if err != nil {
+		return TeleportGUID{}, err
+	}
+	return *guid, nil
+}
+
+// fields returns the TeleportGUID fields in a form that can be added to the
+// event data. Domains and addresses are returned as 32-byte words, numbers
+// are returned as big-endian encoded unsigned integers.
+func (g *TeleportGUID) fields() map[string][]byte {
+	return map[string][]byte{
+		"sourceDomain": g.SourceDomain.Bytes(),
+		"targetDomain": g.TargetDomain.Bytes(),
+		"receiver":     g.Receiver.Bytes(),
+		"operator":     g.Operator.Bytes(),
+		"amount":       g.Amount.Bytes(),
+		"nonce":        g.Nonce.Bytes(),
+		"timestamp":    big.NewInt(g.Timestamp).Bytes(),
+	}
+}
+
 // hash is used to generate an oracle signature for the TeleportGUID struct.
 // It must be compatible with the following contract:
 // https://github.com/makerdao/dss-teleport/blob/master/src/TeleportGUID.sol
-func (g *teleportGUID) hash() (types.Hash, error) {
+func (g *TeleportGUID) hash() (types.Hash, error) {
 	b, err := packTeleportGUID(g)
 	if err != nil {
 		return types.Hash{}, fmt.Errorf("unable to generate a hash for TeleportGUID: %w", err)
@@ -78,8 +207,8 @@ func (g *teleportGUID) hash() (types.Hash, error) {
 	return crypto.Keccak256(b), nil
 }
 
-// packTeleportGUID converts teleportGUID to ABI encoded data.
-func packTeleportGUID(guid *teleportGUID) ([]byte, error) {
+// packTeleportGUID converts TeleportGUID to ABI encoded data.
+func packTeleportGUID(guid *TeleportGUID) ([]byte, error) {
 	b, err := abi.EncodeValue(abiTeleportGUID, guid)
 	if err != nil {
 		return nil, fmt.Errorf("unable to encode TeleportGUID: %w", err)
@@ -87,16 +216,22 @@ func packTeleportGUID(guid *teleportGUID) ([]byte, error) {
 	return b, nil
 }
 
-// unpackTeleportGUID converts ABI encoded data to teleportGUID.
-func unpackTeleportGUID(data []byte) (*teleportGUID, error) {
+// unpackTeleportGUID converts ABI encoded data to TeleportGUID.
+func unpackTeleportGUID(data []byte) (*TeleportGUID, error) {
+	if len(data) != teleportGUIDSize {
+		return nil, fmt.Errorf("unable to decode TeleportGUID: invalid data length %d", len(data))
+	}
 	x := abiTeleportGUID
-	var guid teleportGUID
+	var guid TeleportGUID
 	if err := abi.DecodeValue(x, data, &guid); err != nil {
 		return nil, fmt.Errorf("unable to decode TeleportGUID: %w", err)
 	}
 	return &guid, nil
 }
 
+// teleportGUIDSize is the size of the ABI encoded TeleportGUID.
+const teleportGUIDSize = 7 * 32
+
 var abiTeleportGUID = abi.MustParseType(
 	`(
 		bytes32 sourceDomain, 
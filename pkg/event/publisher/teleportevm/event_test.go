@@ -22,7 +22,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/defiweb/go-eth/crypto"
 	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/ptrutil"
 )
 
 func Test_packTeleportGUID(t *testing.T) {
@@ -46,3 +50,100 @@ func Test_unpackTeleportGUID(t *testing.T) {
 	assert.Equal(t, big.NewInt(66), g.Nonce)
 	assert.Equal(t, int64(77), g.Timestamp)
 }
+
+func TestTeleportEventKey(t *testing.T) {
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	l1 := types.Log{Data: teleportTestGUID, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1))}
+	l2 := types.Log{Data: teleportTestGUID, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(2))}
+
+	// The same log must always produce the same key.
+	assert.Equal(t, TeleportEventKey(l1), TeleportEventKey(l1))
+
+	// Logs with identical data but different log indices must not collide.
+	assert.NotEqual(t, TeleportEventKey(l1), TeleportEventKey(l2))
+}
+
+func Test_decodeLog_EventKey(t *testing.T) {
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	l := types.Log{Data: teleportTestGUID, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1))}
+
+	evt, key, err := decodeLog(l, TeleportDecoder{}, func(types.Log) []byte { return []byte("key") })
+	require.NoError(t, err)
+	assert.Equal(t, TeleportEventType, evt.Type)
+	assert.Equal(t, crypto.Keccak256([]byte(TeleportEventType), []byte{0}, []byte("key")).Bytes(), evt.ID)
+	assert.Equal(t, evt.ID, key)
+}
+
+func Test_decodeLog_LegacyID(t *testing.T) {
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	l1 := types.Log{Data: teleportTestGUID, TransactionHash: &txHash, TransactionIndex: ptrutil.Ptr(uint64(3)), LogIndex: ptrutil.Ptr(uint64(1))}
+	l2 := types.Log{Data: teleportTestGUID, TransactionHash: &txHash, TransactionIndex: ptrutil.Ptr(uint64(3)), LogIndex: ptrutil.Ptr(uint64(2))}
+
+	evt1, key1, err := decodeLog(l1, TeleportDecoder{}, nil)
+	require.NoError(t, err)
+	evt2, key2, err := decodeLog(l2, TeleportDecoder{}, nil)
+	require.NoError(t, err)
+
+	// Teleport events must keep IDs compatible with older versions.
+	assert.Equal(t, crypto.Keccak256(txHash.Bytes(), big.NewInt(3).Bytes()).Bytes(), evt1.ID)
+	assert.Equal(t, evt1.ID, evt2.ID)
+
+	// Deduplication keys must still distinguish logs within a transaction.
+	assert.Equal(t, eventID(TeleportEventType, TeleportEventKey(l1)), key1)
+	assert.NotEqual(t, key1, key2)
+}
+
+type testDecoder struct{ typ string }
+
+func (d testDecoder) EventType() string { return d.typ }
+
+func (d testDecoder) Decode(types.Log) (*messages.Event, error) {
+	return &messages.Event{}, nil
+}
+
+func Test_decodeLog_NamespacedID(t *testing.T) {
+	l := types.Log{Data: teleportTestGUID}
+	keyFn := func(types.Log) []byte { return []byte("key") }
+
+	evt1, _, err := decodeLog(l, testDecoder{typ: "a"}, keyFn)
+	require.NoError(t, err)
+	evt2, _, err := decodeLog(l, testDecoder{typ: "b"}, keyFn)
+	require.NoError(t, err)
+
+	// Events of different types with the same key must not collide.
+	assert.Equal(t, "a", evt1.Type)
+	assert.Equal(t, "b", evt2.Type)
+	assert.NotEqual(t, evt1.ID, evt2.ID)
+}
+
+func TestDecodeGUID(t *testing.T) {
+	g, err := DecodeGUID(teleportTestGUID)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(55), g.Amount)
+	assert.Equal(t, int64(77), g.Timestamp)
+
+	_, err = DecodeGUID(teleportTestGUID[:len(teleportTestGUID)-1])
+	assert.Error(t, err)
+	_, err = DecodeGUID(append(teleportTestGUID.Bytes(), 0))
+	assert.Error(t, err)
+}
+
+func TestTeleportDecoder_IncludeFields(t *testing.T) {
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	l := types.Log{Data: teleportTestGUID, TransactionHash: &txHash}
+
+	evt, err := TeleportDecoder{}.Decode(l)
+	require.NoError(t, err)
+	assert.Len(t, evt.Data, 2)
+
+	evt, err = TeleportDecoder{IncludeFields: true}.Decode(l)
+	require.NoError(t, err)
+	assert.Equal(t, teleportTestGUID.Bytes(), evt.Data["event"])
+	assert.Equal(t, types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone).Bytes(), evt.Data["sourceDomain"])
+	assert.Equal(t, types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone).Bytes(), evt.Data["targetDomain"])
+	assert.Equal(t, types.MustHashFromHex("0x0000000000000000000000003333333333333333333333333333333333333333", types.PadNone).Bytes(), evt.Data["receiver"])
+	assert.Equal(t, types.MustHashFromHex("0x0000000000000000000000004444444444444444444444444444444444444444", types.PadNone).Bytes(), evt.Data["operator"])
+	assert.Equal(t, []byte{55}, evt.Data["amount"])
+	assert.Equal(t, []byte{66}, evt.Data["nonce"])
+	assert.Equal(t, []byte{77}, evt.Data["timestamp"])
+}
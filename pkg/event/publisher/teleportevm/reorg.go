@@ -0,0 +1,167 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// blockHashes remembers hashes of recent blocks in which events were found
+// and of the last blocks of processed block ranges. It is used to detect
+// chain reorganizations. It is safe for concurrent use.
+type blockHashes struct {
+	mu     sync.Mutex
+	hashes map[uint64]types.Hash
+}
+
+func newBlockHashes() *blockHashes {
+	return &blockHashes{hashes: make(map[uint64]types.Hash)}
+}
+
+// set stores the hash of the given block.
+func (b *blockHashes) set(block uint64, hash types.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hashes[block] = hash
+}
+
+// recent removes blocks older than minBlock and returns the remaining
+// block numbers in ascending order.
+func (b *blockHashes) recent(minBlock uint64) []uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	blocks := make([]uint64, 0, len(b.hashes))
+	for block := range b.hashes {
+		if block < minBlock {
+			delete(b.hashes, block)
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+	return blocks
+}
+
+// get returns the hash of the given block.
+func (b *blockHashes) get(block uint64) (types.Hash, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	hash, ok := b.hashes[block]
+	return hash, ok
+}
+
+// recordBlockHash remembers the hash of the block in which the given log
+// was emitted, if reorg detection is enabled.
+func (ep *EventProvider) recordBlockHash(l types.Log) {
+	if ep.blockHashes == nil || l.BlockNumber == nil || l.BlockHash == nil {
+		return
+	}
+	ep.blockHashes.set(l.BlockNumber.Uint64(), *l.BlockHash)
+}
+
+// recordBlock remembers the hash of the given processed block, if reorg
+// detection is enabled and the block is within the reorg depth from the
+// head. Because every block hash depends on its parent, a reorg of any
+// processed block also changes the hash of the last processed block, so it
+// is enough to track the last block of every processed range.
+func (ep *EventProvider) recordBlock(ctx context.Context, head *big.Int, block *bn.IntNumber) {
+	if ep.blockHashes == nil || block.Sign() < 0 {
+		return
+	}
+	if h := head.Uint64(); h > ep.reorgDepth && block.BigInt().Uint64() < h-ep.reorgDepth {
+		return
+	}
+	b, err := ep.getBlock(ctx, block)
+	if err != nil {
+		if ctx.Err() == nil {
+			ep.log.WithError(err).Error("Unable to get block hash for reorg detection")
+		}
+		return
+	}
+	ep.blockHashes.set(block.BigInt().Uint64(), b.Hash)
+}
+
+// checkReorgs verifies that recent processed blocks are still part of the
+// canonical chain. Tracked blocks are checked starting from the newest one
+// until a block with an unchanged hash is found, because its ancestors
+// cannot have changed either. Usually only a single block is fetched.
+//
+// For every block whose hash has changed, a warning is logged and the
+// OnReorg callback is invoked. Then, logs are fetched again from the blocks
+// after the newest unchanged block, or from all blocks within the reorg
+// depth, so events that were not emitted before, also from blocks that had
+// no events, are sent to the eventCh channel.
+func (ep *EventProvider) checkReorgs(ctx context.Context, head *big.Int) {
+	if ep.blockHashes == nil {
+		return
+	}
+	var minBlock uint64
+	if h := head.Uint64(); h > ep.reorgDepth {
+		minBlock = h - ep.reorgDepth
+	}
+	blocks := ep.blockHashes.recent(minBlock)
+	from, to := minBlock, uint64(0)
+	reorged := false
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+		oldHash, ok := ep.blockHashes.get(block)
+		if !ok {
+			continue
+		}
+		b, err := ep.getBlock(ctx, bn.Int(block))
+		if err != nil {
+			if ctx.Err() == nil {
+				ep.log.WithError(err).Error("Unable to check for chain reorganization")
+			}
+			return
+		}
+		if b.Hash == oldHash {
+			from = block + 1
+			break
+		}
+		ep.log.
+			WithFields(log.Fields{
+				"block":   block,
+				"oldHash": oldHash.String(),
+				"newHash": b.Hash.String(),
+			}).
+			Warn("Chain reorganization detected, fetching blocks again")
+		if ep.onReorg != nil {
+			ep.onReorg(block, oldHash, b.Hash)
+		}
+		ep.blockHashes.set(block, b.Hash)
+		if !reorged {
+			to = block
+			reorged = true
+		}
+	}
+	if !reorged {
+		return
+	}
+	for _, r := range splitBlockRanges(bn.Int(from), bn.Int(to), bn.Int(ep.blockLimit)) {
+		if !ep.handleEvents(ctx, r[0], r[1]) {
+			return
+		}
+	}
+}
@@ -17,7 +17,11 @@ package teleportevm
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
 	"github.com/defiweb/go-eth/wallet"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
@@ -25,20 +29,75 @@ import (
 
 const SignatureKey = "ethereum"
 
+// DefaultHashField is the name of the event data field that holds the hash
+// to sign, used if no other field name is given.
+const DefaultHashField = "hash"
+
 // Signer signs events using Ethereum signature.
 //
-// Signer could only sign events that have a "hash" field in the data. The
-// value of that field is used to calculate the signature. The rest of the
-// fields in the data are ignored. The calculated signature is stored in the
-// "ethereum" field of the event's signatures map.
+// Signer could only sign events that have a hash field in the data, by
+// default named "hash" (see NewSignerWithHashField). The value of that field is used to calculate the
+// signature. The rest of the fields in the data are ignored.
+//
+// Alternatively, if the signer is created using NewSignerOverFields, the
+// signature is calculated over a digest of multiple data fields. See
+// FieldsDigest for the encoding used.
+//
+// The calculated signature is added using the Event.AddSignature method with
+// the "ethereum" scheme, so it is stored in the "ethereum" field of the
+// event's signatures map, or in the event's additional signatures if the
+// event was already signed by another signer.
 type Signer struct {
-	signer wallet.Key
-	types  []string
+	signer    wallet.Key
+	types     []string
+	hashField string
+	fields    []string
 }
 
 // NewSigner returns a new instance of the Signer struct.
 func NewSigner(signer wallet.Key, types []string) *Signer {
-	return &Signer{signer: signer, types: types}
+	return &Signer{signer: signer, types: types, hashField: DefaultHashField}
+}
+
+// NewSignerWithHashField returns a new instance of the Signer struct that
+// signs the value of the given data field instead of the default one.
+// If hashField is empty, DefaultHashField is used.
+func NewSignerWithHashField(signer wallet.Key, types []string, hashField string) *Signer {
+	if hashField == "" {
+		hashField = DefaultHashField
+	}
+	return &Signer{signer: signer, types: types, hashField: hashField}
+}
+
+// NewSignerOverFields returns a new instance of the Signer struct that
+// signs the digest of the given data fields instead of a single hash field.
+// The order of fields is significant. At least one field must be given and
+// field names must not be empty.
+func NewSignerOverFields(signer wallet.Key, types []string, fields []string) (*Signer, error) {
+	if err := validateFields(fields); err != nil {
+		return nil, err
+	}
+	return &Signer{signer: signer, types: types, fields: fields}, nil
+}
+
+// FieldsDigest calculates the digest of the given event data fields.
+//
+// The digest is the keccak256 hash of the concatenation of all fields, in
+// the given order. Every field is encoded as its length, as a 32-byte
+// big-endian integer, followed by its value. This is equivalent to
+// keccak256(abi.encodePacked(uint256(len(f1)), f1, uint256(len(f2)), f2, ...))
+// in Solidity.
+func FieldsDigest(data map[string][]byte, fields []string) (types.Hash, error) {
+	var buf []byte
+	for _, f := range fields {
+		v, ok := data[f]
+		if !ok {
+			return types.Hash{}, fmt.Errorf("missing %s field", f)
+		}
+		buf = append(buf, types.MustHashFromBigInt(big.NewInt(int64(len(v)))).Bytes()...)
+		buf = append(buf, v...)
+	}
+	return crypto.Keccak256(buf), nil
 }
 
 // Sign implements the publisher.EventSigner interface.
@@ -56,20 +115,49 @@ func (l *Signer) Sign(event *messages.Event) (bool, error) {
 	if event.Data == nil {
 		return false, errors.New("event data is nil")
 	}
-	h, ok := event.Data["hash"]
-	if !ok {
-		return false, errors.New("missing hash field")
+	h, err := signedData(event.Data, l.hashField, l.fields)
+	if err != nil {
+		return false, err
 	}
 	s, err := l.signer.SignMessage(h)
 	if err != nil {
 		return false, err
 	}
-	if event.Signatures == nil {
-		event.Signatures = map[string]messages.EventSignature{}
-	}
-	event.Signatures[SignatureKey] = messages.EventSignature{
+	event.AddSignature(SignatureKey, messages.EventSignature{
 		Signer:    l.signer.Address().Bytes(),
 		Signature: s.Bytes(),
-	}
+	})
 	return true, nil
 }
+
+// validateFields checks that the list of fields to sign is not empty and
+// does not contain empty field names.
+func validateFields(fields []string) error {
+	if len(fields) == 0 {
+		return errors.New("at least one field must be given")
+	}
+	for _, f := range fields {
+		if f == "" {
+			return errors.New("field name must not be empty")
+		}
+	}
+	return nil
+}
+
+// signedData returns the data that is signed for the event data. If fields
+// are given, it is the digest of these fields, otherwise it is the value
+// of the hash field.
+func signedData(data map[string][]byte, hashField string, fields []string) ([]byte, error) {
+	if len(fields) > 0 {
+		d, err := FieldsDigest(data, fields)
+		if err != nil {
+			return nil, err
+		}
+		return d.Bytes(), nil
+	}
+	v, ok := data[hashField]
+	if !ok {
+		return nil, fmt.Errorf("missing %s field", hashField)
+	}
+	return v, nil
+}
@@ -16,6 +16,7 @@
 package teleportevm
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/defiweb/go-eth/crypto"
@@ -51,6 +52,26 @@ func TestSigner_MissingHashField(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSigner_CustomHashField(t *testing.T) {
+	address := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	key, err := wallet.NewKeyFromJSON("./keystore/1.json", "test123")
+	require.NoError(t, err)
+	msg := &messages.Event{Type: "foo", Data: map[string][]byte{"digest": common.HexToHash("f76b84eff86432f629ab567880256b50c8eb31cafaec58c5edb24d9b4c246470").Bytes()}}
+
+	// The default field is missing:
+	ok, err := NewSigner(key, []string{"foo"}).Sign(msg)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "missing hash field")
+
+	// The custom field is used:
+	ok, err = NewSignerWithHashField(key, []string{"foo"}, "digest").Sign(msg)
+	assert.True(t, ok)
+	require.NoError(t, err)
+	recovered, err := crypto.ECRecoverer.RecoverMessage(msg.Data["digest"], types.MustSignatureFromBytes(msg.Signatures[SignatureKey].Signature))
+	require.NoError(t, err)
+	assert.Equal(t, address, *recovered)
+}
+
 func TestSigner_Sign(t *testing.T) {
 	address := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
 	key, err := wallet.NewKeyFromJSON("./keystore/1.json", "test123")
@@ -70,3 +91,60 @@ func TestSigner_Sign(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, address, *recovered)
 }
+
+func TestSigner_SignOverFields(t *testing.T) {
+	address := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	key, err := wallet.NewKeyFromJSON("./keystore/1.json", "test123")
+	require.NoError(t, err)
+	msg := &messages.Event{Type: "foo", Data: map[string][]byte{"a": {1, 2}, "b": {3}}}
+	signer, err := NewSignerOverFields(key, []string{"foo"}, []string{"a", "b"})
+	require.NoError(t, err)
+
+	ok, err := signer.Sign(msg)
+	assert.True(t, ok)
+	require.NoError(t, err)
+
+	// Verify signature against the digest:
+	digest, err := FieldsDigest(msg.Data, []string{"a", "b"})
+	require.NoError(t, err)
+	recovered, err := crypto.ECRecoverer.RecoverMessage(digest.Bytes(), types.MustSignatureFromBytes(msg.Signatures[SignatureKey].Signature))
+	require.NoError(t, err)
+	assert.Equal(t, address, *recovered)
+
+	// Verify digest encoding:
+	assert.Equal(t, crypto.Keccak256(
+		types.MustHashFromBigInt(big.NewInt(2)).Bytes(), []byte{1, 2},
+		types.MustHashFromBigInt(big.NewInt(1)).Bytes(), []byte{3},
+	), digest)
+
+	// Order of fields is significant:
+	reversed, err := FieldsDigest(msg.Data, []string{"b", "a"})
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, reversed)
+
+	// Missing field:
+	signer, err = NewSignerOverFields(key, []string{"foo"}, []string{"a", "c"})
+	require.NoError(t, err)
+	ok, err = signer.Sign(msg)
+	assert.False(t, ok)
+	assert.EqualError(t, err, "missing c field")
+}
+
+func TestNewSignerOverFields_InvalidFields(t *testing.T) {
+	key := &mocks.Key{}
+	tests := []struct {
+		name   string
+		fields []string
+	}{
+		{name: "nil", fields: nil},
+		{name: "empty", fields: []string{}},
+		{name: "empty-name", fields: []string{"a", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewSignerOverFields(key, []string{"foo"}, tt.fields)
+			assert.Nil(t, signer)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -16,11 +16,16 @@
 package teleportevm
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"sort"
+	"sync/atomic"
 	"time"
 
+	"github.com/defiweb/go-eth/crypto"
 	"github.com/defiweb/go-eth/types"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
@@ -35,16 +40,25 @@ import (
 const TeleportEventType = "teleport_evm"
 const LoggerTag = "ETHEREUM_TELEPORT"
 
-// retryInterval is the interval between retry attempts in case of an error
-// while communicating with a node.
+// retryInterval is the default initial delay between retry attempts in case
+// of an error while communicating with a node.
 const retryInterval = 5 * time.Second
 
+// maxRetryInterval is the default maximum delay between retry attempts.
+const maxRetryInterval = time.Minute
+
 // teleportTopic0 is Keccak256("TeleportInitialized((bytes32,bytes32,bytes32,bytes32,uint128,uint80,uint48))")
 var teleportTopic0 = types.MustHashFromHex(
 	"0x61aedca97129bac4264ec6356bd1f66431e65ab80e2d07b7983647d72776f545",
 	types.PadNone,
 )
 
+// EventTopic returns the topic0 of logs emitted by the event with the given
+// signature, e.g. "TeleportInitialized((bytes32,bytes32,bytes32,bytes32,uint128,uint80,uint48))".
+func EventTopic(signature string) types.Hash {
+	return crypto.Keccak256([]byte(signature))
+}
+
 // Config contains a configuration options for EventProvider.
 type Config struct {
 	// Client is an instance of Ethereum RPC client.
@@ -67,6 +81,103 @@ type Config struct {
 	// fetching logs.
 	BlockConfirmations uint64
 
+	// EventKey is a function used to derive a key that uniquely identifies
+	// an event. The key is used to deduplicate events and to derive event
+	// IDs. If nil, the TeleportEventKey function is used to deduplicate
+	// events, and TeleportInitialized events keep IDs derived from the
+	// transaction hash and index, so they are compatible with older
+	// versions. Setting this field changes IDs of all events.
+	EventKey EventKeyFunc
+
+	// Topics is a list of topic0 values of logs that are fetched and decoded
+	// as TeleportInitialized events. It can be used for variants of the
+	// Teleport contract that use a different event signature. The EventTopic
+	// function can be used to derive a topic from an event signature. If both
+	// Topics and Decoders are empty, only the TeleportInitialized topic is
+	// used.
+	Topics []types.Hash
+
+	// IncludeGUIDFields specifies whether the decoded TeleportGUID fields
+	// should be added to the data of TeleportInitialized events, so consumers
+	// do not have to decode the ABI encoded GUID themselves. It does not
+	// affect decoders provided in the Decoders field.
+	IncludeGUIDFields bool
+
+	// Decoders maps topic0 of logs to decoders used to convert them into
+	// events. Logs with all listed topics are fetched at once and each log
+	// is converted by the decoder registered for its topic0. Decoders
+	// take precedence over the Topics field.
+	Decoders map[types.Hash]LogDecoder
+
+	// BufferSize specifies how many events can be buffered before they are
+	// read from the channel returned by the Events method. If zero, the
+	// channel is unbuffered.
+	BufferSize int
+
+	// BufferPolicy specifies what happens when the buffer is full. By
+	// default, fetching of new logs is blocked until there is room in the
+	// buffer.
+	BufferPolicy BufferPolicy
+
+	// RetryBaseDelay is the initial delay between retry attempts in case of
+	// an error while communicating with a node. The delay is doubled after
+	// each attempt. If zero, 5 seconds is used.
+	RetryBaseDelay time.Duration
+
+	// RetryMaxDelay is the maximum delay between retry attempts. If zero,
+	// 1 minute is used.
+	RetryMaxDelay time.Duration
+
+	// MaxRetries is the maximum number of retries of a failed request. If
+	// zero, requests are retried until they succeed. Otherwise, after
+	// exhausting retries, the provider logs the error. New block ranges are
+	// fetched again on the next interval, starting from the failed range.
+	// Prefetched block ranges are skipped, so events from them may be
+	// missed.
+	MaxRetries int
+
+	// DedupCacheSize is the number of recently seen events remembered to
+	// avoid emitting the same event twice, e.g. when a log is fetched again
+	// after a failed block range is retried. Events are identified by their
+	// ID, so the EventKey function and the event type of the decoder are
+	// taken into account, and by the block hash, so events from blocks
+	// fetched again after a reorg are emitted. If zero, 10000 is used.
+	DedupCacheSize int
+
+	// Concurrency is the maximum number of block ranges fetched
+	// concurrently when catching up with the blockchain. Events are still
+	// sent to the channel returned by the Events method in order. If zero,
+	// ranges are fetched one by one.
+	Concurrency int
+
+	// ReorgDepth is the number of recent blocks checked for chain
+	// reorganizations. Hashes of blocks in which events were found and of
+	// the last processed blocks are remembered and the newest of them is
+	// verified on every interval. If its hash has changed, a warning is
+	// logged and the logs from the reorganized blocks are fetched again.
+	// If zero, reorg detection is disabled.
+	ReorgDepth uint64
+
+	// OnReorg is called when a chain reorganization is detected with the
+	// block number and its previous and current hashes. Optional.
+	OnReorg func(block uint64, oldHash, newHash types.Hash)
+
+	// Checkpoint is used to persist the number of the last processed block.
+	// If set, the provider resumes fetching events from the block after the
+	// saved one instead of prefetching older events. The checkpoint is saved
+	// after each block range is processed. Optional.
+	Checkpoint Checkpoint
+
+	// OnEventsFetched is called after logs from a block range are fetched
+	// with the number of fetched logs. It can be used to collect metrics.
+	// The function may be called concurrently and must not block. Optional.
+	OnEventsFetched func(count int, fromBlock, toBlock uint64)
+
+	// OnFetchError is called every time a request to a node fails, including
+	// requests that are retried later. It can be used to collect metrics.
+	// The function may be called concurrently and must not block. Optional.
+	OnFetchError func(err error)
+
 	// Logger is a current logger interface used by the EventProvider.
 	Logger log.Logger
 }
@@ -78,7 +189,8 @@ type Config struct {
 //
 // It periodically fetches new TeleportGUID events from the blockchain,
 // converts them into messages.Event and sends them to the channel provided
-// by Events method.
+// by Events method. Other events can be fetched alongside by registering
+// additional decoders in Config.Decoders.
 //
 // During the initial start of the provider it also fetches older blocks
 // until it reaches the block that is older than the prefetch period. This is
@@ -88,7 +200,9 @@ type Config struct {
 // network errors or the node itself, the provider will try to repeat requests
 // to the node indefinitely.
 type EventProvider struct {
-	eventCh chan *messages.Event
+	eventCh   chan *messages.Event
+	dropped   uint64 // number of dropped events, must be accessed atomically
+	lastBlock uint64 // last processed block, must be accessed atomically
 
 	// Configuration parameters copied from Config:
 	client         ethereum.Client //nolint:staticcheck // deprecated
@@ -97,6 +211,22 @@ type EventProvider struct {
 	prefetchPeriod time.Duration
 	blockLimit     uint64
 	blockConfirms  uint64
+	eventKey       EventKeyFunc
+	decoders       map[types.Hash]LogDecoder
+	topics         []types.Hash
+	bufferPolicy   BufferPolicy
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	maxRetries     int
+	dedup          *dedup
+	concurrency    int
+	checkpoint     Checkpoint
+	onFetched      func(count int, fromBlock, toBlock uint64)
+	onFetchError   func(err error)
+	onReorg        func(block uint64, oldHash, newHash types.Hash)
+	reorgDepth     uint64
+	blockHashes    *blockHashes // nil if reorg detection is disabled
+	resumeBlock    uint64
 	log            log.Logger
 
 	// Used in tests only:
@@ -115,17 +245,93 @@ func New(cfg Config) (*EventProvider, error) {
 	if cfg.BlockLimit <= 0 {
 		return nil, errors.New("block limit must be greater than 0")
 	}
+	if cfg.BufferSize < 0 {
+		return nil, errors.New("buffer size must not be negative")
+	}
+	if cfg.BufferSize == 0 && cfg.BufferPolicy != BufferBlock {
+		return nil, errors.New("buffer policy requires a non-zero buffer size")
+	}
+	if cfg.RetryBaseDelay < 0 || cfg.RetryMaxDelay < 0 {
+		return nil, errors.New("retry delays must not be negative")
+	}
+	if cfg.MaxRetries < 0 {
+		return nil, errors.New("max retries must not be negative")
+	}
+	if cfg.Concurrency < 0 {
+		return nil, errors.New("concurrency must not be negative")
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.DedupCacheSize < 0 {
+		return nil, errors.New("dedup cache size must not be negative")
+	}
+	if cfg.DedupCacheSize == 0 {
+		cfg.DedupCacheSize = defaultDedupCacheSize
+	}
+	if cfg.RetryBaseDelay == 0 {
+		cfg.RetryBaseDelay = retryInterval
+	}
+	if cfg.RetryMaxDelay == 0 {
+		cfg.RetryMaxDelay = maxRetryInterval
+	}
+	if cfg.RetryMaxDelay < cfg.RetryBaseDelay {
+		return nil, errors.New("retry max delay must not be less than retry base delay")
+	}
+	decoders := make(map[types.Hash]LogDecoder, len(cfg.Topics)+len(cfg.Decoders))
+	for _, topic := range cfg.Topics {
+		decoders[topic] = TeleportDecoder{IncludeFields: cfg.IncludeGUIDFields}
+	}
+	for topic, dec := range cfg.Decoders {
+		if dec == nil {
+			return nil, fmt.Errorf("decoder for topic %s is nil", topic.String())
+		}
+		decoders[topic] = dec
+	}
+	if len(decoders) == 0 {
+		decoders[teleportTopic0] = TeleportDecoder{IncludeFields: cfg.IncludeGUIDFields}
+	}
+	topics := make([]types.Hash, 0, len(decoders))
+	for topic := range decoders {
+		topics = append(topics, topic)
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		return bytes.Compare(topics[i].Bytes(), topics[j].Bytes()) < 0
+	})
+	dedup, err := newDedup(cfg.DedupCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	var hashes *blockHashes
+	if cfg.ReorgDepth > 0 {
+		hashes = newBlockHashes()
+	}
 	if cfg.Logger == nil {
 		cfg.Logger = null.New()
 	}
 	return &EventProvider{
-		eventCh:        make(chan *messages.Event),
+		eventCh:        make(chan *messages.Event, cfg.BufferSize),
 		client:         cfg.Client,
 		interval:       cfg.Interval,
 		addresses:      cfg.Addresses,
 		prefetchPeriod: cfg.PrefetchPeriod,
 		blockLimit:     cfg.BlockLimit,
 		blockConfirms:  cfg.BlockConfirmations,
+		eventKey:       cfg.EventKey,
+		decoders:       decoders,
+		topics:         topics,
+		bufferPolicy:   cfg.BufferPolicy,
+		retryBaseDelay: cfg.RetryBaseDelay,
+		retryMaxDelay:  cfg.RetryMaxDelay,
+		maxRetries:     cfg.MaxRetries,
+		dedup:          dedup,
+		concurrency:    cfg.Concurrency,
+		checkpoint:     cfg.Checkpoint,
+		onFetched:      cfg.OnEventsFetched,
+		onFetchError:   cfg.OnFetchError,
+		onReorg:        cfg.OnReorg,
+		reorgDepth:     cfg.ReorgDepth,
+		blockHashes:    hashes,
 		log:            cfg.Logger.WithField("tag", LoggerTag),
 	}, nil
 }
@@ -135,8 +341,40 @@ func (ep *EventProvider) Events() chan *messages.Event {
 	return ep.eventCh
 }
 
+// LastProcessedBlock returns the highest block number for which all events
+// were sent to the channel returned by the Events method. It returns zero if
+// no block range has been processed yet.
+func (ep *EventProvider) LastProcessedBlock() uint64 {
+	return atomic.LoadUint64(&ep.lastBlock)
+}
+
+// setLastProcessedBlock updates the last processed block number if the given
+// block is higher than the current one.
+func (ep *EventProvider) setLastProcessedBlock(block uint64) {
+	for {
+		last := atomic.LoadUint64(&ep.lastBlock)
+		if block <= last || atomic.CompareAndSwapUint64(&ep.lastBlock, last, block) {
+			return
+		}
+	}
+}
+
 // Start implements the publisher.EventPublisher interface.
 func (ep *EventProvider) Start(ctx context.Context) error {
+	ep.log.
+		WithFields(log.Fields{
+			"bufferSize":   cap(ep.eventCh),
+			"bufferPolicy": ep.bufferPolicy.String(),
+		}).
+		Info("Starting")
+	if ep.checkpoint != nil {
+		block, err := ep.checkpoint.Load()
+		if err != nil {
+			return fmt.Errorf("unable to load checkpoint: %w", err)
+		}
+		ep.resumeBlock = block
+		ep.setLastProcessedBlock(block)
+	}
 	if !ep.disablePrefetchEventsRoutine {
 		go ep.prefetchEventsRoutine(ctx)
 	}
@@ -149,13 +387,19 @@ func (ep *EventProvider) Start(ctx context.Context) error {
 // prefetchEventsRoutine fetches events from older blocks until it reaches the
 // block that is older than the prefetch period. This is done to fetch events
 // that were emitted before the provider was started.
+//
+// If the provider resumes from a checkpoint, older events were already
+// processed, so nothing is prefetched.
 func (ep *EventProvider) prefetchEventsRoutine(ctx context.Context) {
-	if ep.prefetchPeriod == 0 {
+	if ep.prefetchPeriod == 0 || ep.resumeBlock > 0 {
 		return
 	}
-	latestBlock, ok := ep.getBlockNumber(ctx)
-	if !ok {
-		return // Context was canceled.
+	latestBlock, err := ep.getBlockNumber(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			ep.log.WithError(err).Error("Unable to prefetch events")
+		}
+		return
 	}
 	for d := ep.blockConfirms; ctx.Err() == nil; d += ep.blockLimit {
 		from := bn.Int(latestBlock).Sub(d + ep.blockLimit - 1)
@@ -165,9 +409,12 @@ func (ep *EventProvider) prefetchEventsRoutine(ctx context.Context) {
 		}
 
 		ep.handleEvents(ctx, from, to)
-		ts, ok := ep.getBlockTimestamp(ctx, to)
-		if !ok {
-			return // Context was canceled.
+		ts, err := ep.getBlockTimestamp(ctx, to)
+		if err != nil {
+			if ctx.Err() == nil {
+				ep.log.WithError(err).Error("Unable to prefetch events")
+			}
+			return
 		}
 		if from.Sign() == 0 || time.Since(ts) > ep.prefetchPeriod {
 			return // End of the prefetch period reached.
@@ -178,10 +425,13 @@ func (ep *EventProvider) prefetchEventsRoutine(ctx context.Context) {
 // fetchEventsRoutine periodically fetches new TeleportGUID logs from the
 // blockchain.
 func (ep *EventProvider) fetchEventsRoutine(ctx context.Context) {
-	latestBlock, ok := ep.getBlockNumber(ctx)
-	if !ok {
+	latestBlock, err := ep.getBlockNumber(ctx)
+	if err != nil && ctx.Err() != nil {
 		return // Context was canceled.
 	}
+	if latestBlock != nil {
+		latestBlock = ep.startBlock(latestBlock)
+	}
 	t := time.NewTicker(ep.interval)
 	defer t.Stop()
 	for {
@@ -189,31 +439,198 @@ func (ep *EventProvider) fetchEventsRoutine(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-t.C:
-			currentBlock, ok := ep.getBlockNumber(ctx)
-			if !ok {
-				return // Context was canceled.
+			currentBlock, err := ep.getBlockNumber(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return // Context was canceled.
+				}
+				continue // Try again on the next tick.
 			}
+			if latestBlock == nil {
+				// The initial block number could not be fetched.
+				latestBlock = ep.startBlock(currentBlock)
+				continue
+			}
+			ep.checkReorgs(ctx, currentBlock)
 			if currentBlock.Cmp(latestBlock) <= 0 {
 				continue // There are no new blocks.
 			}
-			ranges := splitBlockRanges(
+			headRanges := splitBlockRanges(
 				bn.Int(latestBlock).Add(bn.Int(1)),
 				bn.Int(currentBlock),
 				bn.Int(ep.blockLimit),
 			)
-			for _, b := range ranges {
-				from := b[0].Sub(bn.Int(ep.blockConfirms))
-				to := b[1].Sub(bn.Int(ep.blockConfirms))
-				ep.handleEvents(ctx, from, to)
+			ranges := make([][2]*bn.IntNumber, len(headRanges))
+			for i, b := range headRanges {
+				ranges[i] = [2]*bn.IntNumber{
+					b[0].Sub(bn.Int(ep.blockConfirms)),
+					b[1].Sub(bn.Int(ep.blockConfirms)),
+				}
+			}
+			// If a range could not be processed, it is fetched again on the
+			// next tick, together with all ranges after it.
+			if n := ep.handleRanges(ctx, ranges); n > 0 {
+				latestBlock = headRanges[n-1][1].BigInt()
+				ep.recordBlock(ctx, currentBlock, ranges[n-1][1])
 			}
-			latestBlock = currentBlock
+			lastProcessed := ep.LastProcessedBlock()
+			ep.log.
+				WithFields(log.Fields{
+					"headBlock":          currentBlock.Uint64(),
+					"lastProcessedBlock": lastProcessed,
+					"lag":                blockLag(currentBlock, lastProcessed),
+				}).
+				Debug("Blocks processed")
 		}
 	}
 }
 
-// handleEvents fetches TeleportGUID events from the given block range and
-// sends them to the eventCh channel.
-func (ep *EventProvider) handleEvents(ctx context.Context, from, to *bn.IntNumber) {
+// blockLag returns the number of blocks between the head block and the last
+// processed block. The last processed block may be ahead of the head block
+// reported by a lagging node, e.g. after resuming from a checkpoint, in which
+// case the lag is zero.
+func blockLag(head *big.Int, lastProcessed uint64) uint64 {
+	if h := head.Uint64(); h > lastProcessed {
+		return h - lastProcessed
+	}
+	return 0
+}
+
+// startBlock returns the block number after which the fetch routine should
+// start fetching events. If the provider resumes from a checkpoint, it is the
+// block of the checkpoint, otherwise it is the current block.
+func (ep *EventProvider) startBlock(head *big.Int) *big.Int {
+	if ep.resumeBlock == 0 {
+		return head
+	}
+	block := new(big.Int).SetUint64(ep.resumeBlock + ep.blockConfirms)
+	if block.Cmp(head) > 0 {
+		return head
+	}
+	return block
+}
+
+// saveCheckpoint saves the given block number in the checkpoint, if one is
+// configured.
+func (ep *EventProvider) saveCheckpoint(block *bn.IntNumber) {
+	if ep.checkpoint == nil || block.Sign() < 0 {
+		return
+	}
+	if err := ep.checkpoint.Save(block.BigInt().Uint64()); err != nil {
+		ep.log.
+			WithError(err).
+			WithField("block", block).
+			Warn("Unable to save checkpoint")
+	}
+}
+
+// handleEvents fetches logs with the configured topics from the given block
+// range, converts them into events and sends them to the eventCh channel.
+//
+// If logs from all addresses are fetched and sent to the channel, the last
+// processed block number is updated and the method returns true.
+func (ep *EventProvider) handleEvents(ctx context.Context, from, to *bn.IntNumber) bool {
+	logs, processed := ep.fetchLogs(ctx, from, to)
+	if ctx.Err() != nil {
+		return false // Context was canceled.
+	}
+	if !ep.publishLogs(ctx, logs) {
+		return false // Context was canceled.
+	}
+	if processed && to.Sign() >= 0 {
+		ep.setLastProcessedBlock(to.BigInt().Uint64())
+	}
+	return processed
+}
+
+// handleRanges works like handleEvents, but for multiple block ranges. Up to
+// the configured number of ranges are fetched concurrently, but events are
+// always sent to the eventCh channel in the order of the ranges. After each
+// range is processed, the checkpoint is saved.
+//
+// Processing stops at the first range for which logs could not be fetched,
+// so that neither the last processed block nor the checkpoint skips over
+// it. Fetching of the following ranges is then canceled, because their logs
+// would be discarded anyway. The method returns the number of processed
+// ranges.
+func (ep *EventProvider) handleRanges(ctx context.Context, ranges [][2]*bn.IntNumber) int {
+	type result struct {
+		logs      []types.Log
+		processed bool
+	}
+
+	// Every range is fetched using its own context, so it is possible to
+	// cancel only the ranges that follow the failed one. All contexts are
+	// canceled when the method returns.
+	ctxs := make([]context.Context, len(ranges))
+	cancels := make([]context.CancelFunc, len(ranges))
+	for i := range ranges {
+		ctxs[i], cancels[i] = context.WithCancel(ctx)
+	}
+	cancelFrom := func(i int) {
+		for _, cancel := range cancels[i:] {
+			cancel()
+		}
+	}
+	defer cancelFrom(0)
+
+	// The semaphore limits the number of ranges that are being fetched or
+	// waiting to be published.
+	sem := make(chan struct{}, ep.concurrency)
+	results := make([]chan result, len(ranges))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+	go func() {
+		for i, r := range ranges {
+			select {
+			case sem <- struct{}{}:
+			case <-ctxs[i].Done():
+				return
+			}
+			go func(i int, from, to *bn.IntNumber) {
+				logs, processed := ep.fetchLogs(ctxs[i], from, to)
+				if !processed {
+					cancelFrom(i + 1)
+				}
+				results[i] <- result{logs: logs, processed: processed}
+			}(i, r[0], r[1])
+		}
+	}()
+	for i, r := range ranges {
+		var res result
+		select {
+		case res = <-results[i]:
+			<-sem
+		case <-ctx.Done():
+			return i
+		}
+		if ctx.Err() != nil {
+			return i // Context was canceled.
+		}
+		if !ep.publishLogs(ctx, res.logs) {
+			return i // Context was canceled.
+		}
+		if !res.processed {
+			return i
+		}
+		if r[1].Sign() >= 0 {
+			ep.setLastProcessedBlock(r[1].BigInt().Uint64())
+			ep.saveCheckpoint(r[1])
+		}
+	}
+	return len(ranges)
+}
+
+// fetchLogs fetches logs with the configured topics from the given block
+// range for all addresses. Logs are sorted by their position in the
+// blockchain.
+//
+// The returned bool is false if logs for any of the addresses could not
+// be fetched.
+func (ep *EventProvider) fetchLogs(ctx context.Context, from, to *bn.IntNumber) ([]types.Log, bool) {
+	var res []types.Log
+	processed := true
 	for _, address := range ep.addresses {
 		ep.log.
 			WithFields(log.Fields{
@@ -222,9 +639,21 @@ func (ep *EventProvider) handleEvents(ctx context.Context, from, to *bn.IntNumbe
 				"address": address.String(),
 			}).
 			Info("Fetching logs")
-		logs, ok := ep.filterLogs(ctx, address, from, to, teleportTopic0)
-		if !ok {
-			return // Context was canceled.
+		logs, err := ep.filterLogs(ctx, address, from, to, ep.topics)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, false // Context was canceled.
+			}
+			ep.log.
+				WithError(err).
+				WithFields(log.Fields{
+					"from":    from,
+					"to":      to,
+					"address": address.String(),
+				}).
+				Error("Unable to fetch logs")
+			processed = false
+			continue
 		}
 		for _, l := range logs {
 			if l.Address != address {
@@ -239,120 +668,173 @@ func (ep *EventProvider) handleEvents(ctx context.Context, from, to *bn.IntNumbe
 					}).
 					Panic("Log emitted by wrong contract")
 			}
-			if l.Removed {
-				// This should never happen. All logs returned by
-				// eth_filterLogs should not be removed.
-				ep.log.
-					WithFields(log.Fields{
-						"address":     l.Address.String(),
-						"blockNumber": l.BlockNumber,
-						"blockHash":   l.BlockHash.String(),
-						"txHash":      l.TransactionHash.String(),
-					}).
-					Warn("Received removed log")
-				continue
-			}
-			evt, err := logToMessage(l)
-			if err != nil {
-				ep.log.
-					WithError(err).
-					Error("Unable to convert log to event")
-				continue
-			}
-			ep.eventCh <- evt
 		}
+		res = append(res, logs...)
+	}
+	sortLogs(res)
+	if ep.onFetched != nil {
+		ep.onFetched(len(res), blockUint64(from), blockUint64(to))
+	}
+	return res, processed
+}
+
+// publishLogs converts logs into events and sends them to the eventCh
+// channel. It returns false if the context was canceled.
+func (ep *EventProvider) publishLogs(ctx context.Context, logs []types.Log) bool {
+	for _, l := range logs {
+		if l.Removed {
+			// This should never happen. All logs returned by
+			// eth_filterLogs should not be removed.
+			ep.log.
+				WithFields(log.Fields{
+					"address":     l.Address.String(),
+					"blockNumber": l.BlockNumber,
+					"blockHash":   l.BlockHash.String(),
+					"txHash":      l.TransactionHash.String(),
+				}).
+				Warn("Received removed log")
+			continue
+		}
+		if len(l.Topics) == 0 {
+			ep.log.
+				WithField("txHash", l.TransactionHash.String()).
+				Warn("Received log without topics")
+			continue
+		}
+		dec, ok := ep.decoders[l.Topics[0]]
+		if !ok {
+			ep.log.
+				WithFields(log.Fields{
+					"txHash": l.TransactionHash.String(),
+					"topic0": l.Topics[0].String(),
+				}).
+				Warn("Received log with unknown topic")
+			continue
+		}
+		evt, key, err := decodeLog(l, dec, ep.eventKey)
+		if err != nil {
+			ep.log.
+				WithError(err).
+				Error("Unable to convert log to event")
+			continue
+		}
+		if ep.dedup.has(l, key) {
+			ep.log.
+				WithFields(log.Fields{
+					"txHash":   l.TransactionHash.String(),
+					"logIndex": *l.LogIndex,
+				}).
+				Debug("Skipping already emitted event")
+			continue
+		}
+		sent, ok := ep.publish(ctx, evt)
+		if sent {
+			ep.dedup.add(l, key)
+		}
+		if !ok {
+			return false // Context was canceled.
+		}
+		ep.recordBlockHash(l)
 	}
+	return true
 }
 
 // getBlockNumber returns the latest block number on the blockchain.
 //
-// The method will try to fetch blocks indefinitely in case of an error.
-// The only way to stop this method from trying again is to cancel the
-// context. In that case, the method will return false as a second return
-// value.
-func (ep *EventProvider) getBlockNumber(ctx context.Context) (*big.Int, bool) {
-	var err error
+// The method retries failed requests as configured in Config. It returns an
+// error if retries are exhausted or the context is canceled.
+func (ep *EventProvider) getBlockNumber(ctx context.Context) (*big.Int, error) {
 	var res *big.Int
-	retry.TryForever(
-		ctx,
-		func() error {
-			res, err = ep.client.BlockNumber(ctx)
-			if err != nil {
-				ep.log.WithError(err).Error("Unable to get block number")
-			}
-			return err
-		},
-		retryInterval,
-	)
-	if ctx.Err() != nil {
-		return nil, false
+	err := ep.retry(ctx, func() (err error) {
+		res, err = ep.client.BlockNumber(ctx)
+		if err != nil {
+			ep.log.WithError(err).Error("Unable to get block number")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	return res, true
+	return res, nil
 }
 
-// getBlockNumber returns the latest block number on the blockchain.
+// getBlockTimestamp returns the timestamp of the given block.
 //
-// The method will try to fetch blocks indefinitely in case of an error.
-// The only way to stop this method from trying again is to cancel the
-// context. In that case, the method will return false as a second return
-// value.
-func (ep *EventProvider) getBlockTimestamp(ctx context.Context, block *bn.IntNumber) (time.Time, bool) {
-	var err error
-	var res any
-	retry.TryForever(
-		ctx,
-		func() error {
-			res, err = ep.client.Block(ethereum.WithBlockNumber(ctx, block.BigInt()))
-			if err != nil {
-				ep.log.WithError(err).Error("Unable to get block timestamp")
-			}
-			return err
-		},
-		retryInterval,
-	)
-	if res == nil || ctx.Err() != nil {
-		return time.Time{}, false
-	}
-	return res.(*types.Block).Timestamp, true
+// The method retries failed requests as configured in Config. It returns an
+// error if retries are exhausted or the context is canceled.
+func (ep *EventProvider) getBlockTimestamp(ctx context.Context, block *bn.IntNumber) (time.Time, error) {
+	res, err := ep.getBlock(ctx, block)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return res.Timestamp, nil
+}
+
+// getBlock returns the block with the given number.
+//
+// The method retries failed requests as configured in Config. It returns an
+// error if retries are exhausted or the context is canceled.
+func (ep *EventProvider) getBlock(ctx context.Context, block *bn.IntNumber) (*types.Block, error) {
+	var res *types.Block
+	err := ep.retry(ctx, func() (err error) {
+		res, err = ep.client.Block(ethereum.WithBlockNumber(ctx, block.BigInt()))
+		if err != nil {
+			ep.log.WithError(err).Error("Unable to get block")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
-// filterLogs fetches TeleportGUID events from the blockchain.
+// filterLogs fetches logs with any of the given topics from the blockchain.
 //
-// The method will try to fetch blocks indefinitely in case of an error.
-// The only way to stop this method from trying again is to cancel the
-// context. In that case, the method will return false as a second return
-// value.
+// The method retries failed requests as configured in Config. It returns an
+// error if retries are exhausted or the context is canceled.
 func (ep *EventProvider) filterLogs(
 	ctx context.Context,
 	addr types.Address,
 	from, to *bn.IntNumber,
-	topic0 types.Hash,
-) ([]types.Log, bool) {
+	topics []types.Hash,
+) ([]types.Log, error) {
 
-	var err error
 	var res []types.Log
-	retry.TryForever(
-		ctx,
-		func() error {
-			fromBlockNumber := types.BlockNumberFromBigInt(from.BigInt())
-			toBlockNumber := types.BlockNumberFromBigInt(to.BigInt())
-			res, err = ep.client.FilterLogs(ctx, types.FilterLogsQuery{
-				FromBlock: &fromBlockNumber,
-				ToBlock:   &toBlockNumber,
-				Address:   []types.Address{addr},
-				Topics:    [][]types.Hash{{topic0}},
-			})
-			if err != nil {
-				ep.log.WithError(err).Error("Unable to filter logs")
-			}
-			return err
-		},
-		retryInterval,
-	)
-	if res == nil || ctx.Err() != nil {
-		return nil, false
-	}
-	return res, true
+	err := ep.retry(ctx, func() (err error) {
+		fromBlockNumber := types.BlockNumberFromBigInt(from.BigInt())
+		toBlockNumber := types.BlockNumberFromBigInt(to.BigInt())
+		res, err = ep.client.FilterLogs(ctx, types.FilterLogsQuery{
+			FromBlock: &fromBlockNumber,
+			ToBlock:   &toBlockNumber,
+			Address:   []types.Address{addr},
+			Topics:    [][]types.Hash{topics},
+		})
+		if err != nil {
+			ep.log.WithError(err).Error("Unable to filter logs")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// retry calls f until it succeeds, the retries are exhausted or the context
+// is canceled. The delay between attempts grows exponentially.
+func (ep *EventProvider) retry(ctx context.Context, f func() error) error {
+	attempts := 0 // Retry forever.
+	if ep.maxRetries > 0 {
+		attempts = ep.maxRetries + 1
+	}
+	return retry.TryWithBackoff(ctx, func() error {
+		err := f()
+		if err != nil && ctx.Err() == nil && ep.onFetchError != nil {
+			ep.onFetchError(err)
+		}
+		return err
+	}, attempts, ep.retryBaseDelay, ep.retryMaxDelay)
 }
 
 // splitBlockRanges splits a block range into smaller ranges of at most
@@ -379,3 +861,41 @@ func splitBlockRanges(from, to, limit *bn.IntNumber) [][2]*bn.IntNumber {
 	}
 	return ranges
 }
+
+// sortLogs sorts logs by the block number, transaction index and log index.
+// Missing values are treated as zero.
+func sortLogs(logs []types.Log) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		a, b := logs[i], logs[j]
+		if c := bigOrZero(a.BlockNumber).Cmp(bigOrZero(b.BlockNumber)); c != 0 {
+			return c < 0
+		}
+		if x, y := uint64OrZero(a.TransactionIndex), uint64OrZero(b.TransactionIndex); x != y {
+			return x < y
+		}
+		return uint64OrZero(a.LogIndex) < uint64OrZero(b.LogIndex)
+	})
+}
+
+func bigOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
+func uint64OrZero(x *uint64) uint64 {
+	if x == nil {
+		return 0
+	}
+	return *x
+}
+
+// blockUint64 converts the block number to uint64. Negative numbers are
+// converted to zero.
+func blockUint64(n *bn.IntNumber) uint64 {
+	if n.Sign() < 0 {
+		return 0
+	}
+	return n.BigInt().Uint64()
+}
@@ -17,8 +17,11 @@ package teleportevm
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,6 +33,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/ptrutil"
 )
@@ -57,8 +61,8 @@ func Test_teleportEventProvider_FetchEventsRoutine(t *testing.T) {
 
 	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
 	logs := []types.Log{
-		{TransactionIndex: ptrutil.Ptr(uint64(1)), Data: teleportTestGUID, TransactionHash: &txHash, Address: teleportTestAddress},
-		{TransactionIndex: ptrutil.Ptr(uint64(2)), Data: teleportTestGUID, TransactionHash: &txHash, Address: teleportTestAddress},
+		{TransactionIndex: ptrutil.Ptr(uint64(1)), Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+		{TransactionIndex: ptrutil.Ptr(uint64(2)), Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
 	}
 
 	cli.On("BlockNumber", ctx).Return(big.NewInt(100), nil).Once()
@@ -66,21 +70,21 @@ func Test_teleportEventProvider_FetchEventsRoutine(t *testing.T) {
 	cli.On("BlockNumber", ctx).Return(big.NewInt(125), nil).Once()
 
 	// First two ranges must be split into two FilterLogs calls to avoid exceeding the block limit.
-	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(100), fq.FromBlock.Big().Uint64()) // latest block minus block confirmations
 		assert.Equal(t, uint64(109), fq.ToBlock.Big().Uint64())   // latest block minus block confirmations minus block limit
 		assert.Equal(t, []types.Address{teleportTestAddress}, fq.Address)
 		assert.Equal(t, [][]types.Hash{{teleportTopic0}}, fq.Topics)
 	})
-	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(110), fq.FromBlock.Big().Uint64())
 		assert.Equal(t, uint64(118), fq.ToBlock.Big().Uint64())
 		assert.Equal(t, []types.Address{teleportTestAddress}, fq.Address)
 		assert.Equal(t, [][]types.Hash{{teleportTopic0}}, fq.Topics)
 	})
-	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(119), fq.FromBlock.Big().Uint64())
 		assert.Equal(t, uint64(124), fq.ToBlock.Big().Uint64())
@@ -113,8 +117,8 @@ func Test_teleportEventProvider_PrefetchEventsRoutine(t *testing.T) {
 
 	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
 	logs := []types.Log{
-		{TransactionIndex: ptrutil.Ptr(uint64(1)), Data: teleportTestGUID, TransactionHash: &txHash, Address: teleportTestAddress},
-		{TransactionIndex: ptrutil.Ptr(uint64(2)), Data: teleportTestGUID, TransactionHash: &txHash, Address: teleportTestAddress},
+		{TransactionIndex: ptrutil.Ptr(uint64(1)), Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+		{TransactionIndex: ptrutil.Ptr(uint64(2)), Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
 	}
 
 	now := time.Now().Unix()
@@ -134,21 +138,21 @@ func Test_teleportEventProvider_PrefetchEventsRoutine(t *testing.T) {
 		assert.Equal(t, uint64(69), blockNumber.Uint64())
 	}).Return(dummyBlock(69, now-160), nil).Once()
 	cli.On("BlockNumber", ctx).Return(big.NewInt(100), nil).Once()
-	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log{}, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{}, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(85), fq.FromBlock.Big().Uint64()) // latest block minus block confirmations minus block limit
 		assert.Equal(t, uint64(99), fq.ToBlock.Big().Uint64())   // latest block minus block confirmations
 		assert.Equal(t, []types.Address{teleportTestAddress}, fq.Address)
 		assert.Equal(t, [][]types.Hash{{teleportTopic0}}, fq.Topics)
 	})
-	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log{}, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{}, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(70), fq.FromBlock.Big().Uint64())
 		assert.Equal(t, uint64(84), fq.ToBlock.Big().Uint64())
 		assert.Equal(t, []types.Address{teleportTestAddress}, fq.Address)
 		assert.Equal(t, [][]types.Hash{{teleportTopic0}}, fq.Topics)
 	})
-	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
 		fq := args.Get(1).(types.FilterLogsQuery)
 		assert.Equal(t, uint64(55), fq.FromBlock.Big().Uint64())
 		assert.Equal(t, uint64(69), fq.ToBlock.Big().Uint64())
@@ -161,6 +165,97 @@ func Test_teleportEventProvider_PrefetchEventsRoutine(t *testing.T) {
 	waitForEvents(ctx, t, ep, 2)
 }
 
+func Test_teleportEventProvider_Decoders(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	otherTopic0 := types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	unknownTopic0 := types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone)
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		Decoders: map[types.Hash]LogDecoder{
+			teleportTopic0: TeleportDecoder{},
+			otherTopic0:    testDecoder{typ: "other"},
+		},
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{otherTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{unknownTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+		{Data: teleportTestGUID, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, [][]types.Hash{{otherTopic0, teleportTopic0}}, fq.Topics)
+	})
+
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+
+	// Logs without topics or with unknown topics must be skipped.
+	require.Len(t, ep.Events(), 2)
+	evt1 := <-ep.Events()
+	evt2 := <-ep.Events()
+	assert.Equal(t, TeleportEventType, evt1.Type)
+	assert.Equal(t, "other", evt2.Type)
+	assert.NotEqual(t, evt1.ID, evt2.ID)
+}
+
+func Test_teleportEventProvider_Retry(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		MaxRetries:     2,
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	// Transient errors must be retried.
+	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log(nil), errors.New("error")).Twice()
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	assert.Len(t, ep.Events(), 1)
+
+	// After exhausting retries, the block range must be skipped.
+	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log(nil), errors.New("error")).Times(3)
+	ep.handleEvents(ctx, bn.Int(3), bn.Int(4))
+	assert.Len(t, ep.Events(), 1)
+	cli.AssertExpectations(t)
+}
+
+func TestNew_InvalidRetryDelays(t *testing.T) {
+	_, err := New(Config{
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     1,
+		RetryBaseDelay: time.Minute,
+		RetryMaxDelay:  time.Second,
+	})
+	assert.Error(t, err)
+}
+
 func waitForEvents(ctx context.Context, t *testing.T, ep *EventProvider, expectedEvents int) {
 	events := 0
 loop:
@@ -184,3 +279,757 @@ func dummyBlock(number uint64, timestamp int64) *types.Block {
 		Timestamp: time.Unix(timestamp, 0),
 	}
 }
+
+func Test_teleportEventProvider_LastProcessedBlock(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		MaxRetries:     1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), ep.LastProcessedBlock())
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	// The last processed block must be updated after events are sent.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	assert.True(t, ep.handleEvents(ctx, bn.Int(1), bn.Int(10)))
+	assert.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(10), ep.LastProcessedBlock())
+
+	// Processing an older range must not move the last processed block back.
+	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log(nil), nil).Once()
+	assert.True(t, ep.handleEvents(ctx, bn.Int(1), bn.Int(5)))
+	assert.Equal(t, uint64(10), ep.LastProcessedBlock())
+
+	// A skipped range must not update the last processed block.
+	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log(nil), errors.New("error")).Twice()
+	assert.False(t, ep.handleEvents(ctx, bn.Int(11), bn.Int(20)))
+	assert.Equal(t, uint64(10), ep.LastProcessedBlock())
+	cli.AssertExpectations(t)
+}
+
+func Test_blockLag(t *testing.T) {
+	assert.Equal(t, uint64(5), blockLag(big.NewInt(15), 10))
+	assert.Equal(t, uint64(0), blockLag(big.NewInt(10), 10))
+	// The last processed block is ahead of the head of a lagging node.
+	assert.Equal(t, uint64(0), blockLag(big.NewInt(5), 10))
+}
+
+func Test_teleportEventProvider_Checkpoint(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFunc()
+
+	checkpoint := NewMemoryCheckpoint()
+	require.NoError(t, checkpoint.Save(95))
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:             cli,
+		Addresses:          []types.Address{teleportTestAddress},
+		Interval:           100 * time.Millisecond,
+		PrefetchPeriod:     100 * time.Second,
+		BlockLimit:         10,
+		BlockConfirmations: 1,
+		Checkpoint:         checkpoint,
+		Logger:             null.New(),
+	})
+	require.NoError(t, err)
+	ep.disablePrefetchEventsRoutine = true
+	ep.disableFetchEventsRoutine = false
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	cli.On("BlockNumber", ctx).Return(big.NewInt(100), nil).Once()
+	cli.On("BlockNumber", ctx).Return(big.NewInt(105), nil)
+
+	// Fetching must resume from the block after the checkpoint.
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, uint64(96), fq.FromBlock.Big().Uint64())
+		assert.Equal(t, uint64(104), fq.ToBlock.Big().Uint64())
+	})
+
+	require.NoError(t, ep.Start(ctx))
+	assert.Equal(t, uint64(95), ep.LastProcessedBlock())
+
+	// Prefetching must be skipped when resuming from a checkpoint.
+	ep.prefetchEventsRoutine(ctx)
+
+	waitForEvents(ctx, t, ep, 1)
+	require.Eventually(t, func() bool {
+		block, err := checkpoint.Load()
+		return err == nil && block == 104
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(104), ep.LastProcessedBlock())
+}
+
+func Test_teleportEventProvider_Dedup(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		DedupCacheSize: 2,
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(2)), Address: teleportTestAddress},
+	}
+	otherLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(3)), Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(4)), Address: teleportTestAddress},
+	}
+
+	// Events with identical data but different log indices must be emitted.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	assert.Len(t, ep.Events(), 2)
+
+	// Already seen logs must not be emitted again.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	assert.Len(t, ep.Events(), 2)
+
+	// Once evicted from the cache, logs are emitted again.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(otherLogs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(3), bn.Int(4))
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	assert.Len(t, ep.Events(), 6)
+	cli.AssertExpectations(t)
+}
+
+func Test_teleportEventProvider_DedupDropped(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:       cli,
+		Addresses:    []types.Address{teleportTestAddress},
+		Interval:     time.Second,
+		BlockLimit:   10,
+		BufferSize:   1,
+		BufferPolicy: BufferDropNewest,
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(2)), Address: teleportTestAddress},
+	}
+
+	// The second event is dropped because the buffer is full.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	require.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(1), ep.Dropped())
+	<-ep.Events()
+
+	// Dropped events must not be marked as seen, so they are emitted once
+	// the logs are fetched again.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	require.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(1), ep.Dropped())
+	cli.AssertExpectations(t)
+}
+
+func Test_teleportEventProvider_DedupDroppedOldest(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:       cli,
+		Addresses:    []types.Address{teleportTestAddress},
+		Interval:     time.Second,
+		BlockLimit:   10,
+		BufferSize:   1,
+		BufferPolicy: BufferDropOldest,
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(2)), Address: teleportTestAddress},
+	}
+
+	// The first event is evicted from the buffer by the second one.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	require.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(1), ep.Dropped())
+	<-ep.Events()
+
+	// Evicted events were already marked as seen, so they are not emitted
+	// again once the logs are fetched again.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	assert.Len(t, ep.Events(), 0)
+	assert.Equal(t, uint64(1), ep.Dropped())
+	cli.AssertExpectations(t)
+}
+
+func Test_teleportEventProvider_DedupEventKey(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	otherTopic := EventTopic("Other(bytes)")
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		Topics:     []types.Hash{teleportTopic0},
+		Decoders: map[types.Hash]LogDecoder{
+			otherTopic: testDecoder{typ: "other"},
+		},
+		// The key ignores the log index, so logs from the same transaction
+		// are considered the same event.
+		EventKey: func(l types.Log) []byte { return l.TransactionHash.Bytes() },
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), Address: teleportTestAddress},
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(2)), Address: teleportTestAddress},
+		// Events of another type with the same key are not duplicates.
+		{Data: teleportTestGUID, Topics: []types.Hash{otherTopic}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(3)), Address: teleportTestAddress},
+	}
+
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+	require.Len(t, ep.Events(), 2)
+	assert.Equal(t, TeleportEventType, (<-ep.Events()).Type)
+	assert.Equal(t, "other", (<-ep.Events()).Type)
+	cli.AssertExpectations(t)
+}
+
+func TestEventTopic(t *testing.T) {
+	assert.Equal(t, teleportTopic0, EventTopic("TeleportInitialized((bytes32,bytes32,bytes32,bytes32,uint128,uint80,uint48))"))
+}
+
+func Test_teleportEventProvider_Topics(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	topic := EventTopic("TestTeleportInitialized((bytes32,bytes32,bytes32,bytes32,uint128,uint80,uint48))")
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		Topics:     []types.Hash{topic},
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{topic}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	// Only the configured topic must be used in the filter.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, [][]types.Hash{{topic}}, fq.Topics)
+	})
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+
+	require.Len(t, ep.Events(), 1)
+	evt := <-ep.Events()
+	assert.Equal(t, TeleportEventType, evt.Type)
+	assert.Equal(t, teleportTestGUID.Bytes(), evt.Data["event"])
+}
+
+func Test_teleportEventProvider_Concurrency(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:      cli,
+		Addresses:   []types.Address{teleportTestAddress},
+		Interval:    time.Second,
+		BlockLimit:  10,
+		BufferSize:  10,
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	ranges := [][2]*bn.IntNumber{
+		{bn.Int(1), bn.Int(10)},
+		{bn.Int(11), bn.Int(20)},
+		{bn.Int(21), bn.Int(30)},
+		{bn.Int(31), bn.Int(40)},
+	}
+
+	var inFlight, maxInFlight int32
+	var expected [][]byte
+	for i, r := range ranges {
+		from := r[0].BigInt().Uint64()
+		// Logs within a range are returned in reverse order.
+		logs := []types.Log{
+			{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, TransactionIndex: ptrutil.Ptr(uint64(2*i + 1)), BlockNumber: big.NewInt(int64(from + 1)), LogIndex: ptrutil.Ptr(uint64(2*i + 1)), Address: teleportTestAddress},
+			{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, TransactionIndex: ptrutil.Ptr(uint64(2 * i)), BlockNumber: big.NewInt(int64(from)), LogIndex: ptrutil.Ptr(uint64(2 * i)), Address: teleportTestAddress},
+		}
+		expected = append(expected, legacyTeleportEventID(logs[1]))
+		expected = append(expected, legacyTeleportEventID(logs[0]))
+
+		// Earlier ranges take longer to fetch.
+		delay := time.Duration(len(ranges)-i) * 20 * time.Millisecond
+		cli.On("FilterLogs", mock.Anything, mock.MatchedBy(func(fq types.FilterLogsQuery) bool {
+			return fq.FromBlock.Big().Uint64() == from
+		})).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				m := atomic.LoadInt32(&maxInFlight)
+				if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+					break
+				}
+			}
+			time.Sleep(delay)
+			atomic.AddInt32(&inFlight, -1)
+		})
+	}
+
+	go ep.handleRanges(ctx, ranges)
+
+	// Events must be emitted in order regardless of the fetch order.
+	for _, id := range expected {
+		select {
+		case evt := <-ep.Events():
+			assert.Equal(t, id, evt.ID)
+		case <-ctx.Done():
+			require.Fail(t, "timeout")
+		}
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+	assert.Eventually(t, func() bool {
+		return ep.LastProcessedBlock() == 40
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_teleportEventProvider_FailedRange(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFunc()
+
+	checkpoint := NewMemoryCheckpoint()
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		Concurrency:    3,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		MaxRetries:     1,
+		Checkpoint:     checkpoint,
+		Logger:         null.New(),
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	ranges := [][2]*bn.IntNumber{
+		{bn.Int(1), bn.Int(10)},
+		{bn.Int(11), bn.Int(20)},
+		{bn.Int(21), bn.Int(30)},
+	}
+	for i, r := range ranges {
+		from := r[0].BigInt().Uint64()
+		logs := []types.Log{
+			{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(i)), Address: teleportTestAddress},
+		}
+		call := cli.On("FilterLogs", mock.Anything, mock.MatchedBy(func(fq types.FilterLogsQuery) bool {
+			return fq.FromBlock.Big().Uint64() == from
+		}))
+		if i == 1 {
+			call.Return([]types.Log(nil), errors.New("error"))
+			continue
+		}
+		call.Return(logs, nil)
+	}
+
+	// Processing must stop at the failed range. Events from later ranges
+	// must not be sent, and neither the last processed block nor the
+	// checkpoint may skip over the failed range.
+	assert.Equal(t, 1, ep.handleRanges(ctx, ranges))
+	assert.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(10), ep.LastProcessedBlock())
+	block, err := checkpoint.Load()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), block)
+
+	// If the first range fails, nothing is processed.
+	assert.Equal(t, 0, ep.handleRanges(ctx, ranges[1:]))
+	assert.Len(t, ep.Events(), 1)
+	assert.Equal(t, uint64(10), ep.LastProcessedBlock())
+}
+
+func Test_teleportEventProvider_FailedRangeCancel(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		Concurrency:    3,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		MaxRetries:     1,
+		Logger:         null.New(),
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), Address: teleportTestAddress},
+	}
+	matchFrom := func(from uint64) any {
+		return mock.MatchedBy(func(fq types.FilterLogsQuery) bool {
+			return fq.FromBlock.Big().Uint64() == from
+		})
+	}
+	startedCh := make(chan struct{})
+	canceledCh := make(chan struct{})
+
+	// The first range is still being fetched when the second one fails.
+	var canceledFirst bool
+	cli.On("FilterLogs", mock.Anything, matchFrom(1)).Return(logs, nil).Once().Run(func(mock.Arguments) {
+		select {
+		case <-canceledCh:
+			canceledFirst = true
+		case <-time.After(time.Second):
+		}
+	})
+	cli.On("FilterLogs", mock.Anything, matchFrom(11)).Return([]types.Log(nil), errors.New("error")).Run(func(mock.Arguments) {
+		<-startedCh
+	})
+	// The third range is fetched until its context is canceled.
+	cli.On("FilterLogs", mock.Anything, matchFrom(21)).Return([]types.Log(nil), errors.New("canceled")).Once().Run(func(args mock.Arguments) {
+		close(startedCh)
+		select {
+		case <-args.Get(0).(context.Context).Done():
+			close(canceledCh)
+		case <-ctx.Done():
+		}
+	})
+
+	// Fetching of the range that follows the failed one must be canceled
+	// as soon as the failure occurs, without waiting for earlier ranges and
+	// without canceling the context passed to handleRanges.
+	assert.Equal(t, 1, ep.handleRanges(ctx, [][2]*bn.IntNumber{
+		{bn.Int(1), bn.Int(10)},
+		{bn.Int(11), bn.Int(20)},
+		{bn.Int(21), bn.Int(30)},
+	}))
+	assert.True(t, canceledFirst, "fetching of the third range was not canceled")
+	assert.NoError(t, ctx.Err())
+	assert.Len(t, ep.Events(), 1)
+}
+
+func Test_teleportEventProvider_FetchEventsRoutine_RetryFailedRange(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       100 * time.Millisecond,
+		BlockLimit:     10,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		MaxRetries:     1,
+		Logger:         null.New(),
+	})
+	require.NoError(t, err)
+	ep.disablePrefetchEventsRoutine = true
+	ep.disableFetchEventsRoutine = false
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	cli.On("BlockNumber", ctx).Return(big.NewInt(100), nil).Once()
+	cli.On("BlockNumber", ctx).Return(big.NewInt(110), nil)
+
+	// The failed range must be fetched again on the next tick.
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log(nil), errors.New("error")).Twice()
+	cli.On("FilterLogs", mock.Anything, mock.Anything).Return(logs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, uint64(101), fq.FromBlock.Big().Uint64())
+		assert.Equal(t, uint64(110), fq.ToBlock.Big().Uint64())
+	})
+
+	require.NoError(t, ep.Start(ctx))
+	waitForEvents(ctx, t, ep, 1)
+	assert.Eventually(t, func() bool {
+		return ep.LastProcessedBlock() == 110
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_teleportEventProvider_Callbacks(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	type fetched struct {
+		count     int
+		fromBlock uint64
+		toBlock   uint64
+	}
+	var fetchedCalls []fetched
+	var fetchErrors []error
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:         cli,
+		Addresses:      []types.Address{teleportTestAddress},
+		Interval:       time.Second,
+		BlockLimit:     10,
+		BufferSize:     10,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  time.Millisecond,
+		OnEventsFetched: func(count int, fromBlock, toBlock uint64) {
+			fetchedCalls = append(fetchedCalls, fetched{count: count, fromBlock: fromBlock, toBlock: toBlock})
+		},
+		OnFetchError: func(err error) {
+			fetchErrors = append(fetchErrors, err)
+		},
+	})
+	require.NoError(t, err)
+
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	logs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, Address: teleportTestAddress},
+	}
+
+	cli.On("FilterLogs", ctx, mock.Anything).Return([]types.Log(nil), errors.New("error")).Once()
+	cli.On("FilterLogs", ctx, mock.Anything).Return(logs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(2))
+
+	assert.Equal(t, []fetched{{count: 1, fromBlock: 1, toBlock: 2}}, fetchedCalls)
+	require.Len(t, fetchErrors, 1)
+	assert.EqualError(t, fetchErrors[0], "error")
+}
+
+func Test_teleportEventProvider_Reorg(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	type reorg struct {
+		block   uint64
+		oldHash types.Hash
+		newHash types.Hash
+	}
+	var reorgs []reorg
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		ReorgDepth: 10,
+		OnReorg: func(block uint64, oldHash, newHash types.Hash) {
+			reorgs = append(reorgs, reorg{block: block, oldHash: oldHash, newHash: newHash})
+		},
+	})
+	require.NoError(t, err)
+
+	oldHash := types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	newHash := types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone)
+	txHash1 := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	txHash2 := types.MustHashFromHex("0x77e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	oldLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash1, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(5), BlockHash: &oldHash, Address: teleportTestAddress},
+	}
+	newLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash2, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(5), BlockHash: &newHash, Address: teleportTestAddress},
+	}
+
+	cli.On("FilterLogs", ctx, mock.Anything).Return(oldLogs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(10))
+	require.Len(t, ep.Events(), 1)
+	<-ep.Events()
+
+	// The block hash has not changed.
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(5), Hash: oldHash}, nil).Once()
+	ep.checkReorgs(ctx, big.NewInt(12))
+	assert.Empty(t, reorgs)
+
+	// The block hash has changed and there is no older tracked block, so
+	// all blocks within the reorg depth up to that block must be fetched
+	// again.
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(5), Hash: newHash}, nil).Once()
+	cli.On("FilterLogs", ctx, mock.Anything).Return(newLogs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, uint64(3), fq.FromBlock.Big().Uint64())
+		assert.Equal(t, uint64(5), fq.ToBlock.Big().Uint64())
+	})
+	ep.checkReorgs(ctx, big.NewInt(13))
+	assert.Equal(t, []reorg{{block: 5, oldHash: oldHash, newHash: newHash}}, reorgs)
+	require.Len(t, ep.Events(), 1)
+	evt := <-ep.Events()
+	assert.Equal(t, txHash2.Bytes(), evt.Index)
+
+	// Blocks older than the reorg depth are no longer checked.
+	ep.checkReorgs(ctx, big.NewInt(16))
+	cli.AssertExpectations(t)
+}
+
+func Test_teleportEventProvider_ReorgEmptyBlock(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	var reorgs []uint64
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		ReorgDepth: 10,
+		OnReorg: func(block uint64, oldHash, newHash types.Hash) {
+			reorgs = append(reorgs, block)
+		},
+	})
+	require.NoError(t, err)
+
+	hash5 := types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	oldHash10 := types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone)
+	newHash10 := types.MustHashFromHex("0x3333333333333333333333333333333333333333333333333333333333333333", types.PadNone)
+	hash8 := types.MustHashFromHex("0x4444444444444444444444444444444444444444444444444444444444444444", types.PadNone)
+	txHash1 := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	txHash2 := types.MustHashFromHex("0x77e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	oldLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash1, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(5), BlockHash: &hash5, Address: teleportTestAddress},
+	}
+	newLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash2, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(8), BlockHash: &hash8, Address: teleportTestAddress},
+	}
+
+	// Blocks 1-10 are processed, events are found only in block 5.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(oldLogs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(10))
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(10), Hash: oldHash10}, nil).Once()
+	ep.recordBlock(ctx, big.NewInt(12), bn.Int(10))
+	require.Len(t, ep.Events(), 1)
+	<-ep.Events()
+
+	// The reorg adds an event to block 8, which had no events before. The
+	// last processed block has changed, but block 5 has not, so only blocks
+	// after block 5 are fetched again.
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(10), Hash: newHash10}, nil).Once()
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(5), Hash: hash5}, nil).Once()
+	cli.On("FilterLogs", ctx, mock.Anything).Return(newLogs, nil).Once().Run(func(args mock.Arguments) {
+		fq := args.Get(1).(types.FilterLogsQuery)
+		assert.Equal(t, uint64(6), fq.FromBlock.Big().Uint64())
+		assert.Equal(t, uint64(10), fq.ToBlock.Big().Uint64())
+	})
+	ep.checkReorgs(ctx, big.NewInt(13))
+	assert.Equal(t, []uint64{10}, reorgs)
+	require.Len(t, ep.Events(), 1)
+	evt := <-ep.Events()
+	assert.Equal(t, txHash2.Bytes(), evt.Index)
+
+	// Without a reorg, only the newest tracked block is fetched.
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(10), Hash: newHash10}, nil).Once()
+	ep.checkReorgs(ctx, big.NewInt(14))
+	assert.Equal(t, []uint64{10}, reorgs)
+	cli.AssertExpectations(t)
+}
+
+func Test_teleportEventProvider_ReorgDedup(t *testing.T) {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), time.Second)
+	defer cancelFunc()
+
+	cli := &mocks.Client{}
+	ep, err := New(Config{
+		Client:     cli,
+		Addresses:  []types.Address{teleportTestAddress},
+		Interval:   time.Second,
+		BlockLimit: 10,
+		BufferSize: 10,
+		ReorgDepth: 10,
+		// The key ignores the log data, so only the block hash distinguishes
+		// the logs below.
+		EventKey: func(l types.Log) []byte {
+			return binary.BigEndian.AppendUint64(l.TransactionHash.Bytes(), *l.LogIndex)
+		},
+	})
+	require.NoError(t, err)
+
+	oldHash := types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	newHash := types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone)
+	txHash := types.MustHashFromHex("0x66e8ab5a41d4b109c7f6ea5303e3c292771e57fb0b93a8474ca6f72e53eac0e8", types.PadNone)
+	newData := append([]byte{}, teleportTestGUID...)
+	newData[len(newData)-1] = 0x4e // Different timestamp.
+	oldLogs := []types.Log{
+		{Data: teleportTestGUID, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(5), BlockHash: &oldHash, Address: teleportTestAddress},
+	}
+	newLogs := []types.Log{
+		{Data: newData, Topics: []types.Hash{teleportTopic0}, TransactionHash: &txHash, LogIndex: ptrutil.Ptr(uint64(1)), BlockNumber: big.NewInt(5), BlockHash: &newHash, Address: teleportTestAddress},
+	}
+
+	cli.On("FilterLogs", ctx, mock.Anything).Return(oldLogs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(10))
+	require.Len(t, ep.Events(), 1)
+	<-ep.Events()
+
+	// Logs fetched again from the same block are duplicates.
+	cli.On("FilterLogs", ctx, mock.Anything).Return(oldLogs, nil).Once()
+	ep.handleEvents(ctx, bn.Int(1), bn.Int(10))
+	require.Len(t, ep.Events(), 0)
+
+	// After a reorg, the same log index comes back with different data in
+	// the new block. It must not be dropped by the dedup cache.
+	cli.On("Block", mock.Anything).Return(&types.Block{Number: big.NewInt(5), Hash: newHash}, nil).Once()
+	cli.On("FilterLogs", ctx, mock.Anything).Return(newLogs, nil).Once()
+	ep.checkReorgs(ctx, big.NewInt(12))
+	require.Len(t, ep.Events(), 1)
+	evt := <-ep.Events()
+	assert.Equal(t, newData, evt.Data["event"])
+	cli.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+var (
+	ErrMissingSignature = errors.New("missing ethereum signature")
+	ErrSignerMismatch   = errors.New("recovered signer does not match the declared signer")
+	ErrSignerNotAllowed = errors.New("signer is not allowed")
+)
+
+// Verifier verifies signatures created by the Signer.
+//
+// The signature is read from the "ethereum" field of the event's signatures
+// map and the signer address is recovered from it. The event is valid only
+// if the recovered address matches the declared signer and is on the list of
+// allowed addresses.
+type Verifier struct {
+	recoverer crypto.Recoverer
+	allowed   []types.Address
+	hashField string
+	fields    []string
+}
+
+// NewVerifier returns a new instance of the Verifier struct that verifies
+// signatures created by a Signer returned from NewSigner.
+func NewVerifier(recoverer crypto.Recoverer, allowed []types.Address) *Verifier {
+	return &Verifier{recoverer: recoverer, allowed: allowed, hashField: DefaultHashField}
+}
+
+// NewVerifierWithHashField returns a new instance of the Verifier struct that
+// verifies signatures created by a Signer returned from
+// NewSignerWithHashField. If hashField is empty, DefaultHashField is used.
+func NewVerifierWithHashField(recoverer crypto.Recoverer, allowed []types.Address, hashField string) *Verifier {
+	if hashField == "" {
+		hashField = DefaultHashField
+	}
+	return &Verifier{recoverer: recoverer, allowed: allowed, hashField: hashField}
+}
+
+// NewVerifierOverFields returns a new instance of the Verifier struct that
+// verifies signatures created by a Signer returned from NewSignerOverFields.
+// At least one field must be given and field names must not be empty.
+func NewVerifierOverFields(recoverer crypto.Recoverer, allowed []types.Address, fields []string) (*Verifier, error) {
+	if err := validateFields(fields); err != nil {
+		return nil, err
+	}
+	return &Verifier{recoverer: recoverer, allowed: allowed, fields: fields}, nil
+}
+
+// Verify recovers the signer of the event and verifies that it is allowed.
+// The recovered address is returned even if the signer is not allowed.
+func (v *Verifier) Verify(event *messages.Event) (*types.Address, error) {
+	s, ok := event.Signatures[SignatureKey]
+	if !ok {
+		return nil, ErrMissingSignature
+	}
+	h, err := signedData(event.Data, v.hashField, v.fields)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := types.SignatureFromBytes(s.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	from, err := v.recoverer.RecoverMessage(h, sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer, err := types.AddressFromBytes(s.Signer); err != nil || signer != *from {
+		return from, ErrSignerMismatch
+	}
+	for _, a := range v.allowed {
+		if a == *from {
+			return from, nil
+		}
+	}
+	return from, ErrSignerNotAllowed
+}
+
+// SignedData returns the rule used to derive the signed data from the event
+// data, as configured for the verifier.
+func (v *Verifier) SignedData() messages.SignedDataFunc {
+	return func(data map[string][]byte) ([]byte, error) {
+		return signedData(data, v.hashField, v.fields)
+	}
+}
+
+// VerifyQuorum verifies that at least threshold of the allowed signers
+// signed the event, using the same signed data as the Verify method. See
+// Event.VerifyQuorum for details.
+func (v *Verifier) VerifyQuorum(event *messages.Event, threshold int) error {
+	return event.VerifyQuorum(v.recoverer, v.allowed, threshold, v.SignedData())
+}
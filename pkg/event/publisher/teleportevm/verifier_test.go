@@ -0,0 +1,147 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package teleportevm
+
+import (
+	"testing"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	address := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	key, err := wallet.NewKeyFromJSON("./keystore/1.json", "test123")
+	require.NoError(t, err)
+	msg := &messages.Event{Type: "foo", Data: map[string][]byte{"hash": common.HexToHash("f76b84eff86432f629ab567880256b50c8eb31cafaec58c5edb24d9b4c246470").Bytes()}}
+	_, err = NewSigner(key, []string{"foo"}).Sign(msg)
+	require.NoError(t, err)
+
+	// Allowed signer:
+	from, err := NewVerifier(crypto.ECRecoverer, []types.Address{address}).Verify(msg)
+	require.NoError(t, err)
+	assert.Equal(t, address, *from)
+
+	// Not allowed signer:
+	from, err = NewVerifier(crypto.ECRecoverer, nil).Verify(msg)
+	assert.ErrorIs(t, err, ErrSignerNotAllowed)
+	assert.Equal(t, address, *from)
+
+	// Modified hash:
+	msg.Data["hash"] = common.HexToHash("01").Bytes()
+	_, err = NewVerifier(crypto.ECRecoverer, []types.Address{address}).Verify(msg)
+	assert.ErrorIs(t, err, ErrSignerMismatch)
+
+	// Missing signature:
+	delete(msg.Signatures, SignatureKey)
+	_, err = NewVerifier(crypto.ECRecoverer, []types.Address{address}).Verify(msg)
+	assert.ErrorIs(t, err, ErrMissingSignature)
+}
+
+func TestVerifier_VerifyOverFields(t *testing.T) {
+	address := types.MustAddressFromHex("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	key, err := wallet.NewKeyFromJSON("./keystore/1.json", "test123")
+	require.NoError(t, err)
+	msg := &messages.Event{Type: "foo", Data: map[string][]byte{"a": {1, 2}, "b": {3}}}
+	signer, err := NewSignerOverFields(key, []string{"foo"}, []string{"a", "b"})
+	require.NoError(t, err)
+	_, err = signer.Sign(msg)
+	require.NoError(t, err)
+
+	verifier, err := NewVerifierOverFields(crypto.ECRecoverer, []types.Address{address}, []string{"a", "b"})
+	require.NoError(t, err)
+	from, err := verifier.Verify(msg)
+	require.NoError(t, err)
+	assert.Equal(t, address, *from)
+
+	// Missing field:
+	verifier, err = NewVerifierOverFields(crypto.ECRecoverer, []types.Address{address}, []string{"a", "c"})
+	require.NoError(t, err)
+	_, err = verifier.Verify(msg)
+	assert.EqualError(t, err, "missing c field")
+}
+
+func TestNewVerifierOverFields_InvalidFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+	}{
+		{name: "nil", fields: nil},
+		{name: "empty", fields: []string{}},
+		{name: "empty-name", fields: []string{"", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifierOverFields(crypto.ECRecoverer, nil, tt.fields)
+			assert.Nil(t, verifier)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifier_VerifyQuorum(t *testing.T) {
+	keys := []*wallet.PrivateKey{wallet.NewRandomKey(), wallet.NewRandomKey(), wallet.NewRandomKey()}
+	allowed := []types.Address{keys[0].Address(), keys[1].Address()}
+
+	t.Run("hash-field", func(t *testing.T) {
+		msg := &messages.Event{Type: "foo", Data: map[string][]byte{"digest": common.HexToHash("01").Bytes()}}
+		for _, k := range keys {
+			_, err := NewSignerWithHashField(k, []string{"foo"}, "digest").Sign(msg)
+			require.NoError(t, err)
+		}
+
+		// Signatures of all signers are collected in the event. The first
+		// one is stored under the "ethereum" key.
+		require.Len(t, msg.SchemeSignatures(SignatureKey), 3)
+		require.Len(t, msg.Signatures, 1)
+		assert.Equal(t, keys[0].Address().Bytes(), msg.Signatures[SignatureKey].Signer)
+
+		v := NewVerifierWithHashField(crypto.ECRecoverer, allowed, "digest")
+		from, err := v.Verify(msg)
+		require.NoError(t, err)
+		assert.Equal(t, keys[0].Address(), *from)
+		assert.NoError(t, v.VerifyQuorum(msg, 2))
+		assert.ErrorIs(t, v.VerifyQuorum(msg, 3), messages.ErrEventQuorumNotMet)
+		assert.ErrorIs(t, v.VerifyQuorum(msg, 0), messages.ErrEventInvalidThreshold)
+
+		// The default hash field is not signed.
+		msg.Data["hash"] = common.HexToHash("02").Bytes()
+		assert.ErrorIs(t, NewVerifier(crypto.ECRecoverer, allowed).VerifyQuorum(msg, 1), messages.ErrEventQuorumNotMet)
+	})
+	t.Run("fields", func(t *testing.T) {
+		msg := &messages.Event{Type: "foo", Data: map[string][]byte{"a": {1, 2}, "b": {3}}}
+		for _, k := range keys[:2] {
+			signer, err := NewSignerOverFields(k, []string{"foo"}, []string{"a", "b"})
+			require.NoError(t, err)
+			_, err = signer.Sign(msg)
+			require.NoError(t, err)
+		}
+		v, err := NewVerifierOverFields(crypto.ECRecoverer, allowed, []string{"a", "b"})
+		require.NoError(t, err)
+		assert.NoError(t, v.VerifyQuorum(msg, 2))
+
+		// Fields in a different order produce a different digest.
+		v, err = NewVerifierOverFields(crypto.ECRecoverer, allowed, []string{"b", "a"})
+		require.NoError(t, err)
+		assert.ErrorIs(t, v.VerifyQuorum(msg, 1), messages.ErrEventQuorumNotMet)
+	})
+}
@@ -17,13 +17,15 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
-const shutdownTimeout = 1 * time.Second
+const defaultShutdownTimeout = 1 * time.Second
 
 type Middleware interface {
 	Handle(http.Handler) http.Handler
@@ -43,18 +45,46 @@ type HTTPServer struct {
 	serveCh   chan error
 	waitCh    chan error
 
-	ln          net.Listener
-	srv         *http.Server
-	baseHandler http.Handler
-	handler     http.Handler
+	addrs           []string       // addrs is the list of addresses to listen on.
+	lns             []net.Listener // lns is the list of listeners to serve on.
+	started         bool
+	srv             *http.Server
+	baseHandler     http.Handler
+	handler         http.Handler
+	shutdownTimeout time.Duration
+	certReloader    *CertReloader
 }
 
-// New creates a new HTTPServer instance.
+// New creates a new HTTPServer instance. The server listens on the TCP
+// address specified in srv.Addr.
 func New(srv *http.Server) *HTTPServer {
+	return NewWithAddrs(srv)
+}
+
+// NewWithAddrs creates a new HTTPServer instance that serves the same handler
+// on all given addresses. Addresses prefixed with "unix:" are treated as Unix
+// domain socket paths, e.g. "unix:/run/app.sock", other addresses are TCP
+// addresses. If no addresses are given, srv.Addr is used.
+func NewWithAddrs(srv *http.Server, addrs ...string) *HTTPServer {
+	s := newServer(srv)
+	s.addrs = addrs
+	return s
+}
+
+// NewWithListeners creates a new HTTPServer instance that serves the same
+// handler on all given listeners. The listeners are closed when the server
+// is shut down.
+func NewWithListeners(srv *http.Server, lns ...net.Listener) *HTTPServer {
+	s := newServer(srv)
+	s.lns = lns
+	return s
+}
+
+func newServer(srv *http.Server) *HTTPServer {
 	s := &HTTPServer{
-		serveCh: make(chan error),
-		waitCh:  make(chan error),
-		srv:     srv,
+		waitCh:          make(chan error),
+		srv:             srv,
+		shutdownTimeout: defaultShutdownTimeout,
 	}
 	s.baseHandler = srv.Handler
 	s.handler = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) { s.baseHandler.ServeHTTP(rw, r) })
@@ -75,6 +105,29 @@ func (s *HTTPServer) SetHandler(handler http.Handler) {
 	s.baseHandler = handler
 }
 
+// SetShutdownTimeout sets how long the server waits for in-flight requests
+// to finish after the context passed to Start is canceled. After the timeout
+// the remaining connections are closed forcibly. The default is one second.
+func (s *HTTPServer) SetShutdownTimeout(timeout time.Duration) {
+	s.shutdownTimeout = timeout
+}
+
+// SetTLS enables TLS using the certificate and key from the given files.
+// The files are checked for modifications every minute and reloaded when
+// they change, so renewed certificates are used without a restart.
+func (s *HTTPServer) SetTLS(certFile, keyFile string) error {
+	c, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	if s.srv.TLSConfig == nil {
+		s.srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	s.srv.TLSConfig.GetCertificate = c.GetCertificate
+	s.certReloader = c
+	return nil
+}
+
 // ServeHTTP prepares middlewares stack if necessary and calls ServerHTTP
 // on the wrapped server.
 func (s *HTTPServer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
@@ -90,17 +143,56 @@ func (s *HTTPServer) Start(ctx context.Context) error {
 		return errors.New("context must not be nil")
 	}
 	s.ctx, s.ctxCancel = context.WithCancel(ctx)
-	addr := s.srv.Addr
-	if addr == "" {
-		addr = ":http"
+	addrs := s.addrs
+	if len(addrs) == 0 && len(s.lns) == 0 {
+		addrs = []string{s.srv.Addr}
 	}
-	ln, err := (&net.ListenConfig{}).Listen(s.ctx, "tcp", addr)
-	if err != nil {
-		return err
+	var lns []net.Listener
+	for _, addr := range addrs {
+		ln, err := listen(s.ctx, addr)
+		if err != nil {
+			// Close only the listeners opened here, listeners passed to
+			// NewWithListeners are owned by the caller until the server
+			// starts. The state is reset, so Start can be called again.
+			for _, ln := range lns {
+				_ = ln.Close()
+			}
+			s.ctxCancel()
+			s.ctx, s.ctxCancel = nil, nil
+			return err
+		}
+		lns = append(lns, ln)
 	}
-	s.ln = ln
+	s.lns = append(s.lns, lns...)
+	s.started = true
+	s.serveCh = make(chan error, len(s.lns))
 	go s.shutdownHandler()
-	go s.serve()
+	if s.certReloader != nil {
+		go s.certReloader.Watch(s.ctx, defaultCertReloadInterval)
+	}
+	for _, ln := range s.lns {
+		go s.serve(ln)
+	}
+	return nil
+}
+
+// Shutdown gracefully shuts down the server. It stops accepting new
+// connections and waits for in-flight requests to finish. If the context
+// expires before that, the remaining connections are closed forcibly and
+// the context error is returned. Background goroutines, such as the TLS
+// certificate reloader, are stopped as well.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	if !s.started {
+		return errors.New("service is not started")
+	}
+	// The internal context is canceled after the server is shut down, so
+	// the shutdown handler does not close connections before the given
+	// context expires.
+	defer s.ctxCancel()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		_ = s.srv.Close()
+		return err
+	}
 	return nil
 }
 
@@ -109,25 +201,56 @@ func (s *HTTPServer) Wait() <-chan error {
 	return s.waitCh
 }
 
-// Addr returns the server's network address.
+// Addr returns the server's network address. If the server listens on
+// multiple addresses, the first one is returned.
 func (s *HTTPServer) Addr() net.Addr {
-	return s.ln.Addr()
+	return s.lns[0].Addr()
 }
 
-func (s *HTTPServer) serve() {
-	s.serveCh <- s.srv.Serve(s.ln)
+// Addrs returns the network addresses of all server's listeners.
+func (s *HTTPServer) Addrs() []net.Addr {
+	addrs := make([]net.Addr, len(s.lns))
+	for i, ln := range s.lns {
+		addrs[i] = ln.Addr()
+	}
+	return addrs
+}
+
+func (s *HTTPServer) serve(ln net.Listener) {
+	if s.certReloader != nil {
+		s.serveCh <- s.srv.ServeTLS(ln, "", "")
+		return
+	}
+	s.serveCh <- s.srv.Serve(ln)
 }
 
 func (s *HTTPServer) shutdownHandler() {
 	defer func() { close(s.waitCh) }()
 	select {
 	case <-s.ctx.Done():
-		ctx, ctxCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		ctx, ctxCancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 		defer ctxCancel()
-		s.waitCh <- s.srv.Shutdown(ctx)
+		s.waitCh <- s.Shutdown(ctx)
 	case err := <-s.serveCh:
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			// If one of the listeners fails, stop serving on the other ones
+			// and stop background goroutines, such as the TLS certificate
+			// reloader.
+			_ = s.srv.Close()
+			s.ctxCancel()
 			s.waitCh <- err
 		}
 	}
 }
+
+// listen creates a listener for the given address. Addresses prefixed with
+// "unix:" are treated as Unix domain socket paths.
+func listen(ctx context.Context, addr string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return (&net.ListenConfig{}).Listen(ctx, "unix", path)
+	}
+	if addr == "" {
+		addr = ":http"
+	}
+	return (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+}
@@ -16,11 +16,17 @@
 package httpserver
 
 import (
+	"context"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServer_WithoutMiddlewares(t *testing.T) {
@@ -64,3 +70,185 @@ func TestServer_WithMiddlewares(t *testing.T) {
 	srv.ServeHTTP(rw, r)
 	assert.Equal(t, "before-response-after", rw.Body.String())
 }
+
+func TestServer_Shutdown(t *testing.T) {
+	reqCh := make(chan struct{})
+	srv := New(&http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			close(reqCh)
+			time.Sleep(100 * time.Millisecond)
+			rw.Write([]byte("response"))
+		}),
+	})
+	require.NoError(t, srv.Start(context.Background()))
+
+	resCh := make(chan string)
+	go func() {
+		res, err := http.Get("http://" + srv.Addr().String())
+		if err != nil {
+			resCh <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		resCh <- string(body)
+	}()
+	<-reqCh
+
+	// Shutdown must wait for the in-flight request to finish.
+	require.NoError(t, srv.Shutdown(context.Background()))
+	assert.Equal(t, "response", <-resCh)
+
+	// New connections must be rejected.
+	_, err := http.Get("http://" + srv.Addr().String())
+	assert.Error(t, err)
+
+	_, ok := <-srv.Wait()
+	assert.False(t, ok)
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	reqCh := make(chan struct{})
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	srv := New(&http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			close(reqCh)
+			<-doneCh
+		}),
+	})
+	srv.SetShutdownTimeout(50 * time.Millisecond)
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	require.NoError(t, srv.Start(ctx))
+
+	errCh := make(chan error)
+	go func() {
+		res, err := http.Get("http://" + srv.Addr().String())
+		if err == nil {
+			res.Body.Close()
+		}
+		errCh <- err
+	}()
+	<-reqCh
+
+	// The stuck request must be closed forcibly after the drain timeout.
+	ctxCancel()
+	assert.ErrorIs(t, <-srv.Wait(), context.DeadlineExceeded)
+	assert.Error(t, <-errCh)
+}
+
+func TestServer_MultipleAddrs(t *testing.T) {
+	sock := filepath.Join(t.TempDir(), "server.sock")
+	srv := NewWithAddrs(&http.Server{
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("response"))
+		}),
+	}, "127.0.0.1:0", "unix:"+sock)
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	require.NoError(t, srv.Start(ctx))
+
+	addrs := srv.Addrs()
+	require.Len(t, addrs, 2)
+	assert.Equal(t, "tcp", addrs[0].Network())
+	assert.Equal(t, "unix", addrs[1].Network())
+
+	// TCP listener.
+	res, err := http.Get("http://" + srv.Addr().String())
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "response", string(body))
+
+	// Unix socket listener.
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", sock)
+		},
+	}}
+	res, err = client.Get("http://unix/")
+	require.NoError(t, err)
+	body, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "response", string(body))
+
+	// Both listeners are closed on shutdown.
+	ctxCancel()
+	assert.NoError(t, <-srv.Wait())
+	_, err = http.Get("http://" + srv.Addr().String())
+	assert.Error(t, err)
+	_, err = net.Dial("unix", sock)
+	assert.Error(t, err)
+}
+
+func TestServer_Listeners(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := NewWithListeners(&http.Server{
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("response"))
+		}),
+	}, ln)
+	require.NoError(t, srv.Start(context.Background()))
+	assert.Equal(t, ln.Addr(), srv.Addr())
+
+	res, err := http.Get("http://" + ln.Addr().String())
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "response", string(body))
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServer_ListenError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	srv := NewWithAddrs(&http.Server{}, "127.0.0.1:0", ln.Addr().String())
+	assert.Error(t, srv.Start(context.Background()))
+}
+
+func TestServer_ListenErrorCleanup(t *testing.T) {
+	// Reserve a free address, so it can be checked that the listener opened
+	// by Start is closed after the failure.
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	freeAddr := free.Addr().String()
+	require.NoError(t, free.Close())
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	own, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer own.Close()
+
+	srv := NewWithListeners(&http.Server{
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("response"))
+		}),
+	}, own)
+	srv.addrs = []string{freeAddr, busy.Addr().String()}
+	require.Error(t, srv.Start(context.Background()))
+
+	// Listeners opened by Start must be closed.
+	ln, err := net.Listen("tcp", freeAddr)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	// Listeners passed to NewWithListeners must stay open.
+	conn, err := net.Dial("tcp", own.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+
+	// Start can be retried once the address is available.
+	require.NoError(t, busy.Close())
+	require.NoError(t, srv.Start(context.Background()))
+	assert.Len(t, srv.Addrs(), 3)
+	res, err := http.Get("http://" + busy.Addr().String())
+	require.NoError(t, err)
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, "response", string(body))
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
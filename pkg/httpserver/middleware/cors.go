@@ -17,34 +17,112 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 )
 
 // CORS adds a basic support for CORS preflight requests.
+//
+// Allowed origins, headers and methods can be specified either as functions
+// or as static lists. If a function is set, it takes precedence over the
+// corresponding list. The default configuration is restrictive: no origins
+// are allowed unless they are listed in AllowedOrigins or returned by the
+// Origin function.
 type CORS struct {
-	// Origin is a function that returns a value of
-	// an Access-Control-Allow-Origin header. It cannot be nil.
+	// Origin is an optional function that returns a value of
+	// an Access-Control-Allow-Origin header.
 	Origin func(r *http.Request) string
-	// Headers is a function that returns a value of
-	// an Access-Control-Allow-Headers header. It cannot be nil.
+	// Headers is an optional function that returns a value of
+	// an Access-Control-Allow-Headers header.
 	Headers func(r *http.Request) string
-	// Methods is a function that returns a value of
-	// an Access-Control-Allow-Methods. It cannot be nil.
+	// Methods is an optional function that returns a value of
+	// an Access-Control-Allow-Methods.
 	Methods func(r *http.Request) string
+
+	// AllowedOrigins is a list of origins allowed to access the resource.
+	// The "*" value allows any origin. It is used only if Origin is nil.
+	AllowedOrigins []string
+	// AllowedHeaders is a list of headers allowed in requests. It is used
+	// only if Headers is nil. If empty, only the Content-Type header is
+	// allowed.
+	AllowedHeaders []string
+	// AllowedMethods is a list of methods allowed in requests. It is used
+	// only if Methods is nil. If empty, the GET, HEAD and POST methods
+	// are allowed.
+	AllowedMethods []string
+	// AllowCredentials indicates whether the response can be shared when
+	// the request includes credentials. Since credentials cannot be used
+	// with a wildcard origin, the origin of the request is returned instead
+	// of "*" if enabled.
+	AllowCredentials bool
 }
 
 // Handle implements the httpserver.Middleware interface.
 func (c *CORS) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		headers := rw.Header()
-		headers.Set("Access-Control-Allow-Origin", c.Origin(r))
+		origin := c.allowedOrigin(r)
+		if origin != "*" {
+			// The response depends on the Origin header, so it must not be
+			// reused by caches for requests from other origins.
+			headers.Add("Vary", "Origin")
+		}
+		if origin != "" {
+			headers.Set("Access-Control-Allow-Origin", origin)
+			if c.AllowCredentials {
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			}
+		}
 		switch r.Method {
 		case "OPTIONS":
-			headers.Set("Access-Control-Allow-Headers", c.Headers(r))
-			headers.Set("Access-Control-Allow-Methods", c.Methods(r))
-			headers.Set("Access-Control-Max-Age", "86400")
+			if origin != "" {
+				headers.Set("Access-Control-Allow-Headers", c.allowedHeaders(r))
+				headers.Set("Access-Control-Allow-Methods", c.allowedMethods(r))
+				headers.Set("Access-Control-Max-Age", "86400")
+			}
 			rw.WriteHeader(http.StatusNoContent)
 		default:
 			next.ServeHTTP(rw, r)
 		}
 	})
 }
+
+func (c *CORS) allowedOrigin(r *http.Request) string {
+	if c.Origin != nil {
+		return c.Origin(r)
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return ""
+	}
+	for _, o := range c.AllowedOrigins {
+		switch {
+		case o == "*" && c.AllowCredentials:
+			return origin
+		case o == "*":
+			return "*"
+		case strings.EqualFold(o, origin):
+			return origin
+		}
+	}
+	return ""
+}
+
+func (c *CORS) allowedHeaders(r *http.Request) string {
+	if c.Headers != nil {
+		return c.Headers(r)
+	}
+	if len(c.AllowedHeaders) == 0 {
+		return "Content-Type"
+	}
+	return strings.Join(c.AllowedHeaders, ", ")
+}
+
+func (c *CORS) allowedMethods(r *http.Request) string {
+	if c.Methods != nil {
+		return c.Methods(r)
+	}
+	if len(c.AllowedMethods) == 0 {
+		return "GET, HEAD, POST"
+	}
+	return strings.Join(c.AllowedMethods, ", ")
+}
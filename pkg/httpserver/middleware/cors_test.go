@@ -16,6 +16,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -58,3 +59,86 @@ func TestCORS_Get(t *testing.T) {
 	assert.Empty(t, rw.Header().Get("Access-Control-Allow-Methods"))
 	assert.Empty(t, rw.Header().Get("Access-Control-Allow-Max-Age"))
 }
+
+func TestCORS_AllowedOrigins(t *testing.T) {
+	tests := []struct {
+		cors           *CORS
+		method         string
+		origin         string
+		expectedOrigin string
+		expectedCreds  string
+		expectedVary   string
+		expectedMethod string
+		expectedHeader string
+	}{
+		// Restrictive default.
+		{
+			cors:         &CORS{},
+			method:       "OPTIONS",
+			origin:       "https://example.com",
+			expectedVary: "Origin",
+		},
+		// Allowed origin.
+		{
+			cors:           &CORS{AllowedOrigins: []string{"https://example.com"}},
+			method:         "OPTIONS",
+			origin:         "https://example.com",
+			expectedOrigin: "https://example.com",
+			expectedVary:   "Origin",
+			expectedMethod: "GET, HEAD, POST",
+			expectedHeader: "Content-Type",
+		},
+		// Not allowed origin.
+		{
+			cors:         &CORS{AllowedOrigins: []string{"https://example.com"}},
+			method:       "GET",
+			origin:       "https://example.org",
+			expectedVary: "Origin",
+		},
+		// Custom methods and headers.
+		{
+			cors: &CORS{
+				AllowedOrigins: []string{"https://example.com"},
+				AllowedMethods: []string{"GET", "PUT"},
+				AllowedHeaders: []string{"Content-Type", "Authorization"},
+			},
+			method:         "OPTIONS",
+			origin:         "https://example.com",
+			expectedOrigin: "https://example.com",
+			expectedVary:   "Origin",
+			expectedMethod: "GET, PUT",
+			expectedHeader: "Content-Type, Authorization",
+		},
+		// Wildcard.
+		{
+			cors:           &CORS{AllowedOrigins: []string{"*"}},
+			method:         "GET",
+			origin:         "https://example.com",
+			expectedOrigin: "*",
+		},
+		// Wildcard with credentials.
+		{
+			cors:           &CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method:         "GET",
+			origin:         "https://example.com",
+			expectedOrigin: "https://example.com",
+			expectedCreds:  "true",
+			expectedVary:   "Origin",
+		},
+	}
+	for n, test := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			h := test.cors.Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+			r := httptest.NewRequest(test.method, "/", nil)
+			r.Header.Set("Origin", test.origin)
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, r)
+
+			assert.Equal(t, test.expectedOrigin, rw.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, test.expectedCreds, rw.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, test.expectedVary, rw.Header().Get("Vary"))
+			assert.Equal(t, test.expectedMethod, rw.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, test.expectedHeader, rw.Header().Get("Access-Control-Allow-Headers"))
+		})
+	}
+}
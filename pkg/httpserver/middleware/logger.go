@@ -24,11 +24,16 @@ import (
 
 const httpRequestLog = "HTTP request"
 
-// Logger prints logs for each request. If the log level is set to debug, it
-// will print the contents of requests and responses.
+// Logger prints logs for each request, including the response status code,
+// the response size and the request duration. If the log level is set to
+// debug, it will print the contents of requests and responses.
 type Logger struct {
 	// Log is an instance of a log.Logger. It cannot be nil, otherwise code will panic.
 	Log log.Logger
+	// RequestID is an optional function that returns the ID of the request,
+	// e.g. from the X-Request-ID header. If it returns an empty string, the
+	// ID is not logged. It can be nil.
+	RequestID func(r *http.Request) string
 }
 
 // Handle implements the httpserver.Middleware interface.
@@ -36,27 +41,31 @@ func (l *Logger) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 		t := time.Now()
 		e := l.Log
-		if l.Log.Level() >= log.Debug {
-			rw = newRecorder(rw)
+		debug := l.Log.Level() >= log.Debug
+		rec := newRecorder(rw, debug)
+		if debug {
 			e = e.WithField("request", string(readRequest(r)))
 		}
+		if l.RequestID != nil {
+			if id := l.RequestID(r); id != "" {
+				e = e.WithField("requestID", id)
+			}
+		}
 		defer func() {
 			e = e.WithFields(log.Fields{
 				"remoteAddr": r.RemoteAddr,
 				"duration":   time.Since(t),
 				"method":     r.Method,
 				"url":        r.URL.String(),
+				"status":     rec.code,
+				"size":       rec.size,
 			})
-			if l.Log.Level() >= log.Debug {
-				e = e.WithFields(log.Fields{
-					"response": string(readResponse(rw.(*recorder))),
-					"status":   rw.(*recorder).code,
-				})
-				e.Debug(httpRequestLog)
+			if debug {
+				e.WithField("response", string(readResponse(rec))).Debug(httpRequestLog)
 			} else {
 				e.Info(httpRequestLog)
 			}
 		}()
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(rec, r)
 	})
 }
@@ -16,6 +16,7 @@
 package middleware
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -41,15 +42,22 @@ func TestLogger_InfoLevel(t *testing.T) {
 
 	r := httptest.NewRequest("GET", "/", nil)
 	w := httptest.NewRecorder()
-	h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte("response"))
+	}))
 	h.ServeHTTP(w, r)
 
 	require.Len(t, recordedLogMsgs, 1)
 	assert.Equal(t, httpRequestLog, recordedLogMsgs[0])
 	assert.Equal(t, "GET", recordedLogFields[0]["method"])
 	assert.Equal(t, "/", recordedLogFields[0]["url"])
+	assert.Equal(t, http.StatusNotFound, recordedLogFields[0]["status"])
+	assert.Equal(t, len("response"), recordedLogFields[0]["size"])
 	assert.NotEmpty(t, recordedLogFields[0]["duration"])
 	assert.NotEmpty(t, recordedLogFields[0]["remoteAddr"])
+	assert.NotContains(t, recordedLogFields[0], "response")
+	assert.NotContains(t, recordedLogFields[0], "requestID")
 }
 
 func TestLogger_DebugLevel(t *testing.T) {
@@ -81,3 +89,65 @@ func TestLogger_DebugLevel(t *testing.T) {
 	assert.NotEmpty(t, recordedLogFields[0]["duration"])
 	assert.NotEmpty(t, recordedLogFields[0]["remoteAddr"])
 }
+
+func TestLogger_RequestID(t *testing.T) {
+	var recordedLogFields []log.Fields
+	l := callback.New(log.Info, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogFields = append(recordedLogFields, fields)
+	})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	w := httptest.NewRecorder()
+	h := (&Logger{
+		Log:       l,
+		RequestID: func(r *http.Request) string { return r.Header.Get("X-Request-ID") },
+	}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	h.ServeHTTP(w, r)
+
+	require.Len(t, recordedLogFields, 1)
+	assert.Equal(t, "abc", recordedLogFields[0]["requestID"])
+	assert.Equal(t, http.StatusOK, recordedLogFields[0]["status"])
+}
+
+func TestLogger_FlushAndHijack(t *testing.T) {
+	l := callback.New(log.Info, func(level log.Level, fields log.Fields, msg string) {})
+
+	t.Run("flush", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("chunk"))
+			f, ok := rw.(http.Flusher)
+			require.True(t, ok)
+			f.Flush()
+			// The ResponseController uses the Unwrap method.
+			require.NoError(t, http.NewResponseController(rw).Flush())
+		}))
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.True(t, w.Flushed)
+	})
+	t.Run("hijack", func(t *testing.T) {
+		srv := httptest.NewServer((&Logger{Log: l}).Handle(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			conn, buf, err := rw.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			defer conn.Close()
+			buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+			buf.Flush()
+		})))
+		defer srv.Close()
+		res, err := http.Get(srv.URL)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hijacked", string(body))
+	})
+	t.Run("hijack-unsupported", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_, _, err := rw.(http.Hijacker).Hijack()
+			assert.Error(t, err)
+		}))
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	})
+}
@@ -16,8 +16,11 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 )
 
@@ -27,17 +30,23 @@ import (
 type recorder struct {
 	rw      http.ResponseWriter // rw is an underlying ResponseWriter.
 	code    int                 // code is the HTTP status code
+	size    int                 // size is the number of written body bytes
 	headers http.Header         // headers is the list of HTTP headers
-	body    *bytes.Buffer       // body is the HTTP response body
+	body    *bytes.Buffer       // body is the HTTP response body, nil if not recorded
 }
 
-func newRecorder(rw http.ResponseWriter) *recorder {
-	return &recorder{
+// newRecorder returns a new recorder. If recordBody is false, only the status
+// code and the response size are recorded.
+func newRecorder(rw http.ResponseWriter, recordBody bool) *recorder {
+	r := &recorder{
 		rw:      rw,
 		headers: make(http.Header),
-		body:    new(bytes.Buffer),
 		code:    http.StatusOK,
 	}
+	if recordBody {
+		r.body = new(bytes.Buffer)
+	}
+	return r
 }
 
 func (r *recorder) Header() http.Header {
@@ -45,8 +54,12 @@ func (r *recorder) Header() http.Header {
 }
 
 func (r *recorder) Write(buf []byte) (int, error) {
-	r.body.Write(buf)
-	return r.rw.Write(buf)
+	if r.body != nil {
+		r.body.Write(buf)
+	}
+	n, err := r.rw.Write(buf)
+	r.size += n
+	return n, err
 }
 
 func (r *recorder) WriteHeader(code int) {
@@ -54,6 +67,37 @@ func (r *recorder) WriteHeader(code int) {
 	r.rw.WriteHeader(code)
 }
 
+// Flush implements the http.Flusher interface, so streaming handlers work
+// behind the middleware. It does nothing if the underlying ResponseWriter
+// does not support flushing.
+func (r *recorder) Flush() {
+	if f, ok := r.rw.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface, so websocket handlers work
+// behind the middleware.
+func (r *recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return hijack(r.rw)
+}
+
+// Unwrap returns the underlying ResponseWriter. It is used by
+// http.ResponseController.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.rw
+}
+
+// hijack hijacks the connection of the given ResponseWriter, if it
+// supports it.
+func hijack(rw http.ResponseWriter) (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
 func readRequest(r *http.Request) []byte {
 	b, _ := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(b))
@@ -61,6 +105,9 @@ func readRequest(r *http.Request) []byte {
 }
 
 func readResponse(r *recorder) []byte {
+	if r.body == nil {
+		return nil
+	}
 	b, _ := io.ReadAll(r.body)
 	return b
 }
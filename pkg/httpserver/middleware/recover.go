@@ -15,24 +15,118 @@
 
 package middleware
 
-import "net/http"
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+)
+
+const httpPanicLog = "HTTP handler panicked"
 
 // Recover recovers from panics that occurred during invoking handler's
-// ServeHTTP method.
+// ServeHTTP method. After recovering, it writes an error response unless
+// the handler has already started writing one.
+//
+// The http.ErrAbortHandler panic is not recovered, because it is used by
+// handlers to abort the response on purpose.
 type Recover struct {
 	// Recover is an optional function that will be invoked during panicking.
 	// It can be nil.
 	Recover func(err interface{})
+	// Log is an optional logger used to log the panic value along with the
+	// stack trace. It can be nil.
+	Log log.Logger
+	// StatusCode is the status code of the error response. If zero,
+	// http.StatusInternalServerError is used.
+	StatusCode int
+	// Body is an optional body of the error response.
+	Body []byte
 }
 
 // Handle implements the httpserver.Middleware interface.
 func (c *Recover) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		w := &writeTracker{ResponseWriter: rw}
 		defer func() {
-			if r := recover(); r != nil && c.Recover != nil {
-				c.Recover(r)
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				// The handler wants to abort the response, so the panic
+				// must be handled by the HTTP server.
+				panic(err)
+			}
+			if c.Log != nil {
+				c.Log.
+					WithFields(log.Fields{
+						"panic":  fmt.Sprintf("%v", err),
+						"stack":  string(debug.Stack()),
+						"method": r.Method,
+						"url":    r.URL.String(),
+					}).
+					Error(httpPanicLog)
+			}
+			if c.Recover != nil {
+				c.Recover(err)
+			}
+			if !w.written {
+				code := c.StatusCode
+				if code == 0 {
+					code = http.StatusInternalServerError
+				}
+				rw.WriteHeader(code)
+				if len(c.Body) > 0 {
+					_, _ = rw.Write(c.Body)
+				}
 			}
 		}()
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(w, r)
 	})
 }
+
+// writeTracker wraps an http.ResponseWriter to track whether the response
+// has been started.
+type writeTracker struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *writeTracker) Write(buf []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(buf)
+}
+
+func (w *writeTracker) WriteHeader(code int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush implements the http.Flusher interface. Flushing starts the
+// response, so no error response is written after a panic.
+func (w *writeTracker) Flush() {
+	w.written = true
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements the http.Hijacker interface. After the connection is
+// hijacked, no error response is written after a panic.
+func (w *writeTracker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := hijack(w.ResponseWriter)
+	if err == nil {
+		w.written = true
+	}
+	return conn, buf, err
+}
+
+// Unwrap returns the underlying ResponseWriter. It is used by
+// http.ResponseController.
+func (w *writeTracker) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -16,11 +16,16 @@
 package middleware
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/callback"
 )
 
 func TestRecover_WithCallback(t *testing.T) {
@@ -50,3 +55,119 @@ func TestRecover_WithoutCallback(t *testing.T) {
 	rw := httptest.NewRecorder()
 	h.ServeHTTP(rw, r)
 }
+
+func TestRecover_ErrorResponse(t *testing.T) {
+	h := (&Recover{}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("panic")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Empty(t, rw.Body.String())
+}
+
+func TestRecover_CustomErrorResponse(t *testing.T) {
+	h := (&Recover{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       []byte("unavailable"),
+	}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("panic")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, "unavailable", rw.Body.String())
+}
+
+func TestRecover_StartedResponse(t *testing.T) {
+	h := (&Recover{Body: []byte("error")}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusAccepted)
+		writer.Write([]byte("partial"))
+		panic("panic")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+	assert.Equal(t, http.StatusAccepted, rw.Code)
+	assert.Equal(t, "partial", rw.Body.String())
+}
+
+func TestRecover_WithLogger(t *testing.T) {
+	var recordedLogMsgs []string
+	var recordedLogFields []log.Fields
+	l := callback.New(log.Error, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogMsgs = append(recordedLogMsgs, msg)
+		recordedLogFields = append(recordedLogFields, fields)
+	})
+	h := (&Recover{Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic("panic")
+	}))
+	r := httptest.NewRequest("GET", "/foo", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	require.Len(t, recordedLogMsgs, 1)
+	assert.Equal(t, httpPanicLog, recordedLogMsgs[0])
+	assert.Equal(t, "panic", recordedLogFields[0]["panic"])
+	assert.Equal(t, "/foo", recordedLogFields[0]["url"])
+	assert.Contains(t, recordedLogFields[0]["stack"], "TestRecover_WithLogger")
+}
+
+func TestRecover_AbortHandler(t *testing.T) {
+	var recovered bool
+	var recordedLogMsgs []string
+	l := callback.New(log.Debug, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogMsgs = append(recordedLogMsgs, msg)
+	})
+	h := (&Recover{
+		Log:     l,
+		Recover: func(err interface{}) { recovered = true },
+	}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+
+	// The panic must be passed to the server without writing a response.
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.ServeHTTP(rw, r) })
+	assert.False(t, recovered)
+	assert.Empty(t, recordedLogMsgs)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, rw.Body.String())
+}
+
+func TestRecover_Flush(t *testing.T) {
+	h := (&Recover{Body: []byte("error")}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		// The ResponseController uses the Unwrap method.
+		require.NoError(t, http.NewResponseController(writer).Flush())
+		panic("panic")
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+	assert.True(t, rw.Flushed)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, rw.Body.String())
+}
+
+func TestRecover_Hijack(t *testing.T) {
+	l := callback.New(log.Info, func(level log.Level, fields log.Fields, msg string) {})
+	h := (&Recover{}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		conn, buf, err := writer.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		buf.Flush()
+	}))
+	// The connection must be hijackable through both middlewares.
+	srv := httptest.NewServer((&Logger{Log: l}).Handle(h))
+	defer srv.Close()
+	res, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hijacked", string(body))
+}
@@ -0,0 +1,77 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+)
+
+const httpTimeoutLog = "HTTP request timed out"
+
+// Timeout limits the time a handler can spend serving a request. It is
+// based on http.TimeoutHandler.
+//
+// The request context passed to the handler is canceled after the timeout.
+// If the handler does not return before that, a 503 response is sent to
+// the client.
+//
+// Everything the handler writes is buffered until it returns, so if the
+// timeout is exceeded after the handler has started writing the body, the
+// partial response is discarded and the client receives only the 503
+// response. Subsequent writes return http.ErrHandlerTimeout. Because of the
+// buffering, the response writer passed to the handler does not implement
+// http.Flusher, so this middleware should not be used with streaming
+// handlers.
+type Timeout struct {
+	// Timeout is the maximum duration of a request. It must be positive.
+	Timeout time.Duration
+	// Body is an optional body of the 503 response. If empty, the default
+	// body of http.TimeoutHandler is used.
+	Body string
+	// Log is an optional logger used to log timed out requests. It can be
+	// nil.
+	Log log.Logger
+}
+
+// Handle implements the httpserver.Middleware interface.
+func (t *Timeout) Handle(next http.Handler) http.Handler {
+	th := http.TimeoutHandler(next, t.Timeout, t.Body)
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if t.Log == nil {
+			th.ServeHTTP(rw, r)
+			return
+		}
+		// The deadline is set before http.TimeoutHandler sets its own, so
+		// if the handler has timed out, this context has expired as well.
+		ctx, ctxCancel := context.WithTimeout(r.Context(), t.Timeout)
+		defer ctxCancel()
+		rec := newRecorder(rw, false)
+		th.ServeHTTP(rec, r.WithContext(ctx))
+		if rec.code == http.StatusServiceUnavailable && ctx.Err() == context.DeadlineExceeded {
+			t.Log.
+				WithFields(log.Fields{
+					"timeout": t.Timeout,
+					"method":  r.Method,
+					"url":     r.URL.String(),
+				}).
+				Warn(httpTimeoutLog)
+		}
+	})
+}
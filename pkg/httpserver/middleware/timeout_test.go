@@ -0,0 +1,82 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/callback"
+)
+
+func TestTimeout_InTime(t *testing.T) {
+	h := (&Timeout{Timeout: time.Second}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		_, ok := request.Context().Deadline()
+		assert.True(t, ok)
+		writer.Write([]byte("response"))
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "response", rw.Body.String())
+}
+
+func TestTimeout_Exceeded(t *testing.T) {
+	var recordedLogMsgs []string
+	l := callback.New(log.Warn, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogMsgs = append(recordedLogMsgs, msg)
+	})
+	h := (&Timeout{
+		Timeout: 10 * time.Millisecond,
+		Body:    "timeout",
+		Log:     l,
+	}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("partial"))
+		<-request.Context().Done()
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Equal(t, "timeout", rw.Body.String())
+	require.Len(t, recordedLogMsgs, 1)
+	assert.Equal(t, httpTimeoutLog, recordedLogMsgs[0])
+}
+
+func TestTimeout_HandlerServiceUnavailable(t *testing.T) {
+	var recordedLogMsgs []string
+	l := callback.New(log.Warn, func(level log.Level, fields log.Fields, msg string) {
+		recordedLogMsgs = append(recordedLogMsgs, msg)
+	})
+	h := (&Timeout{Timeout: time.Second, Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	r := httptest.NewRequest("GET", "/", nil)
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+	assert.Empty(t, recordedLogMsgs)
+}
@@ -0,0 +1,126 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCertReloadInterval = time.Minute
+
+// CertReloader loads a TLS certificate and key from files and reloads them
+// when they change, so that renewed certificates can be used without
+// restarting the server.
+//
+// The certificate is swapped atomically, so connections that are being
+// established during a reload use either the old or the new certificate.
+type CertReloader struct {
+	mu       sync.Mutex
+	certFile string
+	keyFile  string
+	certMod  time.Time
+	keyMod   time.Time
+	cert     atomic.Pointer[tls.Certificate]
+}
+
+// NewCertReloader creates a new CertReloader instance and loads the
+// certificate from the given files.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	c := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload loads the certificate from files. If the certificate cannot be
+// loaded, the previous one is kept and an error is returned.
+//
+// It may be used to reload the certificate on demand, e.g. on SIGHUP.
+func (c *CertReloader) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	certMod, keyMod, err := c.modTimes()
+	if err != nil {
+		return err
+	}
+	return c.load(certMod, keyMod)
+}
+
+// GetCertificate returns the current certificate. It can be used as
+// the tls.Config.GetCertificate callback.
+func (c *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.cert.Load(), nil
+}
+
+// Watch periodically checks if the certificate files have been modified and
+// reloads them if so. It blocks until the context is canceled.
+func (c *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			// Errors are ignored because the previous certificate is still
+			// valid. Files may be temporarily missing or inconsistent while
+			// they are being replaced.
+			_ = c.reloadIfModified()
+		}
+	}
+}
+
+func (c *CertReloader) reloadIfModified() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	certMod, keyMod, err := c.modTimes()
+	if err != nil {
+		return err
+	}
+	if certMod.Equal(c.certMod) && keyMod.Equal(c.keyMod) {
+		return nil
+	}
+	return c.load(certMod, keyMod)
+}
+
+func (c *CertReloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.cert.Store(&cert)
+	c.certMod = certMod
+	c.keyMod = keyMod
+	return nil
+}
+
+func (c *CertReloader) modTimes() (certMod, keyMod time.Time, err error) {
+	certInfo, err := os.Stat(c.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(c.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
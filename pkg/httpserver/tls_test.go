@@ -0,0 +1,164 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	now := time.Now()
+	writeTestCert(t, certFile, keyFile, 1, now.Add(-time.Minute))
+
+	c, err := NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	cert1, _ := c.GetCertificate(nil)
+
+	// Files are not modified.
+	require.NoError(t, c.reloadIfModified())
+	cert, _ := c.GetCertificate(nil)
+	assert.Same(t, cert1, cert)
+
+	// Files are modified.
+	writeTestCert(t, certFile, keyFile, 2, now)
+	require.NoError(t, c.reloadIfModified())
+	cert2, _ := c.GetCertificate(nil)
+	assert.NotSame(t, cert1, cert2)
+
+	// Invalid files keep the previous certificate.
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0600))
+	assert.Error(t, c.Reload())
+	cert, _ = c.GetCertificate(nil)
+	assert.Same(t, cert2, cert)
+}
+
+func TestServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeTestCert(t, certFile, keyFile, 1, time.Now().Add(-time.Minute))
+
+	srv := New(&http.Server{
+		Addr: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte("response"))
+		}),
+	})
+	require.NoError(t, srv.SetTLS(certFile, keyFile))
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+	require.NoError(t, srv.Start(ctx))
+
+	serial := func() int64 {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		}}
+		res, err := client.Get("https://" + srv.Addr().String())
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+	assert.Equal(t, int64(1), serial())
+
+	writeTestCert(t, certFile, keyFile, 2, time.Now())
+	require.NoError(t, srv.certReloader.Reload())
+	assert.Equal(t, int64(2), serial())
+}
+
+func TestServer_TLSShutdown(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeTestCert(t, certFile, keyFile, 1, time.Now().Add(-time.Minute))
+
+	srv := New(&http.Server{Addr: "127.0.0.1:0"})
+	require.NoError(t, srv.SetTLS(certFile, keyFile))
+	require.NoError(t, srv.Start(context.Background()))
+
+	// Shutdown must cancel the internal context, so the certificate
+	// reloader stops watching the files.
+	require.NoError(t, srv.Shutdown(context.Background()))
+	assert.ErrorIs(t, srv.ctx.Err(), context.Canceled)
+	for err := range srv.Wait() {
+		assert.NoError(t, err)
+	}
+}
+
+// failingListener is a listener that fails to accept connections.
+type failingListener struct {
+	net.Listener
+}
+
+func (l failingListener) Accept() (net.Conn, error) {
+	return nil, errors.New("accept failed")
+}
+
+func TestServer_TLSServeError(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writeTestCert(t, certFile, keyFile, 1, time.Now().Add(-time.Minute))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := NewWithListeners(&http.Server{}, failingListener{Listener: ln})
+	require.NoError(t, srv.SetTLS(certFile, keyFile))
+	require.NoError(t, srv.Start(context.Background()))
+
+	// A serve error must cancel the internal context, so the certificate
+	// reloader stops watching the files.
+	assert.Error(t, <-srv.Wait())
+	assert.ErrorIs(t, srv.ctx.Err(), context.Canceled)
+}
@@ -80,6 +80,7 @@ func (b *Bittrex) callOne(pair Pair) (*Price, error) {
 		return nil, fmt.Errorf("wrong response from Bittrex %v", resp)
 	}
 
+	// The ticker endpoint does not provide the volume, so it is left zero.
 	return &Price{
 		Pair:      pair,
 		Price:     resp.Result.Last,
@@ -26,8 +26,9 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
 )
 
-// Coinbase URL
-const coinbaseProBaseURL = "https://api.pro.coinbase.com"
+// Coinbase URL. The Coinbase Pro API has been replaced by the Coinbase
+// Exchange API, which serves the ticker endpoint in the same format.
+const coinbaseProBaseURL = "https://api.exchange.coinbase.com"
 const coinbaseProURL = "%s/products/%s/ticker"
 
 type coinbaseProResponse struct {
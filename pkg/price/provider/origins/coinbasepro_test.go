@@ -130,6 +130,34 @@ func (suite *CoinbaseProSuite) TestSuccessResponse() {
 	suite.Greater(cr[0].Price.Timestamp.Unix(), int64(2))
 }
 
+func (suite *CoinbaseProSuite) TestSuccessResponseTicker() {
+	pair := Pair{Base: "BTC", Quote: "USD"}
+	resp := &query.HTTPResponse{
+		Body: []byte(`{
+			"ask": "30000.12",
+			"bid": "29999.98",
+			"volume": "12345.67890123",
+			"trade_id": 123456789,
+			"price": "30000.05",
+			"size": "0.0015",
+			"time": "2023-06-01T12:00:00.000000Z"
+		}`),
+	}
+	pool := suite.origin.ExchangeHandler.(CoinbasePro).Pool().(*query.MockWorkerPool)
+	pool.MockResp(resp)
+	pool.SetRequestAssertions(func(req *query.HTTPRequest) {
+		suite.Equal("https://api.exchange.coinbase.com/products/BTC-USD/ticker", req.URL)
+	})
+	defer pool.SetRequestAssertions(nil)
+	cr := suite.origin.Fetch([]Pair{pair})
+	suite.NoError(cr[0].Error)
+	suite.Equal(pair, cr[0].Price.Pair)
+	suite.Equal(30000.05, cr[0].Price.Price)
+	suite.Equal(30000.12, cr[0].Price.Ask)
+	suite.Equal(29999.98, cr[0].Price.Bid)
+	suite.Equal(12345.67890123, cr[0].Price.Volume24h)
+}
+
 func (suite *CoinbaseProSuite) TestRealAPICall() {
 	testRealAPICall(
 		suite,
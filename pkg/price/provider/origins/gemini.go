@@ -30,9 +30,10 @@ const geminiBaseURL = "https://api.gemini.com"
 const geminiURL = "%s/v1/pubticker/%s"
 
 type geminiResponse struct {
-	Price string `json:"last"`
-	Ask   string `json:"ask"`
-	Bid   string `json:"bid"`
+	Price  string         `json:"last"`
+	Ask    string         `json:"ask"`
+	Bid    string         `json:"bid"`
+	Volume map[string]any `json:"volume"` // Volumes by symbol and a timestamp.
 }
 
 // Gemini origin handler
@@ -92,12 +93,21 @@ func (g *Gemini) callOne(pair Pair) (*Price, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse bid from gemini origin %s", res.Body)
 	}
+	// Parsing base volume from string, it is optional
+	var volume float64
+	if v, ok := resp.Volume[strings.ToUpper(pair.Base)].(string); ok {
+		volume, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse volume from gemini origin %s", res.Body)
+		}
+	}
 	// building Price
 	return &Price{
 		Pair:      pair,
 		Price:     price,
 		Ask:       ask,
 		Bid:       bid,
+		Volume24h: volume,
 		Timestamp: time.Now(),
 	}, nil
 }
@@ -118,9 +118,39 @@ func (suite *GeminiSuite) TestSuccessResponse() {
 	suite.Equal(1.0, cr[0].Price.Price)
 	suite.Equal(2.0, cr[0].Price.Ask)
 	suite.Equal(4.0, cr[0].Price.Bid)
+	suite.Equal(0.0, cr[0].Price.Volume24h)
 	suite.Greater(cr[0].Price.Timestamp.Unix(), int64(0))
 }
 
+func (suite *GeminiSuite) TestSuccessResponseWithVolume() {
+	pair := Pair{Base: "BTC", Quote: "USD"}
+	resp := &query.HTTPResponse{
+		Body: []byte(`{
+			"bid": "29999.99",
+			"ask": "30000.01",
+			"volume": {
+				"BTC": "1234.5678",
+				"USD": "37037034.00",
+				"timestamp": 1685620800000
+			},
+			"last": "30000.00"
+		}`),
+	}
+	suite.origin.ExchangeHandler.(Gemini).Pool().(*query.MockWorkerPool).MockResp(resp)
+	cr := suite.origin.Fetch([]Pair{pair})
+	suite.NoError(cr[0].Error)
+	suite.Equal(30000.0, cr[0].Price.Price)
+	suite.Equal(1234.5678, cr[0].Price.Volume24h)
+
+	// Malformed volume:
+	resp = &query.HTTPResponse{
+		Body: []byte(`{"last":"1","ask":"2","bid":"4","volume":{"BTC":"abc"}}`),
+	}
+	suite.origin.ExchangeHandler.(Gemini).Pool().(*query.MockWorkerPool).MockResp(resp)
+	cr = suite.origin.Fetch([]Pair{pair})
+	suite.Error(cr[0].Error)
+}
+
 func (suite *GeminiSuite) TestRealAPICall() {
 	testRealAPICall(
 		suite,
@@ -0,0 +1,174 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/interpolate"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
+)
+
+// Generic is an origin handler for REST APIs that return a price of a single
+// pair in a JSON response. The request URL and paths to values in the
+// response are configurable, so new origins can be added without writing
+// code.
+//
+// The URL and paths may contain the following variables:
+//   - ${lcbase} - lower case base asset
+//   - ${ucbase} - upper case base asset
+//   - ${lcquote} - lower case quote asset
+//   - ${ucquote} - upper case quote asset
+//
+// A path is a list of object keys and array indexes separated by dots, e.g.
+// "data.0.price" or "result.${ucbase}${ucquote}.c[0]". Values may be either
+// JSON numbers or strings containing numbers.
+type Generic struct {
+	WorkerPool query.WorkerPool
+	Headers    map[string]string
+
+	// URL is the URL template of the ticker endpoint.
+	URL string
+
+	// PricePath is the path to the price. It is required.
+	PricePath string
+
+	// BidPath, AskPath and VolumePath are paths to the bid and ask prices
+	// and the 24h volume. They are optional, if empty, values are zero.
+	BidPath    string
+	AskPath    string
+	VolumePath string
+}
+
+func (g Generic) Pool() query.WorkerPool {
+	return g.WorkerPool
+}
+
+func (g Generic) PullPrices(pairs []Pair) []FetchResult {
+	return callSinglePairOrigin(&g, pairs)
+}
+
+func (g *Generic) callOne(pair Pair) (*Price, error) {
+	if g.URL == "" || g.PricePath == "" {
+		return nil, errors.New("generic origin requires the url and the price path")
+	}
+	req := &query.HTTPRequest{
+		URL:     interpolatePair(g.URL, pair),
+		Headers: g.Headers,
+	}
+
+	// make query
+	res := g.Pool().Query(req)
+	if res == nil {
+		return nil, ErrEmptyOriginResponse
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	// parsing JSON
+	var resp any
+	if err := json.Unmarshal(res.Body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse generic origin response: %w", err)
+	}
+	price, err := jsonPathFloat(resp, interpolatePair(g.PricePath, pair))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price from generic origin: %w", err)
+	}
+	var bid, ask, volume float64
+	if g.BidPath != "" {
+		if bid, err = jsonPathFloat(resp, interpolatePair(g.BidPath, pair)); err != nil {
+			return nil, fmt.Errorf("failed to parse bid from generic origin: %w", err)
+		}
+	}
+	if g.AskPath != "" {
+		if ask, err = jsonPathFloat(resp, interpolatePair(g.AskPath, pair)); err != nil {
+			return nil, fmt.Errorf("failed to parse ask from generic origin: %w", err)
+		}
+	}
+	if g.VolumePath != "" {
+		if volume, err = jsonPathFloat(resp, interpolatePair(g.VolumePath, pair)); err != nil {
+			return nil, fmt.Errorf("failed to parse volume from generic origin: %w", err)
+		}
+	}
+	// building Price
+	return &Price{
+		Pair:      pair,
+		Price:     price,
+		Bid:       bid,
+		Ask:       ask,
+		Volume24h: volume,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// interpolatePair replaces pair variables in the given string.
+func interpolatePair(s string, pair Pair) string {
+	return interpolate.Parse(s).Interpolate(func(variable interpolate.Variable) string {
+		switch variable.Name {
+		case "lcbase":
+			return strings.ToLower(pair.Base)
+		case "ucbase":
+			return strings.ToUpper(pair.Base)
+		case "lcquote":
+			return strings.ToLower(pair.Quote)
+		case "ucquote":
+			return strings.ToUpper(pair.Quote)
+		}
+		return variable.Default
+	})
+}
+
+// jsonPathFloat returns a number at the given path in the decoded JSON value.
+func jsonPathFloat(v any, path string) (float64, error) {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			continue
+		}
+		switch t := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = t[key]; !ok {
+				return 0, fmt.Errorf("key %q not found in path %q", key, path)
+			}
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(t) {
+				return 0, fmt.Errorf("invalid index %q in path %q", key, path)
+			}
+			v = t[i]
+		default:
+			return 0, fmt.Errorf("unable to get %q from a scalar value in path %q", key, path)
+		}
+	}
+	switch t := v.(type) {
+	case float64:
+		return t, nil
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return 0, fmt.Errorf("value at path %q is not a number: %w", path, err)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("value at path %q is not a number", path)
+	}
+}
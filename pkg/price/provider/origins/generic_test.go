@@ -0,0 +1,87 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
+)
+
+func TestGeneric(t *testing.T) {
+	pool := query.NewMockWorkerPool()
+	pool.SetRequestAssertions(func(req *query.HTTPRequest) {
+		assert.Equal(t, "https://example.com/ticker?symbol=btc_usd", req.URL)
+		assert.Equal(t, "key", req.Headers["X-Api-Key"])
+	})
+	pool.MockBody(`{
+		"data": [{
+			"BTCUSD": {"last": "30000.5", "bid": 30000, "ask": "30001"},
+			"volume": "123.45"
+		}]
+	}`)
+	h := NewBaseExchangeHandler(Generic{
+		WorkerPool: pool,
+		Headers:    map[string]string{"X-Api-Key": "key"},
+		URL:        "https://example.com/ticker?symbol=${lcbase}_${lcquote}",
+		PricePath:  "data[0].${ucbase}${ucquote}.last",
+		BidPath:    "data.0.${ucbase}${ucquote}.bid",
+		AskPath:    "data.0.${ucbase}${ucquote}.ask",
+		VolumePath: "data.0.volume",
+	}, nil)
+
+	pair := Pair{Base: "BTC", Quote: "USD"}
+	res := h.Fetch([]Pair{pair})
+	require.Len(t, res, 1)
+	require.NoError(t, res[0].Error)
+	assert.Equal(t, pair, res[0].Price.Pair)
+	assert.Equal(t, 30000.5, res[0].Price.Price)
+	assert.Equal(t, 30000.0, res[0].Price.Bid)
+	assert.Equal(t, 30001.0, res[0].Price.Ask)
+	assert.Equal(t, 123.45, res[0].Price.Volume24h)
+}
+
+func TestGeneric_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		path string
+	}{
+		{name: "invalid-json", body: `{`, path: "price"},
+		{name: "missing-key", body: `{"price": "1"}`, path: "last"},
+		{name: "invalid-index", body: `{"data": ["1"]}`, path: "data.1"},
+		{name: "scalar", body: `{"price": "1"}`, path: "price.value"},
+		{name: "not-a-number", body: `{"price": "abc"}`, path: "price"},
+		{name: "object", body: `{"price": {}}`, path: "price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := query.NewMockWorkerPool()
+			pool.MockBody(tt.body)
+			h := NewBaseExchangeHandler(Generic{
+				WorkerPool: pool,
+				URL:        "https://example.com",
+				PricePath:  tt.path,
+			}, nil)
+			res := h.Fetch([]Pair{{Base: "BTC", Quote: "USD"}})
+			require.Len(t, res, 1)
+			assert.Error(t, res[0].Error)
+		})
+	}
+}
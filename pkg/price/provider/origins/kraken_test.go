@@ -120,6 +120,53 @@ func (suite *KrakenSuite) TestSuccessResponse() {
 	suite.Greater(cr[0].Price.Timestamp.Unix(), int64(0))
 }
 
+func (suite *KrakenSuite) TestSuccessResponseNormalizedSymbols() {
+	// Kraken uses XBT instead of BTC, the symbol must be replaced in the
+	// request and reverted in the result.
+	pool := query.NewMockWorkerPool()
+	origin := NewBaseExchangeHandler(Kraken{WorkerPool: pool}, ExchangeSymbolAliases("kraken", nil))
+	pool.SetRequestAssertions(func(req *query.HTTPRequest) {
+		suite.Equal("https://api.kraken.com/0/public/Ticker?pair=XBT/USD,ETH/USD", req.URL)
+	})
+	pairs := []Pair{{Base: "BTC", Quote: "USD"}, {Base: "ETH", Quote: "USD"}}
+	pool.MockResp(&query.HTTPResponse{
+		Body: []byte(`{
+			"error": [],
+			"result": {
+				"XBT/USD": {
+					"a": ["30000.10000", "1", "1.000"],
+					"b": ["29999.90000", "2", "2.000"],
+					"c": ["30000.00000", "0.00100000"],
+					"v": ["150.50000000", "1200.25000000"],
+					"p": ["29950.00000", "29900.00000"],
+					"t": [1000, 5000],
+					"l": ["29500.00000", "29400.00000"],
+					"h": ["30500.00000", "30600.00000"],
+					"o": "29800.00000"
+				},
+				"ETH/USD": {
+					"a": ["2000.10000", "1", "1.000"],
+					"b": ["1999.90000", "2", "2.000"],
+					"c": ["2000.00000", "0.01000000"],
+					"v": ["1000.00000000", "8000.00000000"]
+				}
+			}
+		}`),
+	})
+	cr := origin.Fetch(pairs)
+	suite.Require().Len(cr, 2)
+	suite.NoError(cr[0].Error)
+	suite.Equal(pairs[0], cr[0].Price.Pair)
+	suite.Equal(30000.0, cr[0].Price.Price)
+	suite.Equal(30000.1, cr[0].Price.Ask)
+	suite.Equal(29999.9, cr[0].Price.Bid)
+	suite.Equal(150.5, cr[0].Price.Volume24h)
+	suite.Greater(cr[0].Price.Timestamp.Unix(), int64(0))
+	suite.NoError(cr[1].Error)
+	suite.Equal(pairs[1], cr[1].Price.Pair)
+	suite.Equal(2000.0, cr[1].Price.Price)
+}
+
 func (suite *KrakenSuite) TestRealAPICall() {
 	pairs := []Pair{
 		{Base: "ETH", Quote: "BTC"},
@@ -95,13 +95,13 @@ func (k *Kucoin) callOne(pair Pair) (*Price, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse bid from kucoin origin %s", res.Body)
 	}
-	// Parsing volume from string
+	// The level1 endpoint does not provide the volume, so it is left zero.
 	// building Price
 	return &Price{
 		Pair:      pair,
 		Timestamp: time.Unix(resp.Data.Time/1000, 0),
 		Price:     price,
-		Ask:       bid,
-		Bid:       ask,
+		Ask:       ask,
+		Bid:       bid,
 	}, nil
 }
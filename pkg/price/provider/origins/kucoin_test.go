@@ -157,8 +157,8 @@ func (suite *KucoinSuite) TestSuccessResponse() {
 	suite.NoError(cr[0].Error)
 	suite.Equal(int64(1596632420), cr[0].Price.Timestamp.Unix())
 	suite.Equal(1.23, cr[0].Price.Price)
-	suite.Equal(1.3, cr[0].Price.Bid)
-	suite.Equal(1.2, cr[0].Price.Ask)
+	suite.Equal(1.2, cr[0].Price.Bid)
+	suite.Equal(1.3, cr[0].Price.Ask)
 }
 
 func (suite *KucoinSuite) TestRealAPICall() {
@@ -113,6 +113,8 @@ func (l *Loopring) pickPairDetails(response loopringResponse, pair Pair) FetchRe
 	if err != nil {
 		return fetchResultWithError(pair, fmt.Errorf("failed to parse ask for pair %s: %w", pair, err))
 	}
+	// The volume is reported in the smallest token units, which depend on
+	// token decimals, so it is left zero.
 	// building Price
 	return fetchResult(Price{
 		Pair:      pair,
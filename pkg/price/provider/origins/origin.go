@@ -43,7 +43,8 @@ type ExchangeHandler interface {
 
 type BaseExchangeHandler struct {
 	ExchangeHandler
-	aliases SymbolAliases
+	aliases      SymbolAliases
+	inversePairs bool
 }
 
 func NewBaseExchangeHandler(handler ExchangeHandler, aliases SymbolAliases) *BaseExchangeHandler {
@@ -53,7 +54,55 @@ func NewBaseExchangeHandler(handler ExchangeHandler, aliases SymbolAliases) *Bas
 	}
 }
 
+// SetInversePairs enables fetching inverse pairs. If enabled, pairs that
+// could not be fetched are fetched again in the inverted form, and if that
+// succeeds, inverted prices are returned. It is useful for origins that
+// list only one direction of a pair.
+func (h *BaseExchangeHandler) SetInversePairs(enabled bool) {
+	h.inversePairs = enabled
+}
+
 func (h BaseExchangeHandler) Fetch(pairs []Pair) []FetchResult {
+	results := h.fetch(pairs)
+	if !h.inversePairs {
+		return results
+	}
+
+	// Try to fetch inverse pairs for pairs that failed:
+	var inversePairs []Pair
+	for _, r := range results {
+		if r.Error != nil {
+			inversePairs = append(inversePairs, r.Price.Pair.Inverse())
+		}
+	}
+	if len(inversePairs) == 0 {
+		return results
+	}
+	inverseResults := map[string]FetchResult{}
+	for _, r := range h.fetch(inversePairs) {
+		if r.Error == nil {
+			inverseResults[r.Price.Pair.Inverse().String()] = r
+		}
+	}
+	for i, r := range results {
+		if r.Error == nil {
+			continue
+		}
+		ir, ok := inverseResults[r.Price.Pair.String()]
+		if !ok {
+			continue
+		}
+		price, err := invertPrice(ir.Price)
+		if err != nil {
+			results[i] = fetchResultWithError(r.Price.Pair, err)
+			continue
+		}
+		results[i] = fetchResult(price)
+	}
+	return results
+}
+
+func (h BaseExchangeHandler) fetch(pairs []Pair) []FetchResult {
 	if h.aliases == nil {
 		return h.PullPrices(pairs)
 	}
@@ -155,11 +204,36 @@ func (p Pair) Equal(c Pair) bool {
 	return p.Base == c.Base && p.Quote == c.Quote
 }
 
+// invertPrice returns the price of the inverse pair.
+func invertPrice(p Price) (Price, error) {
+	if p.Price <= 0 {
+		return Price{}, fmt.Errorf("unable to invert price of %s: %w", p.Pair, ErrInvalidPrice)
+	}
+	inv := Price{
+		Pair:      p.Pair.Inverse(),
+		Price:     1 / p.Price,
+		Volume24h: p.Volume24h * p.Price,
+		Timestamp: p.Timestamp,
+	}
+	if p.Ask > 0 {
+		inv.Bid = 1 / p.Ask
+	}
+	if p.Bid > 0 {
+		inv.Ask = 1 / p.Bid
+	}
+	return inv, nil
+}
+
 type Price struct {
-	Pair      Pair
-	Price     float64
-	Bid       float64
-	Ask       float64
+	Pair  Pair
+	Price float64 // Last price.
+	// Bid and Ask are the best bid and ask prices. They are zero if the
+	// origin does not provide them.
+	Bid float64
+	Ask float64
+	// Volume24h is the 24h trading volume. It is zero if the
+	// origin does not provide it, so a zero volume does not necessarily
+	// mean that the market is inactive.
 	Volume24h float64
 	Timestamp time.Time
 }
@@ -280,7 +354,7 @@ func DefaultOriginSet(pool query.WorkerPool) *Set {
 		"gemini":        NewBaseExchangeHandler(Gemini{WorkerPool: pool}, nil),
 		"hitbtc":        NewBaseExchangeHandler(Hitbtc{WorkerPool: pool}, nil),
 		"huobi":         NewBaseExchangeHandler(Huobi{WorkerPool: pool}, nil),
-		"kraken":        NewBaseExchangeHandler(Kraken{WorkerPool: pool}, nil),
+		"kraken":        NewBaseExchangeHandler(Kraken{WorkerPool: pool}, ExchangeSymbolAliases("kraken", nil)),
 		"kucoin":        NewBaseExchangeHandler(Kucoin{WorkerPool: pool}, nil),
 		"loopring":      NewBaseExchangeHandler(Loopring{WorkerPool: pool}, nil),
 		"okex":          NewBaseExchangeHandler(Okex{WorkerPool: pool}, nil),
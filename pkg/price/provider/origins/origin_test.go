@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
@@ -216,3 +217,81 @@ func TestReplacementAndRevertingUsingAliases(t *testing.T) {
 	assert.Equal(t, "BTC", reverted.Base)
 	assert.Equal(t, "WETH", reverted.Quote)
 }
+
+// mockInverseExchangeHandler returns prices only for the listed pairs.
+type mockInverseExchangeHandler struct {
+	prices map[string]Price
+}
+
+func (u mockInverseExchangeHandler) PullPrices(pairs []Pair) []FetchResult {
+	var results []FetchResult
+	for _, pair := range pairs {
+		price, ok := u.prices[pair.String()]
+		if !ok {
+			results = append(results, fetchResultWithError(pair, ErrMissingResponseForPair))
+			continue
+		}
+		price.Pair = pair
+		results = append(results, fetchResult(price))
+	}
+	return results
+}
+
+func TestBaseExchangeHandlerInversePairs(t *testing.T) {
+	eh := NewBaseExchangeHandler(mockInverseExchangeHandler{prices: map[string]Price{
+		"ETH/BTC": {Price: 0.05, Ask: 0.04, Bid: 0.08, Volume24h: 100},
+		"ZRO/BTC": {Price: 0},
+	}}, nil)
+
+	// Inverse pairs are disabled by default:
+	results := eh.Fetch([]Pair{{Base: "BTC", Quote: "ETH"}})
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Error, ErrMissingResponseForPair)
+
+	eh.SetInversePairs(true)
+	results = eh.Fetch([]Pair{
+		{Base: "ETH", Quote: "BTC"},
+		{Base: "BTC", Quote: "ETH"},
+		{Base: "BTC", Quote: "ZRO"},
+		{Base: "BTC", Quote: "DAI"},
+	})
+	require.Len(t, results, 4)
+
+	// Directly available pair:
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, 0.05, results[0].Price.Price)
+
+	// Inverted pair:
+	assert.NoError(t, results[1].Error)
+	assert.Equal(t, Pair{Base: "BTC", Quote: "ETH"}, results[1].Price.Pair)
+	assert.InDelta(t, 20, results[1].Price.Price, 1e-9)
+	assert.InDelta(t, 12.5, results[1].Price.Ask, 1e-9)
+	assert.InDelta(t, 25, results[1].Price.Bid, 1e-9)
+	assert.InDelta(t, 5, results[1].Price.Volume24h, 1e-9)
+
+	// Inverse price is zero:
+	assert.ErrorIs(t, results[2].Error, ErrInvalidPrice)
+	assert.Equal(t, Pair{Base: "BTC", Quote: "ZRO"}, results[2].Price.Pair)
+
+	// Pair not available in any direction:
+	assert.ErrorIs(t, results[3].Error, ErrMissingResponseForPair)
+}
+
+func TestExchangeSymbolAliases(t *testing.T) {
+	// Exchange without its own symbols:
+	assert.Nil(t, ExchangeSymbolAliases("binance", nil))
+	assert.Equal(t, SymbolAliases{"USD": "USDT"}, ExchangeSymbolAliases("binance", SymbolAliases{"USD": "USDT"}))
+
+	// Exchange with its own symbols:
+	assert.Equal(t, SymbolAliases{"BTC": "XBT", "DOGE": "XDG"}, ExchangeSymbolAliases("kraken", nil))
+
+	// Given aliases take precedence:
+	assert.Equal(
+		t,
+		SymbolAliases{"BTC": "WBTC", "DOGE": "XDG", "USD": "USDT"},
+		ExchangeSymbolAliases("kraken", SymbolAliases{"BTC": "WBTC", "USD": "USDT"}),
+	)
+
+	// Table must not be modified:
+	assert.Equal(t, SymbolAliases{"BTC": "XBT", "DOGE": "XDG"}, exchangeSymbolAliases["kraken"])
+}
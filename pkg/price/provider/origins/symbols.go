@@ -0,0 +1,44 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+// exchangeSymbolAliases is a table of exchanges which use different symbols
+// than the canonical ones. For every exchange, it maps canonical symbols to
+// the symbols used by that exchange.
+var exchangeSymbolAliases = map[string]SymbolAliases{
+	"kraken": {
+		"BTC":  "XBT",
+		"DOGE": "XDG",
+	},
+}
+
+// ExchangeSymbolAliases returns symbol aliases for the given exchange type
+// merged with the given aliases. The given aliases take precedence over
+// the ones defined for the exchange. It returns nil if there are no aliases.
+func ExchangeSymbolAliases(exchange string, aliases SymbolAliases) SymbolAliases {
+	defaults := exchangeSymbolAliases[exchange]
+	if len(defaults) == 0 {
+		return aliases
+	}
+	merged := make(SymbolAliases, len(defaults)+len(aliases))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range aliases {
+		merged[k] = v
+	}
+	return merged
+}
@@ -88,7 +88,7 @@ func (g GenericEVM) FetchTicks(ctx context.Context, pairs []provider.Pair) []pro
 		if abi.IsPanic(results[i]) {
 			ticks[i] = provider.Tick{
 				Pair:  pairs[i],
-				Error: fmt.Errorf("contract panicked: %s", abi.DecodePanic(results[i])),
+				Error: panicError(results[i]),
 			}
 			continue
 		}
@@ -107,3 +107,12 @@ func (g GenericEVM) FetchTicks(ctx context.Context, pairs []provider.Pair) []pro
 	}
 	return ticks
 }
+
+// panicError returns an error describing the Panic(uint256) data returned
+// by a contract call. If the data cannot be decoded, the raw data is used.
+func panicError(data []byte) error {
+	if reason := ethereum.DecodeReason(data); reason != "" {
+		return fmt.Errorf("contract %s", reason)
+	}
+	return fmt.Errorf("contract panicked with invalid data: 0x%x", data)
+}
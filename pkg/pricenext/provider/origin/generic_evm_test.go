@@ -0,0 +1,24 @@
+package origin
+
+import (
+	"testing"
+
+	"github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_panicError(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		data := hexutil.MustHexToBytes("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+		require.True(t, abi.IsPanic(data))
+		assert.EqualError(t, panicError(data), "contract panic: arithmetic overflow (0x11)")
+	})
+	t.Run("truncated", func(t *testing.T) {
+		// The reason cannot be decoded, so the raw data is used instead.
+		data := hexutil.MustHexToBytes("0x4e487b7100")
+		require.True(t, abi.IsPanic(data))
+		assert.EqualError(t, panicError(data), "contract panicked with invalid data: 0x4e487b7100")
+	})
+}
@@ -0,0 +1,129 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+// knownTxErrors are parts of error messages returned by nodes when the
+// transaction has already been received, either directly or from other
+// nodes in the network.
+var knownTxErrors = []string{
+	"already known",
+	"nonce too low",
+}
+
+// broadcast sends the raw transaction to all endpoints and returns the
+// transaction hash once any endpoint accepts it. Errors indicating that the
+// transaction is already known are treated as success.
+//
+// Unlike other methods, the requests are not canceled after the response is
+// returned, so that the transaction is sent to slower endpoints as well.
+func (s *server) broadcast(data types.Bytes) (types.Hash, error) {
+	start := time.Now()
+	callers := s.healthyCallers()
+	if len(callers) == 0 {
+		return types.Hash{}, errNotEnoughHealthyEndpoints
+	}
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), s.totalTimeout)
+	ch := s.callAll(ctx, callers, reflect.TypeOf(types.Hash{}), "eth_sendRawTransaction", []any{data})
+
+	var errs []error
+	for i := 0; i < len(callers); i++ {
+		r := <-ch
+		if err, ok := r.value.(error); ok && !isKnownTxError(err) {
+			errs = append(errs, err)
+			continue
+		}
+		// Wait for the remaining endpoints in the background.
+		go func(n int) {
+			for ; n > 0; n-- {
+				<-ch
+			}
+			ctxCancel()
+		}(len(callers) - i - 1)
+		s.logResolution(resolution{
+			method:   "eth_sendRawTransaction",
+			args:     []any{data},
+			strategy: "broadcast",
+			callers:  len(callers),
+			agreeing: 1,
+			start:    start,
+		})
+		if h, ok := r.value.(*types.Hash); ok {
+			return *h, nil
+		}
+		return types.BytesToHash(crypto.Keccak256(data)), nil
+	}
+	ctxCancel()
+	err := mostInformativeError(errs)
+	s.logResolution(resolution{
+		method:   "eth_sendRawTransaction",
+		args:     []any{data},
+		strategy: "broadcast",
+		callers:  len(callers),
+		start:    start,
+		err:      err,
+	})
+	return types.Hash{}, err
+}
+
+// isKnownTxError returns true if the error indicates that the transaction
+// has already been received by the node.
+func isKnownTxError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, e := range knownTxErrors {
+		if strings.Contains(msg, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// mostInformativeError returns the most common JSON-RPC error, because it
+// explains why the node rejected the transaction. If there are no JSON-RPC
+// errors, all errors are returned.
+func mostInformativeError(errs []error) error {
+	var (
+		best  error
+		count = map[string]int{}
+	)
+	for _, err := range errs {
+		var rpcErr gethRPC.Error
+		if !errors.As(err, &rpcErr) {
+			continue
+		}
+		count[err.Error()]++
+		if best == nil || count[err.Error()] > count[best.Error()] {
+			best = err
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return addError(errNotEnoughResponses, errs...)
+}
@@ -0,0 +1,49 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isKnownTxError(t *testing.T) {
+	assert.True(t, isKnownTxError(errors.New("already known")))
+	assert.True(t, isKnownTxError(errors.New("Nonce too low: address 0x00, tx: 1 state: 2")))
+	assert.False(t, isKnownTxError(errors.New("insufficient funds for gas * price + value")))
+}
+
+func Test_mostInformativeError(t *testing.T) {
+	t.Run("rpc-errors", func(t *testing.T) {
+		err := mostInformativeError([]error{
+			errors.New("connection refused"),
+			&mockRPCError{msg: "replacement transaction underpriced"},
+			&mockRPCError{msg: "insufficient funds"},
+			&mockRPCError{msg: "insufficient funds"},
+		})
+		assert.EqualError(t, err, "insufficient funds")
+	})
+	t.Run("other-errors", func(t *testing.T) {
+		err := mostInformativeError([]error{
+			errors.New("connection refused"),
+			errors.New("timeout"),
+		})
+		assert.ErrorContains(t, err, "connection refused")
+		assert.ErrorContains(t, err, "timeout")
+	})
+}
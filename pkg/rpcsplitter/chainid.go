@@ -0,0 +1,159 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
+
+const chainIDCheckInterval = 5 * time.Minute
+
+var errUnknownChainID = errors.New("unable to determine the chain ID used by the majority of RPC servers")
+
+// chainIDGuard verifies that endpoints are connected to the expected chain.
+// Endpoints connected to a different chain are excluded until the next
+// check. Endpoints that fail to return a chain ID keep their previous status,
+// and if they have never been verified, they are excluded. If the expected
+// chain ID is not configured and more than half of all endpoints do not
+// agree on the chain ID, all endpoints keep their previous status.
+//
+// It is safe for concurrent use.
+type chainIDGuard struct {
+	mu        sync.Mutex
+	expected  uint64          // expected chain ID, zero to use the one returned by the majority of endpoints
+	interval  time.Duration   // interval between checks
+	valid     map[string]bool // endpoints connected to the expected chain
+	lastCheck time.Time
+	checking  bool
+	now       func() time.Time
+}
+
+func newChainIDGuard(expected uint64, interval time.Duration) *chainIDGuard {
+	return &chainIDGuard{
+		expected: expected,
+		interval: interval,
+		valid:    map[string]bool{},
+		now:      time.Now,
+	}
+}
+
+// due reports whether the next check should be performed. If it returns
+// true, the caller must call the check method.
+func (g *chainIDGuard) due() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.checking || g.now().Sub(g.lastCheck) < g.interval {
+		return false
+	}
+	g.checking = true
+	return true
+}
+
+// check fetches chain IDs from all endpoints and updates the list of valid
+// endpoints. It returns the names of endpoints connected to a different
+// chain. An error is returned if none of the endpoints is connected to the
+// expected chain.
+func (g *chainIDGuard) check(ctx context.Context, callers map[string]caller) ([]string, error) {
+	ids := fetchChainIDs(ctx, callers)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastCheck = g.now()
+	g.checking = false
+	expected := g.expected
+	if expected == 0 {
+		var ok bool
+		if expected, ok = majorityChainID(ids, len(callers)); !ok {
+			// A temporary split, e.g. during a network outage, must not
+			// exclude endpoints that were verified before.
+			return nil, errUnknownChainID
+		}
+	}
+	var mismatched []string
+	for name, id := range ids {
+		g.valid[name] = id == expected
+		if id != expected {
+			mismatched = append(mismatched, name)
+		}
+	}
+	sort.Strings(mismatched)
+	for name := range callers {
+		if g.valid[name] {
+			return mismatched, nil
+		}
+	}
+	return mismatched, fmt.Errorf("none of the RPC servers is connected to the chain with ID %d", expected)
+}
+
+// filter returns only endpoints connected to the expected chain.
+func (g *chainIDGuard) filter(callers map[string]caller) map[string]caller {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	valid := make(map[string]caller, len(callers))
+	for n, c := range callers {
+		if g.valid[n] {
+			valid[n] = c
+		}
+	}
+	return valid
+}
+
+// fetchChainIDs returns chain IDs of all endpoints that responded without
+// an error.
+func fetchChainIDs(ctx context.Context, callers map[string]caller) map[string]uint64 {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = make(map[string]uint64, len(callers))
+	)
+	wg.Add(len(callers))
+	for n, c := range callers {
+		n, c := n, c
+		go func() {
+			defer wg.Done()
+			var id types.Number
+			if err := c.CallContext(ctx, &id, "eth_chainId"); err != nil {
+				return
+			}
+			mu.Lock()
+			ids[n] = id.Big().Uint64()
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return ids
+}
+
+// majorityChainID returns the chain ID returned by more than half of all
+// endpoints, including those that failed to return a chain ID. If there is
+// no such chain ID, false is returned.
+func majorityChainID(ids map[string]uint64, endpoints int) (uint64, bool) {
+	count := map[uint64]int{}
+	for _, id := range ids {
+		count[id]++
+		if count[id]*2 > endpoints {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,164 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_chainIDGuard(t *testing.T) {
+	ctx := context.Background()
+	clients := []*mockClient{{t: t}, {t: t}, {t: t}}
+	callers := map[string]caller{"0": clients[0], "1": clients[1], "2": clients[2]}
+
+	now := time.Unix(0, 0)
+	g := newChainIDGuard(0, time.Minute)
+	g.now = func() time.Time { return now }
+
+	// The majority chain ID is used.
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	clients[2].mockCall(`0x2`, "eth_chainId")
+	mismatched, err := g.check(ctx, callers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, mismatched)
+	assert.Len(t, g.filter(callers), 2)
+	assert.NotContains(t, g.filter(callers), "2")
+
+	// The next check is not due before the interval passes.
+	assert.False(t, g.due())
+	now = now.Add(time.Minute)
+	assert.True(t, g.due())
+	assert.False(t, g.due())
+
+	// Endpoints that fail keep their previous status.
+	clients[0].mockCall(errors.New("error"), "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	clients[2].mockCall(`0x1`, "eth_chainId")
+	mismatched, err = g.check(ctx, callers)
+	require.NoError(t, err)
+	assert.Empty(t, mismatched)
+	assert.Len(t, g.filter(callers), 3)
+
+	// Without a majority, all endpoints keep their previous status.
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x2`, "eth_chainId")
+	clients[2].mockCall(errors.New("error"), "eth_chainId")
+	_, err = g.check(ctx, callers)
+	assert.ErrorIs(t, err, errUnknownChainID)
+	assert.Len(t, g.filter(callers), 3)
+
+	// A chain ID returned by the only responding endpoint is not a majority.
+	clients[0].mockCall(`0x2`, "eth_chainId")
+	clients[1].mockCall(errors.New("error"), "eth_chainId")
+	clients[2].mockCall(errors.New("error"), "eth_chainId")
+	_, err = g.check(ctx, callers)
+	assert.ErrorIs(t, err, errUnknownChainID)
+	assert.Len(t, g.filter(callers), 3)
+
+	// Endpoints that have never been verified are excluded.
+	g = newChainIDGuard(0, time.Minute)
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x2`, "eth_chainId")
+	clients[2].mockCall(errors.New("error"), "eth_chainId")
+	_, err = g.check(ctx, callers)
+	assert.ErrorIs(t, err, errUnknownChainID)
+	assert.Empty(t, g.filter(callers))
+}
+
+func Test_majorityChainID(t *testing.T) {
+	tests := []struct {
+		ids       map[string]uint64
+		endpoints int
+		want      uint64
+		wantOK    bool
+	}{
+		{ids: map[string]uint64{}, endpoints: 0},
+		{ids: map[string]uint64{"a": 1}, endpoints: 1, want: 1, wantOK: true},
+		{ids: map[string]uint64{"a": 1, "b": 1, "c": 2}, endpoints: 3, want: 1, wantOK: true},
+		{ids: map[string]uint64{"a": 1, "b": 2}, endpoints: 2},
+		{ids: map[string]uint64{"a": 1, "b": 1}, endpoints: 4},
+		{ids: map[string]uint64{"a": 1, "b": 1, "c": 2}, endpoints: 5},
+		{ids: map[string]uint64{"a": 1, "b": 1, "c": 1}, endpoints: 5, want: 1, wantOK: true},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			got, ok := majorityChainID(tt.ids, tt.endpoints)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func Test_chainIDGuard_Expected(t *testing.T) {
+	ctx := context.Background()
+	clients := []*mockClient{{t: t}, {t: t}}
+	callers := map[string]caller{"0": clients[0], "1": clients[1]}
+
+	g := newChainIDGuard(2, time.Minute)
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x2`, "eth_chainId")
+	mismatched, err := g.check(ctx, callers)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0"}, mismatched)
+
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	_, err = g.check(ctx, callers)
+	assert.Error(t, err)
+}
+
+func Test_RPC_EnforceChainID(t *testing.T) {
+	clients := []*mockClient{{t: t}, {t: t}, {t: t}}
+	callers := map[string]caller{"0": clients[0], "1": clients[1], "2": clients[2]}
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	clients[2].mockCall(`0x2`, "eth_chainId")
+	h, err := NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithEnforceChainID(0),
+	)
+	require.NoError(t, err)
+	s := h.(*server)
+
+	// Endpoints connected to a different chain are not called.
+	clients[0].mockCall(`0x1`, "eth_getBalance")
+	clients[1].mockCall(`0x1`, "eth_getBalance")
+	var res any
+	require.NoError(t, s.call(context.Background(), s.defaultResolver, &res, "eth_getBalance"))
+	assert.Equal(t, 1, clients[2].currCall)
+}
+
+func TestNewServer_ChainIDMismatch(t *testing.T) {
+	clients := []*mockClient{{t: t}, {t: t}}
+	callers := map[string]caller{"0": clients[0], "1": clients[1]}
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	_, err := NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithEnforceChainID(5),
+	)
+	assert.Error(t, err)
+}
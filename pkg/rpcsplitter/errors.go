@@ -15,7 +15,16 @@
 
 package rpcsplitter
 
-import "strings"
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
+)
 
 type errorList []error
 
@@ -68,3 +77,63 @@ func addError(err error, errs ...error) error {
 	}
 	return errList
 }
+
+// debugError is an error that includes additional data in the JSON-RPC
+// error response.
+type debugError struct {
+	err  error
+	data any
+}
+
+func (e *debugError) Error() string {
+	return e.err.Error()
+}
+
+func (e *debugError) Unwrap() error {
+	return e.err
+}
+
+// ErrorData implements the rpc.DataError interface.
+func (e *debugError) ErrorData() any {
+	return e.data
+}
+
+// responseSummary describes a response of a single endpoint.
+type responseSummary struct {
+	Endpoint string `json:"endpoint"`
+	Result   string `json:"result,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// summarizeResponses returns a summary of the responses sorted by endpoint.
+// Results are replaced by the hashes of their JSON representation, so that
+// the summary is short but different results can still be told apart.
+func summarizeResponses(resps []response) []responseSummary {
+	s := make([]responseSummary, 0, len(resps))
+	for _, r := range resps {
+		rs := responseSummary{Endpoint: endpointHost(r.name)}
+		if err, ok := r.value.(error); ok {
+			rs.Error = err.Error()
+		} else if b, err := json.Marshal(r.value); err != nil {
+			rs.Error = err.Error()
+		} else {
+			h := types.BytesToHash(crypto.Keccak256(b))
+			rs.Result = h.String()
+		}
+		s = append(s, rs)
+	}
+	sort.SliceStable(s, func(i, j int) bool {
+		return s[i].Endpoint < s[j].Endpoint
+	})
+	return s
+}
+
+// endpointHost returns the host of the endpoint URL. Other parts of the URL
+// are omitted, because they may contain API keys.
+func endpointHost(name string) string {
+	u, err := url.Parse(name)
+	if err != nil || u.Host == "" {
+		return name
+	}
+	return u.Host
+}
@@ -0,0 +1,112 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker tracks the health of endpoints. An endpoint that fails
+// threshold times in a row is ejected for the cooldown period. After the
+// cooldown, a single request is sent to the endpoint to probe whether it
+// works again. If it succeeds, the endpoint is considered healthy again,
+// otherwise it is ejected for another cooldown period.
+//
+// It is safe for concurrent use.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	endpoints map[string]*endpointHealth
+	now       func() time.Time
+}
+
+type endpointHealth struct {
+	failures     int       // number of consecutive failures
+	ejectedUntil time.Time // zero if the endpoint is not ejected
+	probing      bool      // true if a probe request is in progress
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		endpoints: map[string]*endpointHealth{},
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a request can be sent to the endpoint. For an
+// ejected endpoint whose cooldown has passed, it allows a single probe
+// request. Every allowed request must be followed by a call to the success,
+// failure or release method.
+func (cb *circuitBreaker) allow(name string) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	h := cb.health(name)
+	switch {
+	case h.ejectedUntil.IsZero():
+		return true
+	case h.probing || cb.now().Before(h.ejectedUntil):
+		return false
+	default:
+		h.probing = true
+		return true
+	}
+}
+
+// success records a successful request.
+func (cb *circuitBreaker) success(name string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	h := cb.health(name)
+	h.failures = 0
+	h.ejectedUntil = time.Time{}
+	h.probing = false
+}
+
+// failure records a failed request. It returns true if the endpoint has
+// been ejected as a result.
+func (cb *circuitBreaker) failure(name string) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	h := cb.health(name)
+	h.failures++
+	if h.probing || (h.ejectedUntil.IsZero() && h.failures >= cb.threshold) {
+		h.ejectedUntil = cb.now().Add(cb.cooldown)
+		h.probing = false
+		return true
+	}
+	return false
+}
+
+// release records a request whose result is unknown, e.g. because it was
+// canceled.
+func (cb *circuitBreaker) release(name string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.health(name).probing = false
+}
+
+func (cb *circuitBreaker) health(name string) *endpointHealth {
+	h, ok := cb.endpoints[name]
+	if !ok {
+		h = &endpointHealth{}
+		cb.endpoints[name] = h
+	}
+	return h
+}
@@ -0,0 +1,116 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpcsplitter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_circuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	cb := newCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time { return now }
+
+	// An endpoint is ejected after threshold consecutive failures.
+	assert.True(t, cb.allow("a"))
+	assert.False(t, cb.failure("a"))
+	assert.True(t, cb.allow("a"))
+	assert.True(t, cb.failure("a"))
+	assert.False(t, cb.allow("a"))
+
+	// A success resets the failure counter.
+	assert.True(t, cb.allow("b"))
+	assert.False(t, cb.failure("b"))
+	cb.success("b")
+	assert.False(t, cb.failure("b"))
+	assert.True(t, cb.allow("b"))
+
+	// After the cooldown, only a single probe request is allowed.
+	now = now.Add(time.Minute)
+	assert.True(t, cb.allow("a"))
+	assert.False(t, cb.allow("a"))
+
+	// A failed probe ejects the endpoint again.
+	assert.True(t, cb.failure("a"))
+	assert.False(t, cb.allow("a"))
+
+	// A released probe can be retried.
+	now = now.Add(time.Minute)
+	assert.True(t, cb.allow("a"))
+	cb.release("a")
+	assert.True(t, cb.allow("a"))
+
+	// A successful probe restores the endpoint.
+	cb.success("a")
+	assert.True(t, cb.allow("a"))
+	assert.True(t, cb.allow("a"))
+}
+
+func Test_RPC_CircuitBreaker(t *testing.T) {
+	clients := []*mockClient{{t: t}, {t: t}, {t: t}}
+	callers := map[string]caller{}
+	for i, c := range clients {
+		callers[string(rune('0'+i))] = c
+	}
+	h, err := NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithCircuitBreaker(2, time.Hour),
+		WithGracefulTimeout(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	s := h.(*server)
+
+	// The third endpoint fails twice and is ejected. Other endpoints respond
+	// later, so the failure is reported before the quorum is reached and
+	// the pending requests are canceled.
+	for i := 0; i < 2; i++ {
+		clients[0].mockSlowCall(20*time.Millisecond, `0x1`, "eth_chainId")
+		clients[1].mockSlowCall(20*time.Millisecond, `0x1`, "eth_chainId")
+		clients[2].mockCall(errors.New("error"), "eth_chainId")
+		_, err := s.eth.ChainId()
+		require.NoError(t, err)
+	}
+
+	// Ejected endpoints are not called.
+	clients[0].mockCall(`0x1`, "eth_chainId")
+	clients[1].mockCall(`0x1`, "eth_chainId")
+	_, err = s.eth.ChainId()
+	require.NoError(t, err)
+	assert.Equal(t, 2, clients[2].currCall)
+
+	// The second endpoint fails twice, so there are not enough healthy
+	// endpoints left.
+	for i := 0; i < 2; i++ {
+		clients[0].mockCall(`0x1`, "eth_chainId")
+		clients[1].mockCall(errors.New("error"), "eth_chainId")
+		_, _ = s.eth.ChainId()
+	}
+	_, err = s.eth.ChainId()
+	assert.ErrorIs(t, err, errNotEnoughHealthyEndpoints)
+}
+
+func TestWithCircuitBreaker_Invalid(t *testing.T) {
+	_, err := NewServer(withCallers(map[string]caller{}), WithRequirements(1, 10), WithCircuitBreaker(0, time.Second))
+	assert.Error(t, err)
+	_, err = NewServer(withCallers(map[string]caller{}), WithRequirements(1, 10), WithCircuitBreaker(1, 0))
+	assert.Error(t, err)
+}
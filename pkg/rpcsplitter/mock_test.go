@@ -45,6 +45,7 @@ type rpcRes struct {
 	Error   struct {
 		Code    int    `json:"code"`
 		Message string `json:"message"`
+		Data    any    `json:"data"`
 	} `json:"error"`
 }
 
@@ -111,6 +112,14 @@ func (c *mockClient) CallContext(ctx context.Context, result any, method string,
 	return json.Unmarshal(jsonMarshal(c.t, callResult), result)
 }
 
+// mockRPCError is an error returned by a node as a JSON-RPC error response.
+type mockRPCError struct {
+	msg string
+}
+
+func (e *mockRPCError) Error() string  { return e.msg }
+func (e *mockRPCError) ErrorCode() int { return -32000 }
+
 type handlerTester struct {
 	t *testing.T
 
@@ -120,6 +129,8 @@ type handlerTester struct {
 	expMethod string
 	expParams []any
 	expErrors []string
+	expData   any
+	hasData   bool
 }
 
 func prepareHandlerTest(t *testing.T, clients int, method string, params ...any) *handlerTester {
@@ -161,6 +172,13 @@ func (t *handlerTester) expectedError(msg string) *handlerTester {
 	return t
 }
 
+// expectedErrorData sets the expected data field of the returned error.
+func (t *handlerTester) expectedErrorData(data any) *handlerTester {
+	t.expData = data
+	t.hasData = true
+	return t
+}
+
 func (t *handlerTester) test() {
 	// Prepare server.
 	callers := map[string]caller{}
@@ -200,6 +218,9 @@ func (t *handlerTester) test() {
 				assert.Contains(t.t, res.Error.Message, e, "error message mismatch")
 			}
 		}
+		if t.hasData {
+			assert.JSONEq(t.t, string(jsonMarshal(t.t, t.expData)), string(jsonMarshal(t.t, res.Error.Data)), "error data mismatch")
+		}
 	} else {
 		assert.Equal(t.t, 0, res.Error.Code, "error code mismatch")
 		assert.Empty(t.t, res.Error.Message, "error message mismatch")
@@ -16,6 +16,7 @@
 package rpcsplitter
 
 import (
+	"fmt"
 	"time"
 
 	gethRPC "github.com/ethereum/go-ethereum/rpc"
@@ -55,12 +56,162 @@ func WithEndpoints(endpoints []string) Option {
 func WithRequirements(minResponses int, maxBlockBehind int) Option {
 	return func(s *server) error {
 		s.defaultResolver = &defaultResolver{minResponses: minResponses}
+		s.blockResolver = &blockResolver{minResponses: minResponses}
 		s.gasValueResolver = &gasValueResolver{minResponses: minResponses}
 		s.blockNumberResolver = &blockNumberResolver{minResponses: minResponses, maxBlocksBehind: maxBlockBehind}
 		return nil
 	}
 }
 
+// WithMethodRequirements overrides the minResponses requirement set by
+// WithRequirements for specific methods. The map keys are RPC method names,
+// e.g. "eth_getBalance", and the values are the minimum number of same or
+// valid responses, as described in WithRequirements. Methods not listed in
+// the map use the default requirement.
+//
+// The requirement of a method cannot exceed the number of endpoints.
+func WithMethodRequirements(minResponses map[string]int) Option {
+	return func(s *server) error {
+		for method, n := range minResponses {
+			if n < 1 {
+				return fmt.Errorf("requirement for method %s must be greater than zero, got %d", method, n)
+			}
+		}
+		s.methodRequirements = minResponses
+		return nil
+	}
+}
+
+// WithCallerWeights specifies the voting weights of endpoints. The response
+// of an endpoint is counted as many times as its weight, and the requirements
+// specified in WithRequirements and WithMethodRequirements are expressed in
+// weight units. Endpoints not listed have a weight of one.
+func WithCallerWeights(weights map[string]int) Option {
+	return func(s *server) error {
+		s.weights = make(map[string]int, len(weights))
+		for name, w := range weights {
+			if w < 1 {
+				return fmt.Errorf("weight for endpoint %s must be greater than zero", name)
+			}
+			s.weights[name] = w
+		}
+		return nil
+	}
+}
+
+// WithEnforceChainID enables verification of chain IDs of endpoints.
+// Endpoints connected to a chain other than expected are not used. If
+// expected is zero, the chain ID returned by more than half of all endpoints
+// is used. Chain IDs are verified when the server is created and then
+// periodically. If none of the endpoints is connected to the expected chain,
+// NewServer returns an error.
+func WithEnforceChainID(expected uint64) Option {
+	return func(s *server) error {
+		s.chainID = newChainIDGuard(expected, chainIDCheckInterval)
+		return nil
+	}
+}
+
+// WithResponseDebug enables diagnostics for failed requests. If responses
+// cannot be resolved, the data field of the JSON-RPC error contains
+// a summary of the responses of all endpoints. Results are replaced by
+// their hashes and only hosts of endpoints are included.
+func WithResponseDebug(enabled bool) Option {
+	return func(s *server) error {
+		s.responseDebug = enabled
+		return nil
+	}
+}
+
+// WithResolutionLogging enables logging of the resolution of every request.
+// The log contains the method, the number of queried endpoints, the number
+// of endpoints that returned the resolved response, the strategy used to
+// resolve it, and the duration of the request. Arguments of methods listed in
+// WithRedactedMethods are omitted.
+func WithResolutionLogging(enabled bool) Option {
+	return func(s *server) error {
+		s.resolutionLog = enabled
+		return nil
+	}
+}
+
+// WithRedactedMethods specifies methods whose arguments are never logged,
+// for example eth_sendRawTransaction.
+func WithRedactedMethods(methods []string) Option {
+	return func(s *server) error {
+		s.redactedMethods = make(map[string]struct{}, len(methods))
+		for _, m := range methods {
+			s.redactedMethods[m] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithRaceMethods specifies methods for which responses are not compared.
+// Instead, the request is sent to all endpoints and the first successful
+// response is returned, then the requests to the other endpoints are
+// canceled. It should only be used for methods that do not need consensus.
+func WithRaceMethods(methods []string) Option {
+	return func(s *server) error {
+		s.raceMethods = make(map[string]struct{}, len(methods))
+		for _, m := range methods {
+			s.raceMethods[m] = struct{}{}
+		}
+		return nil
+	}
+}
+
+// WithGasOutlierFactor enables outlier rejection for methods that return
+// a gas value, such as eth_gasPrice. Responses that are more than factor
+// times higher or lower than the median of all responses are discarded before
+// the final value is calculated. The remaining responses must still meet
+// the minResponses requirement. A factor of zero disables outlier rejection.
+func WithGasOutlierFactor(factor float64) Option {
+	return func(s *server) error {
+		if factor != 0 && factor <= 1 {
+			return fmt.Errorf("gas outlier factor must be greater than 1, got %f", factor)
+		}
+		s.gasOutlierFactor = factor
+		return nil
+	}
+}
+
+// WithGasPriceStrategy sets the percentile of responses returned by the
+// eth_gasPrice and eth_maxPriorityFeePerGas methods, e.g. 50 for the median
+// or 75 for a higher price that is more likely to be accepted. Errors are
+// ignored as long as the minResponses requirement is met. By default, the
+// median is returned, or the lower value if there are only two responses.
+func WithGasPriceStrategy(percentile float64) Option {
+	return func(s *server) error {
+		if percentile <= 0 || percentile > 100 {
+			return fmt.Errorf("gas price percentile must be between 0 and 100, got %f", percentile)
+		}
+		s.gasPricePercentile = percentile
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables tracking of endpoint health. An endpoint that
+// fails threshold times in a row, for example because it is unreachable or
+// times out, is ejected for the cooldown period. After the cooldown, a single
+// request is sent to the endpoint to check whether it works again.
+//
+// Ejected endpoints are not called and are not counted when checking the
+// minResponses requirement. If there are fewer healthy endpoints than
+// required, requests fail immediately.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(s *server) error {
+		if threshold < 1 {
+			return fmt.Errorf("circuit breaker threshold must be greater than zero, got %d", threshold)
+		}
+		if cooldown <= 0 {
+			return fmt.Errorf("circuit breaker cooldown must be greater than zero, got %s", cooldown)
+		}
+		s.breaker = newCircuitBreaker(threshold, cooldown)
+		return nil
+	}
+}
+
 // WithTotalTimeout sets the total timeout for all endpoints. When the timeout
 // is exceeded, RPC-Splitter cancels all requests to the endpoints.
 func WithTotalTimeout(t time.Duration) Option {
@@ -70,11 +221,13 @@ func WithTotalTimeout(t time.Duration) Option {
 	}
 }
 
-// WithGracefulTimeout sets a timeout for slower endpoints. If the RPC-Splitter
-// gets enough responses to return a valid response, it will wait until the
-// timeout for slower endpoints is exceeded. This will allow slower requests
-// to be gracefully finished, and for endpoints that calculate the median value,
-// it will return a more accurate response.
+// WithGracefulTimeout sets a timeout for slower endpoints. For methods that
+// calculate the median value, the RPC-Splitter waits for all endpoints until
+// the timeout is exceeded, so it will return a more accurate response. For
+// methods that require a quorum of agreeing responses, the response is
+// returned as soon as the quorum is reached, and the timeout only limits how
+// long the RPC-Splitter waits before it resolves the responses received so
+// far.
 func WithGracefulTimeout(t time.Duration) Option {
 	return func(s *server) error {
 		s.gracefulTimeout = t
@@ -82,6 +235,20 @@ func WithGracefulTimeout(t time.Duration) Option {
 	}
 }
 
+// WithCallerTimeout sets a timeout for a single endpoint. Endpoints that do
+// not respond in time are abandoned, so that the response can be resolved
+// from the other endpoints before the total timeout is exceeded. Zero
+// disables the timeout.
+func WithCallerTimeout(t time.Duration) Option {
+	return func(s *server) error {
+		if t < 0 {
+			return fmt.Errorf("caller timeout must not be negative")
+		}
+		s.callerTimeout = t
+		return nil
+	}
+}
+
 // WithLogger sets logger.
 func WithLogger(logger log.Logger) Option {
 	return func(s *server) error {
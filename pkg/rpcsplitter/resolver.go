@@ -17,6 +17,7 @@ package rpcsplitter
 
 import (
 	"errors"
+	"math"
 	"math/big"
 	"sort"
 
@@ -25,6 +26,7 @@ import (
 
 var errNotEnoughResponses = errors.New("not enough responses from RPC servers")
 var errDifferentResponses = errors.New("RPC servers returned different responses")
+var errNotEnoughHealthyEndpoints = errors.New("not enough healthy RPC servers")
 
 // resolver takes responses from different endpoints and returns a single
 // response.
@@ -45,6 +47,9 @@ func (r *defaultResolver) resolve(resps []any) (any, error) {
 		return nil, addError(errNotEnoughResponses, collectErrors(resps)...)
 	}
 	if len(resps) == 1 {
+		if err, ok := resps[0].(error); ok {
+			return nil, addError(errNotEnoughResponses, err)
+		}
 		return resps[0], nil
 	}
 	mostCommonResp := resps[0]
@@ -75,14 +80,106 @@ func (r *defaultResolver) resolve(resps []any) (any, error) {
 	return mostCommonResp, nil
 }
 
+// blockResolver is designed to handle responses from methods returning a
+// block, such as eth_getBlockByNumber. Instead of comparing whole blocks,
+// it compares only block hashes, because some fields, like totalDifficulty,
+// may be formatted differently by different endpoints. Of the responses
+// with the most common block hash, the most common one is returned. If there
+// are multiple hashes with the same number of occurrences, an error is
+// returned.
+type blockResolver struct {
+	minResponses int // specifies minimum number of occurrences of the most common block hash
+}
+
+// resolve implements resolver interface.
+func (r *blockResolver) resolve(resps []any) (any, error) {
+	if len(resps) < r.minResponses {
+		return nil, addError(errNotEnoughResponses, collectErrors(resps)...)
+	}
+	var (
+		hashes []types.Hash
+		blocks = map[types.Hash][]any{}
+	)
+	for _, resp := range resps {
+		hash, ok := blockHash(resp)
+		if !ok {
+			continue
+		}
+		if _, ok := blocks[hash]; !ok {
+			hashes = append(hashes, hash)
+		}
+		blocks[hash] = append(blocks[hash], resp)
+	}
+	if len(hashes) == 0 {
+		return nil, addError(errNotEnoughResponses, collectErrors(resps)...)
+	}
+	mostCommon := hashes[0]
+	multiple := false
+	for _, hash := range hashes[1:] {
+		switch {
+		case len(blocks[hash]) > len(blocks[mostCommon]):
+			mostCommon = hash
+			multiple = false
+		case len(blocks[hash]) == len(blocks[mostCommon]):
+			multiple = true
+		}
+	}
+	if multiple || len(blocks[mostCommon]) < r.minResponses {
+		return nil, addError(errDifferentResponses, collectErrors(resps)...)
+	}
+	return mostCommonResponse(blocks[mostCommon]), nil
+}
+
+// mostCommonResponse returns the response that occurs most often in the
+// given list. If there are multiple such responses, the first one is
+// returned.
+func mostCommonResponse(resps []any) any {
+	mostCommonResp := resps[0]
+	mostCommonCounter := 0
+	for _, a := range resps {
+		counter := 0
+		for _, b := range resps {
+			if compare(a, b) {
+				counter++
+			}
+		}
+		if counter > mostCommonCounter {
+			mostCommonResp = a
+			mostCommonCounter = counter
+		}
+	}
+	return mostCommonResp
+}
+
+// blockHash returns the hash of a block response.
+func blockHash(resp any) (types.Hash, bool) {
+	switch b := resp.(type) {
+	case *types.BlockTxHashes:
+		return b.Hash, true
+	case *types.BlockTxObjects:
+		return b.Hash, true
+	}
+	return types.Hash{}, false
+}
+
 // gasValueResolver is designed to handle responses from methods returning a
 // gas value. The way how the response is calculated depends on the number of
 // responses:
 // * one response: returns value as is
 // * two responses: returns the lowest one
 // * three or more responses: returns the median value
+//
+// If percentile is set, the given percentile of all responses is returned
+// instead, regardless of the number of responses.
+//
+// If outlierFactor is greater than one, and there are at least three
+// responses, values that are more than outlierFactor times higher or lower
+// than the median are discarded before the final value is calculated. The
+// remaining responses must still satisfy minResponses.
 type gasValueResolver struct {
-	minResponses int // specifies minimum number of valid responses
+	minResponses  int     // specifies minimum number of valid responses
+	outlierFactor float64 // specifies how far from the median a value can be, zero disables outlier rejection
+	percentile    float64 // specifies the percentile of responses to return, zero to use the default strategy
 }
 
 // resolve implements resolver interface.
@@ -91,8 +188,17 @@ func (r *gasValueResolver) resolve(resps []any) (any, error) {
 	if len(ns) < r.minResponses {
 		return nil, addError(errNotEnoughResponses, collectErrors(resps)...)
 	}
+	if r.outlierFactor > 1 && len(ns) >= 3 {
+		ns = rejectOutliers(ns, r.outlierFactor)
+		if len(ns) < r.minResponses {
+			return nil, addError(errNotEnoughResponses, collectErrors(resps)...)
+		}
+	}
 	if len(ns) == 1 {
-		return resps[0], nil
+		return ns[0], nil
+	}
+	if r.percentile > 0 {
+		return percentile(ns, r.percentile), nil
 	}
 	if len(ns) == 2 {
 		// With two correct answers, it is safer to return the lower value.
@@ -106,17 +212,7 @@ func (r *gasValueResolver) resolve(resps []any) (any, error) {
 		}
 		return bigToNumberPtr(a), nil
 	}
-	// Calculate the median.
-	sort.Slice(ns, func(i, j int) bool {
-		return ns[i].Big().Cmp(ns[j].Big()) < 0
-	})
-	if len(ns)%2 == 0 {
-		m := len(ns) / 2
-		bx := ns[m-1].Big()
-		by := ns[m].Big()
-		return bigToNumberPtr(new(big.Int).Div(new(big.Int).Add(bx, by), big.NewInt(2))), nil
-	}
-	return ns[len(ns)/2], nil
+	return median(ns), nil
 }
 
 // blockNumberResolver is designed to handle responses from eth_blockNumber method.
@@ -157,6 +253,130 @@ func (r *blockNumberResolver) resolve(resps []any) (any, error) {
 	return bigToNumberPtr(block), nil
 }
 
+// withMinResponses returns a copy of the resolver with the minResponses
+// requirement set to n. Resolvers of unknown types are returned as is.
+func withMinResponses(r resolver, n int) resolver {
+	switch r := r.(type) {
+	case *defaultResolver:
+		c := *r
+		c.minResponses = n
+		return &c
+	case *blockResolver:
+		c := *r
+		c.minResponses = n
+		return &c
+	case *gasValueResolver:
+		c := *r
+		c.minResponses = n
+		return &c
+	case *blockNumberResolver:
+		c := *r
+		c.minResponses = n
+		return &c
+	}
+	return r
+}
+
+// minResponses returns the minResponses requirement of the resolver. For
+// resolvers of unknown types, it returns zero.
+func minResponses(r resolver) int {
+	switch r := r.(type) {
+	case *defaultResolver:
+		return r.minResponses
+	case *blockResolver:
+		return r.minResponses
+	case *gasValueResolver:
+		return r.minResponses
+	case *blockNumberResolver:
+		return r.minResponses
+	}
+	return 0
+}
+
+// resolvesEarly returns true if the resolver requires a quorum of agreeing
+// responses. Such a resolver can be called before all endpoints respond, so
+// the response is returned as soon as the quorum is reached. Other resolvers
+// calculate the response from all responses, e.g. the median gas price.
+func resolvesEarly(r resolver) bool {
+	switch r.(type) {
+	case *defaultResolver, *blockResolver:
+		return true
+	}
+	return false
+}
+
+// resolverName returns the name of the strategy used by the resolver.
+func resolverName(r resolver) string {
+	switch r := r.(type) {
+	case *defaultResolver:
+		return "most-common"
+	case *blockResolver:
+		return "block-hash"
+	case *gasValueResolver:
+		if r.percentile > 0 {
+			return "percentile"
+		}
+		return "median"
+	case *blockNumberResolver:
+		return "block-number"
+	}
+	return "unknown"
+}
+
+// rejectOutliers returns only those numbers that are not more than factor
+// times higher or lower than the median of all numbers.
+func rejectOutliers(ns []*types.Number, factor float64) []*types.Number {
+	m := new(big.Float).SetInt(median(ns).Big())
+	hi := new(big.Float).Mul(m, big.NewFloat(factor))
+	lo := new(big.Float).Quo(m, big.NewFloat(factor))
+	var s []*types.Number
+	for _, n := range ns {
+		f := new(big.Float).SetInt(n.Big())
+		if f.Cmp(hi) > 0 || f.Cmp(lo) < 0 {
+			continue
+		}
+		s = append(s, n)
+	}
+	return s
+}
+
+// median returns the median of the given numbers. For an even number of
+// elements, the average of the two middle values is returned. The slice is
+// sorted in place.
+func median(ns []*types.Number) *types.Number {
+	sort.Slice(ns, func(i, j int) bool {
+		return ns[i].Big().Cmp(ns[j].Big()) < 0
+	})
+	if len(ns)%2 == 0 {
+		m := len(ns) / 2
+		bx := ns[m-1].Big()
+		by := ns[m].Big()
+		return bigToNumberPtr(new(big.Int).Div(new(big.Int).Add(bx, by), big.NewInt(2)))
+	}
+	return ns[len(ns)/2]
+}
+
+// percentile returns the p-th percentile of the given numbers, where p is
+// between 0 and 100. Values between ranks are linearly interpolated and
+// rounded down, so the 50th percentile is the same as the median. The slice
+// is sorted in place.
+func percentile(ns []*types.Number, p float64) *types.Number {
+	sort.Slice(ns, func(i, j int) bool {
+		return ns[i].Big().Cmp(ns[j].Big()) < 0
+	})
+	rank := p / 100 * float64(len(ns)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	if lo == hi {
+		return ns[lo]
+	}
+	bx := new(big.Float).SetInt(ns[lo].Big())
+	by := new(big.Float).SetInt(ns[hi].Big())
+	d := new(big.Float).Mul(new(big.Float).Sub(by, bx), big.NewFloat(rank-float64(lo)))
+	x, _ := new(big.Float).Add(bx, d).Int(nil)
+	return bigToNumberPtr(x)
+}
+
 func filterByNumberType(resps []any) (s []*types.Number) {
 	for _, r := range resps {
 		if t, ok := r.(*types.Number); ok {
@@ -97,6 +97,68 @@ func Test_defaultResolver_resolve(t *testing.T) {
 	}
 }
 
+func Test_blockResolver_resolve(t *testing.T) {
+	block := func(hash string, totalDifficulty string) *types.BlockTxHashes {
+		b := &types.BlockTxHashes{}
+		b.Hash = types.HexToHash(hash)
+		b.TotalDifficulty = types.HexToNumber(totalDifficulty)
+		return b
+	}
+	tests := []struct {
+		resps        []any
+		minResponses int
+		want         any
+		wantErr      bool
+	}{
+		{
+			resps:        []any{block("0x01", "0x1")},
+			minResponses: 1,
+			want:         block("0x01", "0x1"),
+		},
+		{
+			resps:        []any{block("0x01", "0x1"), block("0x01", "0x2"), block("0x02", "0x1")},
+			minResponses: 2,
+			want:         block("0x01", "0x1"),
+		},
+		{
+			resps:        []any{block("0x02", "0x1"), block("0x01", "0x2"), block("0x01", "0x1")},
+			minResponses: 2,
+			want:         block("0x01", "0x2"),
+		},
+		{
+			resps:        []any{block("0x01", "0x2"), block("0x01", "0x1"), block("0x01", "0x1")},
+			minResponses: 3,
+			want:         block("0x01", "0x1"),
+		},
+		{
+			resps:        []any{block("0x01", "0x1"), errors.New("err"), errors.New("err")},
+			minResponses: 2,
+			wantErr:      true,
+		},
+		{
+			resps:        []any{block("0x01", "0x1"), block("0x02", "0x1")},
+			minResponses: 1,
+			wantErr:      true,
+		},
+		{
+			resps:        []any{errors.New("err"), errors.New("err")},
+			minResponses: 1,
+			wantErr:      true,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n), func(t *testing.T) {
+			r := blockResolver{minResponses: tt.minResponses}
+			v, err := r.resolve(tt.resps)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
 func Test_gasValueResolver_resolve(t *testing.T) {
 	tests := []struct {
 		resps        []any
@@ -163,6 +225,121 @@ func Test_gasValueResolver_resolve(t *testing.T) {
 	}
 }
 
+func Test_gasValueResolver_resolve_outliers(t *testing.T) {
+	tests := []struct {
+		resps         []any
+		minResponses  int
+		outlierFactor float64
+		want          any
+		wantErr       bool
+	}{
+		{
+			// Outlier rejection disabled.
+			resps:         []any{hexToNumberPtr(`0x10`), hexToNumberPtr(`0x11`), hexToNumberPtr(`0x1000`), hexToNumberPtr(`0x1000`)},
+			minResponses:  3,
+			outlierFactor: 0,
+			want:          hexToNumberPtr(`0x808`),
+		},
+		{
+			resps:         []any{hexToNumberPtr(`0x10`), hexToNumberPtr(`0x12`), hexToNumberPtr(`0x14`), hexToNumberPtr(`0x1000`)},
+			minResponses:  3,
+			outlierFactor: 2,
+			want:          hexToNumberPtr(`0x12`),
+		},
+		{
+			resps:         []any{hexToNumberPtr(`0x1`), hexToNumberPtr(`0x10`), hexToNumberPtr(`0x12`), hexToNumberPtr(`0x14`)},
+			minResponses:  3,
+			outlierFactor: 2,
+			want:          hexToNumberPtr(`0x12`),
+		},
+		{
+			// Only two values remain, the lower one is used.
+			resps:         []any{hexToNumberPtr(`0x10`), hexToNumberPtr(`0x12`), hexToNumberPtr(`0x1000`)},
+			minResponses:  2,
+			outlierFactor: 2,
+			want:          hexToNumberPtr(`0x10`),
+		},
+		{
+			// Not enough responses after rejecting outliers.
+			resps:         []any{hexToNumberPtr(`0x10`), hexToNumberPtr(`0x12`), hexToNumberPtr(`0x1000`)},
+			minResponses:  3,
+			outlierFactor: 2,
+			wantErr:       true,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n), func(t *testing.T) {
+			r := gasValueResolver{minResponses: tt.minResponses, outlierFactor: tt.outlierFactor}
+			v, err := r.resolve(tt.resps)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func Test_gasValueResolver_resolve_percentile(t *testing.T) {
+	tests := []struct {
+		resps        []any
+		minResponses int
+		percentile   float64
+		want         any
+		wantErr      bool
+	}{
+		{
+			resps:        []any{hexToNumberPtr(`0x1`), hexToNumberPtr(`0x3`), hexToNumberPtr(`0x2`)},
+			minResponses: 3,
+			percentile:   50,
+			want:         hexToNumberPtr(`0x2`),
+		},
+		{
+			// Two values are interpolated instead of returning the lower one.
+			resps:        []any{hexToNumberPtr(`0x10`), hexToNumberPtr(`0x20`)},
+			minResponses: 2,
+			percentile:   75,
+			want:         hexToNumberPtr(`0x1c`),
+		},
+		{
+			resps:        []any{hexToNumberPtr(`0x1`), hexToNumberPtr(`0x2`), hexToNumberPtr(`0x3`), hexToNumberPtr(`0x4`), hexToNumberPtr(`0x5`)},
+			minResponses: 3,
+			percentile:   75,
+			want:         hexToNumberPtr(`0x4`),
+		},
+		{
+			resps:        []any{hexToNumberPtr(`0x1`), hexToNumberPtr(`0x2`), hexToNumberPtr(`0x3`)},
+			minResponses: 3,
+			percentile:   100,
+			want:         hexToNumberPtr(`0x3`),
+		},
+		{
+			// Errors are ignored if there are enough valid responses.
+			resps:        []any{hexToNumberPtr(`0x1`), errors.New("error"), hexToNumberPtr(`0x3`)},
+			minResponses: 2,
+			percentile:   50,
+			want:         hexToNumberPtr(`0x2`),
+		},
+		{
+			resps:        []any{hexToNumberPtr(`0x1`), errors.New("error"), errors.New("error")},
+			minResponses: 2,
+			percentile:   50,
+			wantErr:      true,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n), func(t *testing.T) {
+			r := gasValueResolver{minResponses: tt.minResponses, percentile: tt.percentile}
+			v, err := r.resolve(tt.resps)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
 func Test_blockNumberResolver_resolve(t *testing.T) {
 	tests := []struct {
 		resps           []any
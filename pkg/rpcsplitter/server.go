@@ -54,10 +54,34 @@ type server struct {
 	// Timeout for slower endpoints, when it exceeds, request will be canceled
 	// if there is enough responses.
 	gracefulTimeout time.Duration
+	// Timeout for a single endpoint, zero if disabled.
+	callerTimeout time.Duration
+	// Factor used to reject outliers in gas value responses.
+	gasOutlierFactor float64
+	// Percentile of gas price responses to return, zero to use the median.
+	gasPricePercentile float64
+	// Minimum number of responses for specific methods.
+	methodRequirements map[string]int
+	// Tracks the health of endpoints, nil if disabled.
+	breaker *circuitBreaker
+	// Verifies chain IDs of endpoints, nil if disabled.
+	chainID *chainIDGuard
+	// Methods for which the first successful response is returned.
+	raceMethods map[string]struct{}
+	// Voting weights of endpoints, endpoints not listed have a weight of one.
+	weights map[string]int
+	// If true, errors include responses of all endpoints in the data field.
+	responseDebug bool
+	// If true, the resolution of every request is logged.
+	resolutionLog bool
+	// Methods whose arguments are not logged.
+	redactedMethods map[string]struct{}
 
 	// Resolvers used to convert multiple responses into a single response:
 	defaultResolver     *defaultResolver
+	blockResolver       *blockResolver
 	gasValueResolver    *gasValueResolver
+	gasPriceResolver    *gasValueResolver
 	blockNumberResolver *blockNumberResolver
 }
 
@@ -96,9 +120,29 @@ func NewServer(opts ...Option) (http.Handler, error) {
 	if h.callers == nil {
 		return nil, fmt.Errorf("rpc-splitter error: WithEndpoints option is required")
 	}
-	if h.defaultResolver == nil || h.gasValueResolver == nil || h.blockNumberResolver == nil {
+	if h.defaultResolver == nil || h.blockResolver == nil || h.gasValueResolver == nil || h.blockNumberResolver == nil {
 		return nil, fmt.Errorf("rpc-splitter error: WithRequirements option is required")
 	}
+	for name := range h.weights {
+		if _, ok := h.callers[name]; !ok {
+			return nil, fmt.Errorf("rpc-splitter error: weight specified for unknown endpoint %s", name)
+		}
+	}
+	total := h.totalWeight(h.callers)
+	for method, n := range h.methodRequirements {
+		if n > total {
+			return nil, fmt.Errorf(
+				"rpc-splitter error: requirement for method %s (%d) exceeds the total weight of endpoints (%d)",
+				method, n, total,
+			)
+		}
+	}
+	h.gasValueResolver.outlierFactor = h.gasOutlierFactor
+	h.gasPriceResolver = &gasValueResolver{
+		minResponses:  h.gasValueResolver.minResponses,
+		outlierFactor: h.gasOutlierFactor,
+		percentile:    h.gasPricePercentile,
+	}
 	if h.totalTimeout == 0 {
 		h.totalTimeout = defaultTotalTimeout
 	}
@@ -106,6 +150,13 @@ func NewServer(opts ...Option) (http.Handler, error) {
 		h.gracefulTimeout = defaultGracefulTimeout
 	}
 	h.log = h.log.WithField("tag", LoggerTag)
+	if h.chainID != nil {
+		ctx, ctxCancel := context.WithTimeout(context.Background(), h.totalTimeout)
+		defer ctxCancel()
+		if err := h.checkChainID(ctx); err != nil {
+			return nil, fmt.Errorf("rpc-splitter error: %w", err)
+		}
+	}
 	return h, nil
 }
 
@@ -149,8 +200,9 @@ func (r *rpcETHAPI) GetBlockByHash(blockHash types.Hash, obj bool) (any, error)
 
 // GetBlockByNumber implements the "eth_getBlockByNumber" call.
 //
-// It returns the most common response that occurred at least as many times as
-// specified in the minRes method.
+// It returns a block with the most common hash that occurred at least as many
+// times as specified in the minRes method. Other fields of the block are not
+// compared.
 func (r *rpcETHAPI) GetBlockByNumber(blockNumber types.Number, obj bool) (any, error) {
 	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
 	defer ctxCancel()
@@ -162,7 +214,7 @@ func (r *rpcETHAPI) GetBlockByNumber(blockNumber types.Number, obj bool) (any, e
 	case false:
 		res = &types.BlockTxHashes{}
 	}
-	err := r.handler.call(ctx, r.handler.defaultResolver, res, "eth_getBlockByNumber", blockNumber, obj)
+	err := r.handler.call(ctx, r.handler.blockResolver, res, "eth_getBlockByNumber", blockNumber, obj)
 
 	return res, err
 }
@@ -224,15 +276,15 @@ func (r *rpcETHAPI) GetTransactionReceipt(txHash types.Hash) (any, error) {
 
 // SendRawTransaction implements the "eth_sendRawTransaction" call.
 //
-// It returns the most common response.
+// The transaction is sent to all endpoints and the hash is returned as soon
+// as any endpoint accepts it. If all endpoints reject the transaction, the
+// most common JSON-RPC error is returned.
 func (r *rpcETHAPI) SendRawTransaction(data types.Bytes) (any, error) {
-	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
-	defer ctxCancel()
-
-	res := &types.Hash{}
-	err := r.handler.call(ctx, r.handler.defaultResolver, res, "eth_sendRawTransaction", data)
-
-	return res, err
+	res, err := r.handler.broadcast(data)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
 }
 
 // GetBalance implements the "eth_getBalance" call.
@@ -363,13 +415,13 @@ func (r *rpcETHAPI) GetLogs(logFilter types.FilterLogsQuery) (any, error) {
 // GasPrice implements the "eth_gasPrice" call.
 //
 // The number returned by this method is the median of all numbers returned
-// by the endpoints.
+// by the endpoints, or the percentile specified in WithGasPriceStrategy.
 func (r *rpcETHAPI) GasPrice() (any, error) {
 	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
 	defer ctxCancel()
 
 	res := &types.Number{}
-	err := r.handler.call(ctx, r.handler.gasValueResolver, res, "eth_gasPrice")
+	err := r.handler.call(ctx, r.handler.gasPriceResolver, res, "eth_gasPrice")
 
 	return res, err
 }
@@ -417,13 +469,13 @@ func (r *rpcETHAPI) FeeHistory(count types.Number, newestBlockID types.BlockNumb
 // MaxPriorityFeePerGas implements the "eth_maxPriorityFeePerGas" call.
 //
 // The number returned by this method is the median of all numbers returned
-// by the endpoints.
+// by the endpoints, or the percentile specified in WithGasPriceStrategy.
 func (r *rpcETHAPI) MaxPriorityFeePerGas() (any, error) {
 	ctx, ctxCancel := context.WithTimeout(context.Background(), r.handler.totalTimeout)
 	defer ctxCancel()
 
 	res := &types.Number{}
-	err := r.handler.call(ctx, r.handler.gasValueResolver, res, "eth_maxPriorityFeePerGas")
+	err := r.handler.call(ctx, r.handler.gasPriceResolver, res, "eth_maxPriorityFeePerGas")
 
 	return res, err
 }
@@ -509,21 +561,162 @@ func (s *server) call(
 		return fmt.Errorf("call result parameter must be pointer")
 	}
 
+	start := time.Now()
+
 	// Recover from panics.
 	defer func() {
 		if r := recover(); r != nil {
 			s.log.
 				WithField("method", method).
-				WithField("args", args).
+				WithField("args", s.redactArgs(method, args)).
 				WithError(fmt.Errorf("panic: %s", r)).
 				Error("Panic")
 		}
 	}()
 
-	// Send request to all endpoints.
-	ch := make(chan any, len(s.callers))
-	rt := reflect.TypeOf(result).Elem()
-	for n, c := range s.callers {
+	if n, ok := s.methodRequirements[method]; ok {
+		resolver = withMinResponses(resolver, n)
+	}
+
+	// Skip endpoints ejected by the circuit breaker.
+	_, race := s.raceMethods[method]
+	callers := s.healthyCallers()
+	if len(callers) == 0 || (!race && s.totalWeight(callers) < minResponses(resolver)) {
+		if s.breaker != nil {
+			for n := range callers {
+				s.breaker.release(n)
+			}
+		}
+		return errNotEnoughHealthyEndpoints
+	}
+
+	if race {
+		err := s.race(ctx, callers, result, method, args...)
+		agreeing := 0
+		if err == nil {
+			agreeing = 1
+		}
+		s.logResolution(resolution{
+			method:   method,
+			args:     args,
+			strategy: "race",
+			callers:  len(callers),
+			agreeing: agreeing,
+			start:    start,
+			err:      err,
+		})
+		return err
+	}
+
+	// Send request to all endpoints. Requests that are still pending after
+	// the response is resolved are canceled.
+	ctx, ctxCancel := context.WithCancel(ctx)
+	defer ctxCancel()
+	ch := s.callAll(ctx, callers, reflect.TypeOf(result).Elem(), method, args)
+
+	// Wait for responses. Resolvers that require a quorum of agreeing
+	// responses are called after each response, so the call returns as soon
+	// as the quorum is reached. Other resolvers use all responses, so they
+	// are called once all endpoints respond. If gracefulTimeout exceeds,
+	// the responses received so far are resolved, and then again after each
+	// response, until a valid response is found or all endpoints respond.
+	//
+	// The response of each endpoint is passed to the resolver as many times
+	// as the weight of the endpoint.
+	t := time.NewTimer(s.gracefulTimeout)
+	defer t.Stop()
+	var (
+		rs       []any
+		resps    []response
+		early    = resolvesEarly(resolver)
+		timedOut bool
+	)
+	for {
+		select {
+		case r := <-ch:
+			for i := 0; i < s.weight(r.name); i++ {
+				rs = append(rs, r.value)
+			}
+			resps = append(resps, r)
+			if !early && !timedOut && len(resps) < len(callers) {
+				continue
+			}
+		case <-t.C:
+			timedOut = true
+		}
+		res, err := resolver.resolve(rs)
+		if err == nil || len(resps) >= len(callers) {
+			s.logResolution(resolution{
+				method:   method,
+				args:     args,
+				strategy: resolverName(resolver),
+				callers:  len(callers),
+				agreeing: countAgreeing(resps, res),
+				start:    start,
+				err:      err,
+			})
+		}
+		switch {
+		case err == nil:
+			reflect.ValueOf(result).Elem().Set(reflect.ValueOf(res).Elem())
+			return nil
+		case len(resps) >= len(callers):
+			if s.responseDebug {
+				return &debugError{err: err, data: summarizeResponses(resps)}
+			}
+			return err
+		}
+	}
+}
+
+// race executes RPC on all endpoints and returns the first successful
+// response. Once a response is received, the requests to the other endpoints
+// are canceled. If all endpoints fail, the errors are returned.
+func (s *server) race(
+	ctx context.Context,
+	callers map[string]caller,
+	result any,
+	method string,
+	args ...any,
+) error {
+
+	ctx, ctxCancel := context.WithCancel(ctx)
+	defer ctxCancel()
+
+	ch := s.callAll(ctx, callers, reflect.TypeOf(result).Elem(), method, args)
+	var errs []error
+	for range callers {
+		r := <-ch
+		if err, ok := r.value.(error); ok {
+			errs = append(errs, err)
+			continue
+		}
+		reflect.ValueOf(result).Elem().Set(reflect.ValueOf(r.value).Elem())
+		return nil
+	}
+	return addError(errNotEnoughResponses, errs...)
+}
+
+// response is a response from a single endpoint. The value is either
+// a result or an error.
+type response struct {
+	name  string
+	value any
+}
+
+// callAll sends the request to all given endpoints concurrently. Either the
+// result, which is a pointer to a new value of the rt type, or an error is
+// sent to the returned channel for every endpoint.
+func (s *server) callAll(
+	ctx context.Context,
+	callers map[string]caller,
+	rt reflect.Type,
+	method string,
+	args []any,
+) chan response {
+
+	ch := make(chan response, len(callers))
+	for n, c := range callers {
 		n, c := n, c
 		go func() {
 			t := time.Now()
@@ -533,57 +726,174 @@ func (s *server) call(
 				if r := recover(); r != nil {
 					err = fmt.Errorf("panic: %s", r)
 				}
+				s.reportHealth(ctx, n, err)
 				switch {
 				case err != nil:
 					s.log.
 						WithField("name", n).
 						WithField("method", method).
-						WithField("args", args).
+						WithField("args", s.redactArgs(method, args)).
 						WithField("duration", time.Since(t)).
 						WithError(err).
 						Error("Call error")
-					ch <- err
+					ch <- response{name: n, value: err}
 				default:
 					s.log.
 						WithField("name", n).
 						WithField("method", method).
-						WithField("args", args).
+						WithField("args", s.redactArgs(method, args)).
 						WithField("duration", time.Since(t)).
 						Debug("Call")
-					ch <- res
+					ch <- response{name: n, value: res}
 				}
 			}()
+			cctx := ctx
+			if s.callerTimeout > 0 {
+				var cctxCancel context.CancelFunc
+				cctx, cctxCancel = context.WithTimeout(ctx, s.callerTimeout)
+				defer cctxCancel()
+			}
 			res = reflect.New(rt).Interface()
-			err = c.CallContext(ctx, res, method, removeTrailingNilArgs(args)...)
+			err = c.CallContext(cctx, res, method, removeTrailingNilArgs(args)...)
 		}()
 	}
-	// Wait for response. The following code will wait for the above requests
-	// to complete, but if gracefulTimeout exceeds and there are enough
-	// responses to return a valid response, then the context will be canceled
-	// and the response returned.
-	t := time.NewTimer(s.gracefulTimeout)
-	defer t.Stop()
-	var rs []any
-	for {
-		wait := true
-		select {
-		case r := <-ch:
-			rs = append(rs, r)
-		case <-t.C:
-			wait = false
+	return ch
+}
+
+// weight returns the voting weight of the endpoint.
+func (s *server) weight(name string) int {
+	if w, ok := s.weights[name]; ok {
+		return w
+	}
+	return 1
+}
+
+// totalWeight returns the sum of the voting weights of the given endpoints.
+func (s *server) totalWeight(callers map[string]caller) int {
+	total := 0
+	for n := range callers {
+		total += s.weight(n)
+	}
+	return total
+}
+
+// healthyCallers returns endpoints that are connected to the expected chain
+// and are not ejected by the circuit breaker. If the chain IDs are due to be
+// checked again, the check is started in the background.
+func (s *server) healthyCallers() map[string]caller {
+	all := s.callers
+	if s.chainID != nil {
+		if s.chainID.due() {
+			go func() {
+				ctx, ctxCancel := context.WithTimeout(context.Background(), s.totalTimeout)
+				defer ctxCancel()
+				if err := s.checkChainID(ctx); err != nil {
+					s.log.WithError(err).Error("Chain ID check failed")
+				}
+			}()
 		}
-		if len(rs) == len(s.callers) {
-			wait = false
+		all = s.chainID.filter(all)
+	}
+	if s.breaker == nil {
+		return all
+	}
+	callers := make(map[string]caller, len(all))
+	for n, c := range all {
+		if s.breaker.allow(n) {
+			callers[n] = c
 		}
-		if !wait {
-			res, err := resolver.resolve(rs)
-			switch {
-			case err == nil:
-				reflect.ValueOf(result).Elem().Set(reflect.ValueOf(res).Elem())
-				return nil
-			case len(rs) >= len(s.callers):
-				return err
-			}
+	}
+	return callers
+}
+
+// resolution describes how the response to a request was resolved.
+type resolution struct {
+	method   string
+	args     []any
+	strategy string    // name of the strategy used to resolve the response
+	callers  int       // number of endpoints queried
+	agreeing int       // number of endpoints that returned the resolved response
+	start    time.Time // time when the request was received
+	err      error
+}
+
+// logResolution logs how the response to a request was resolved, if enabled
+// by the WithResolutionLogging option.
+func (s *server) logResolution(r resolution) {
+	if !s.resolutionLog {
+		return
+	}
+	l := s.log.WithFields(log.Fields{
+		"method":   r.method,
+		"args":     s.redactArgs(r.method, r.args),
+		"strategy": r.strategy,
+		"callers":  r.callers,
+		"agreeing": r.agreeing,
+		"duration": time.Since(r.start),
+	})
+	if r.err != nil {
+		l.WithError(r.err).Info("Request failed")
+		return
+	}
+	l.Info("Request resolved")
+}
+
+// redactArgs returns the arguments to be logged. Arguments of methods listed
+// in the WithRedactedMethods option are omitted.
+func (s *server) redactArgs(method string, args []any) any {
+	if _, ok := s.redactedMethods[method]; ok {
+		return "[redacted]"
+	}
+	return args
+}
+
+// countAgreeing returns the number of endpoints that returned a response
+// equal to res.
+func countAgreeing(resps []response, res any) int {
+	if res == nil {
+		return 0
+	}
+	n := 0
+	for _, r := range resps {
+		if compare(r.value, res) {
+			n++
+		}
+	}
+	return n
+}
+
+// checkChainID verifies that endpoints are connected to the expected chain.
+func (s *server) checkChainID(ctx context.Context) error {
+	mismatched, err := s.chainID.check(ctx, s.callers)
+	for _, n := range mismatched {
+		s.log.
+			WithField("name", n).
+			Warn("Endpoint excluded due to chain ID mismatch")
+	}
+	return err
+}
+
+// reportHealth reports the result of a request to the circuit breaker.
+//
+// Errors returned by the RPC server, like reverted calls, do not affect the
+// endpoint health, because the endpoint works correctly. Requests canceled
+// after enough responses were received are not counted either.
+func (s *server) reportHealth(ctx context.Context, name string, err error) {
+	if s.breaker == nil {
+		return
+	}
+	var rpcErr gethRPC.Error
+	switch {
+	case err == nil || errors.As(err, &rpcErr):
+		s.breaker.success(name)
+	case errors.Is(ctx.Err(), context.Canceled):
+		s.breaker.release(name)
+	default:
+		if s.breaker.failure(name) {
+			s.log.
+				WithField("name", name).
+				WithError(err).
+				Warn("Endpoint ejected due to errors")
 		}
 	}
 }
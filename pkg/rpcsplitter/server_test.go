@@ -18,9 +18,17 @@ package rpcsplitter
 import (
 	"encoding/json"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/callback"
 	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter/types"
 )
 
@@ -391,6 +399,35 @@ func Test_RPC_GetBlockByNumber(t *testing.T) {
 			expectedError("error#2").
 			test()
 	})
+	t.Run("different-non-canonical-fields", func(t *testing.T) {
+		otherTotalDifficulty := json.RawMessage(strings.Replace(
+			string(blockWithHashesResp),
+			`"totalDifficulty": "0x262c34a6fd1268f6c"`,
+			`"totalDifficulty": "0x1"`,
+			1,
+		))
+		prepareHandlerTest(t, 3, "eth_getBlockByNumber", blockNumber, false).
+			setOptions(WithRequirements(3, 10)).
+			mockClientCall(0, blockWithHashesResp, "eth_getBlockByNumber", blockNumber, false).
+			mockClientCall(1, otherTotalDifficulty, "eth_getBlockByNumber", blockNumber, false).
+			mockClientCall(2, blockWithHashesResp, "eth_getBlockByNumber", blockNumber, false).
+			expectedResult(blockWithHashesResp).
+			test()
+	})
+	t.Run("different-hashes", func(t *testing.T) {
+		otherHash := json.RawMessage(strings.Replace(
+			string(blockWithHashesResp),
+			`"hash": "0xc0f4906fea23cf6f3cce98cb44e8e1449e455b28d684dfa9ff65426495584de6"`,
+			`"hash": "0x0000000000000000000000000000000000000000000000000000000000000001"`,
+			1,
+		))
+		prepareHandlerTest(t, 2, "eth_getBlockByNumber", blockNumber, false).
+			setOptions(WithRequirements(2, 10)).
+			mockClientCall(0, blockWithHashesResp, "eth_getBlockByNumber", blockNumber, false).
+			mockClientCall(1, otherHash, "eth_getBlockByNumber", blockNumber, false).
+			expectedError("").
+			test()
+	})
 	t.Run("different-responses", func(t *testing.T) {
 		prepareHandlerTest(t, 2, "eth_getBlockByNumber", blockNumber, false).
 			setOptions(WithRequirements(2, 10)).
@@ -596,8 +633,7 @@ func Test_RPC_SendRawTransaction(t *testing.T) {
 			mockClientCall(0, txHash1, "eth_sendRawTransaction", txData).
 			mockClientCall(1, errors.New("error#1"), "eth_sendRawTransaction", txData).
 			mockClientCall(2, errors.New("error#2"), "eth_sendRawTransaction", txData).
-			expectedError("error#1").
-			expectedError("error#2").
+			expectedResult(txHash1).
 			test()
 	})
 	t.Run("all-failed", func(t *testing.T) {
@@ -611,13 +647,32 @@ func Test_RPC_SendRawTransaction(t *testing.T) {
 			expectedError("error#3").
 			test()
 	})
-	t.Run("different-responses", func(t *testing.T) {
+	t.Run("already-known", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_sendRawTransaction", txData).
+			setOptions(WithRequirements(2, 10)).
+			mockClientCall(0, errors.New("already known"), "eth_sendRawTransaction", txData).
+			mockClientCall(1, errors.New("nonce too low"), "eth_sendRawTransaction", txData).
+			expectedResult(types.BytesToHash(crypto.Keccak256(txData))).
+			test()
+	})
+	t.Run("rpc-error", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_sendRawTransaction", txData).
+			setOptions(WithRequirements(2, 10)).
+			mockClientCall(0, errors.New("error#1"), "eth_sendRawTransaction", txData).
+			mockClientCall(1, &mockRPCError{msg: "insufficient funds"}, "eth_sendRawTransaction", txData).
+			mockClientCall(2, &mockRPCError{msg: "insufficient funds"}, "eth_sendRawTransaction", txData).
+			expectedError("insufficient funds").
+			test()
+	})
+	t.Run("slow-endpoint", func(t *testing.T) {
+		start := time.Now()
 		prepareHandlerTest(t, 2, "eth_sendRawTransaction", txData).
 			setOptions(WithRequirements(2, 10)).
 			mockClientCall(0, txHash1, "eth_sendRawTransaction", txData).
-			mockClientCall(1, txHash2, "eth_sendRawTransaction", txData).
-			expectedError("").
+			mockClientSlowCall(time.Second, 1, txHash2, "eth_sendRawTransaction", txData).
+			expectedResult(txHash1).
 			test()
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
 	})
 }
 
@@ -1174,6 +1229,36 @@ func Test_RPC_EstimateGas(t *testing.T) {
 	})
 }
 
+func Test_RPC_GasPriceStrategy(t *testing.T) {
+	t.Run("gas-price", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_gasPrice").
+			setOptions(WithRequirements(2, 10), WithGasPriceStrategy(100)).
+			mockClientCall(0, `0x1`, "eth_gasPrice").
+			mockClientCall(1, `0x2`, "eth_gasPrice").
+			mockClientCall(2, `0x3`, "eth_gasPrice").
+			expectedResult(`0x3`).
+			test()
+	})
+	t.Run("max-priority-fee", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_maxPriorityFeePerGas").
+			setOptions(WithRequirements(2, 10), WithGasPriceStrategy(50)).
+			mockClientCall(0, `0x10`, "eth_maxPriorityFeePerGas").
+			mockClientCall(1, `0x20`, "eth_maxPriorityFeePerGas").
+			expectedResult(`0x18`).
+			test()
+	})
+	t.Run("estimate-gas", func(t *testing.T) {
+		call := newAny(`{"to": "0xd46e8dd67c5d32be8058bb8eb970870f07244567"}`)
+		blockNumber := types.StringToBlockNumber("0x10")
+		prepareHandlerTest(t, 2, "eth_estimateGas", call, blockNumber).
+			setOptions(WithRequirements(2, 10), WithGasPriceStrategy(100)).
+			mockClientCall(0, `0x10`, "eth_estimateGas", call, blockNumber).
+			mockClientCall(1, `0x20`, "eth_estimateGas", call, blockNumber).
+			expectedResult(`0x10`).
+			test()
+	})
+}
+
 func Test_RPC_FeeHistory(t *testing.T) {
 	blockCount := types.HexToNumber("0x5")
 	newestBlock := types.StringToBlockNumber("0x10")
@@ -1320,6 +1405,15 @@ func Test_RPC_ChainId(t *testing.T) {
 			expectedError("error#2").
 			test()
 	})
+	t.Run("different-encodings", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(3, 10)).
+			mockClientCall(0, `0x10`, "eth_chainId").
+			mockClientCall(1, `0x010`, "eth_chainId").
+			mockClientCall(2, json.RawMessage(`16`), "eth_chainId").
+			expectedResult(`0x10`).
+			test()
+	})
 	t.Run("different-responses", func(t *testing.T) {
 		prepareHandlerTest(t, 2, "eth_chainId").
 			setOptions(WithRequirements(2, 10)).
@@ -1370,6 +1464,7 @@ func Test_RPC_Version(t *testing.T) {
 }
 
 func Test_RPC_Timeout(t *testing.T) {
+	txHash := types.HexToHash("0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b")
 	t.Run("total-timeout", func(t *testing.T) {
 		prepareHandlerTest(t, 3, "eth_blockNumber").
 			setOptions(WithRequirements(2, 10), WithTotalTimeout(100*time.Millisecond), WithGracefulTimeout(100*time.Millisecond)).
@@ -1388,6 +1483,284 @@ func Test_RPC_Timeout(t *testing.T) {
 			expectedResult(`0x1`).
 			test()
 	})
+	t.Run("caller-timeout", func(t *testing.T) {
+		start := time.Now()
+		prepareHandlerTest(t, 3, "eth_blockNumber").
+			setOptions(WithRequirements(2, 10), WithGracefulTimeout(time.Second), WithCallerTimeout(50*time.Millisecond)).
+			mockClientCall(0, 1, "eth_blockNumber").
+			mockClientCall(1, 1, "eth_blockNumber").
+			mockClientSlowCall(time.Second, 2, 2, "eth_blockNumber").
+			expectedResult(`0x1`).
+			test()
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+	t.Run("quorum", func(t *testing.T) {
+		// The call returns as soon as the quorum is reached, without waiting
+		// for the slow endpoint or the graceful timeout.
+		start := time.Now()
+		prepareHandlerTest(t, 3, "eth_getTransactionByHash", txHash).
+			setOptions(WithRequirements(2, 10), WithGracefulTimeout(time.Second)).
+			mockClientCall(0, transaction1Resp, "eth_getTransactionByHash", txHash).
+			mockClientCall(1, transaction1Resp, "eth_getTransactionByHash", txHash).
+			mockClientSlowCall(time.Second, 2, transaction1Resp, "eth_getTransactionByHash", txHash).
+			expectedResult(transaction1Resp).
+			test()
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+	t.Run("no-quorum-yet", func(t *testing.T) {
+		// Different responses do not reach the quorum, so the call waits
+		// for the slow endpoint.
+		prepareHandlerTest(t, 3, "eth_getTransactionByHash", txHash).
+			setOptions(WithRequirements(2, 10), WithGracefulTimeout(time.Second)).
+			mockClientCall(0, transaction1Resp, "eth_getTransactionByHash", txHash).
+			mockClientCall(1, transaction2Resp, "eth_getTransactionByHash", txHash).
+			mockClientSlowCall(50*time.Millisecond, 2, transaction2Resp, "eth_getTransactionByHash", txHash).
+			expectedResult(transaction2Resp).
+			test()
+	})
+	t.Run("caller-timeout-no-quorum", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_blockNumber").
+			setOptions(WithRequirements(2, 10), WithCallerTimeout(50*time.Millisecond)).
+			mockClientCall(0, 1, "eth_blockNumber").
+			mockClientSlowCall(time.Second, 1, 1, "eth_blockNumber").
+			mockClientSlowCall(time.Second, 2, 1, "eth_blockNumber").
+			expectedError("context cancelled").
+			test()
+	})
+}
+
+func Test_RPC_MethodRequirements(t *testing.T) {
+	t.Run("stricter-requirement", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithMethodRequirements(map[string]int{"eth_chainId": 3})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x1`, "eth_chainId").
+			mockClientCall(2, `0x2`, "eth_chainId").
+			expectedError("").
+			test()
+	})
+	t.Run("looser-requirement", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_gasPrice").
+			setOptions(WithRequirements(2, 10), WithMethodRequirements(map[string]int{"eth_gasPrice": 1})).
+			mockClientCall(0, `0x3`, "eth_gasPrice").
+			mockClientCall(1, errors.New("error#1"), "eth_gasPrice").
+			mockClientCall(2, errors.New("error#2"), "eth_gasPrice").
+			expectedResult(`0x3`).
+			test()
+	})
+	t.Run("other-method", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithMethodRequirements(map[string]int{"eth_getBalance": 3})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x1`, "eth_chainId").
+			mockClientCall(2, `0x2`, "eth_chainId").
+			expectedResult(`0x1`).
+			test()
+	})
+}
+
+func Test_RPC_ResponseDebug(t *testing.T) {
+	hash := func(v string) string {
+		h := types.BytesToHash(crypto.Keccak256([]byte(v)))
+		return h.String()
+	}
+	t.Run("different-responses", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithResponseDebug(true)).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x2`, "eth_chainId").
+			mockClientCall(2, errors.New("error#1"), "eth_chainId").
+			expectedError("").
+			expectedErrorData([]responseSummary{
+				{Endpoint: "0", Result: hash(`"0x1"`)},
+				{Endpoint: "1", Result: hash(`"0x2"`)},
+				{Endpoint: "2", Error: "error#1"},
+			}).
+			test()
+	})
+	t.Run("disabled", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_chainId").
+			setOptions(WithRequirements(2, 10)).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x2`, "eth_chainId").
+			expectedError("").
+			expectedErrorData(nil).
+			test()
+	})
+}
+
+func Test_RPC_ResolutionLogging(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		fields []log.Fields
+	)
+	logger := callback.New(log.Debug, func(level log.Level, f log.Fields, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if msg == "Request resolved" || msg == "Request failed" {
+			fields = append(fields, f)
+		}
+		if _, ok := f["args"]; ok && f["method"] == "eth_sendRawTransaction" {
+			assert.Equal(t, "[redacted]", f["args"])
+		}
+	})
+	lastFields := func() log.Fields {
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, fields)
+		return fields[len(fields)-1]
+	}
+	t.Run("resolved", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithLogger(logger), WithResolutionLogging(true)).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x1`, "eth_chainId").
+			mockClientCall(2, `0x2`, "eth_chainId").
+			expectedResult(`0x1`).
+			test()
+		f := lastFields()
+		assert.Equal(t, "eth_chainId", f["method"])
+		assert.Equal(t, "most-common", f["strategy"])
+		assert.Equal(t, 3, f["callers"])
+		assert.Equal(t, 2, f["agreeing"])
+		assert.Contains(t, f, "duration")
+	})
+	t.Run("failed", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_gasPrice").
+			setOptions(WithRequirements(2, 10), WithLogger(logger), WithResolutionLogging(true)).
+			mockClientCall(0, `0x1`, "eth_gasPrice").
+			mockClientCall(1, errors.New("error#1"), "eth_gasPrice").
+			expectedError("error#1").
+			test()
+		f := lastFields()
+		assert.Equal(t, "median", f["strategy"])
+		assert.Equal(t, 0, f["agreeing"])
+		assert.Contains(t, f, "err")
+	})
+	t.Run("redacted", func(t *testing.T) {
+		txData := types.HexToBytes("0xd46e8dd67c5d32be8d46e8dd67c5d32be8058bb8eb970870f072445675058bb8eb970870f072445675")
+		txHash := types.HexToHash("0xe670ec64341771606e55d6b4ca35a1a6b75ee3d5145a99d05921026d15273310")
+		prepareHandlerTest(t, 1, "eth_sendRawTransaction", txData).
+			setOptions(
+				WithRequirements(1, 10),
+				WithLogger(logger),
+				WithResolutionLogging(true),
+				WithRedactedMethods([]string{"eth_sendRawTransaction"}),
+			).
+			mockClientCall(0, txHash, "eth_sendRawTransaction", txData).
+			expectedResult(txHash).
+			test()
+		f := lastFields()
+		assert.Equal(t, "broadcast", f["strategy"])
+		assert.Equal(t, "[redacted]", f["args"])
+	})
+}
+
+func Test_RPC_CallerWeights(t *testing.T) {
+	t.Run("heavier-caller-wins", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(3, 10), WithCallerWeights(map[string]int{"0": 3})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x2`, "eth_chainId").
+			mockClientCall(2, `0x2`, "eth_chainId").
+			expectedResult(`0x1`).
+			test()
+	})
+	t.Run("quorum-in-weight-units", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_chainId").
+			setOptions(WithRequirements(3, 10), WithCallerWeights(map[string]int{"0": 2})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x1`, "eth_chainId").
+			expectedResult(`0x1`).
+			test()
+	})
+	t.Run("quorum-not-reached", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_chainId").
+			setOptions(WithRequirements(3, 10), WithCallerWeights(map[string]int{"0": 2})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientCall(1, `0x2`, "eth_chainId").
+			expectedError("").
+			test()
+	})
+	t.Run("gas-price", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_gasPrice").
+			setOptions(WithRequirements(2, 10), WithCallerWeights(map[string]int{"1": 2})).
+			mockClientCall(0, `0x1`, "eth_gasPrice").
+			mockClientCall(1, `0x3`, "eth_gasPrice").
+			expectedResult(`0x3`).
+			test()
+	})
+}
+
+func TestNewServer_InvalidCallerWeights(t *testing.T) {
+	callers := map[string]caller{"0": &mockClient{t: t}, "1": &mockClient{t: t}}
+	_, err := NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithCallerWeights(map[string]int{"0": 0}),
+	)
+	assert.Error(t, err)
+	_, err = NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithCallerWeights(map[string]int{"2": 2}),
+	)
+	assert.Error(t, err)
+	_, err = NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithCallerWeights(map[string]int{"0": 2}),
+		WithMethodRequirements(map[string]int{"eth_getBalance": 3}),
+	)
+	assert.NoError(t, err)
+}
+
+func Test_RPC_RaceMethods(t *testing.T) {
+	t.Run("first-response", func(t *testing.T) {
+		start := time.Now()
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(3, 10), WithRaceMethods([]string{"eth_chainId"})).
+			mockClientCall(0, `0x1`, "eth_chainId").
+			mockClientSlowCall(time.Second, 1, `0x2`, "eth_chainId").
+			mockClientSlowCall(time.Second, 2, `0x2`, "eth_chainId").
+			expectedResult(`0x1`).
+			test()
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+	t.Run("skip-errors", func(t *testing.T) {
+		prepareHandlerTest(t, 3, "eth_chainId").
+			setOptions(WithRequirements(3, 10), WithRaceMethods([]string{"eth_chainId"})).
+			mockClientCall(0, errors.New("error#1"), "eth_chainId").
+			mockClientCall(1, errors.New("error#2"), "eth_chainId").
+			mockClientSlowCall(10*time.Millisecond, 2, `0x2`, "eth_chainId").
+			expectedResult(`0x2`).
+			test()
+	})
+	t.Run("all-failed", func(t *testing.T) {
+		prepareHandlerTest(t, 2, "eth_chainId").
+			setOptions(WithRequirements(2, 10), WithRaceMethods([]string{"eth_chainId"})).
+			mockClientCall(0, errors.New("error#1"), "eth_chainId").
+			mockClientCall(1, errors.New("error#2"), "eth_chainId").
+			expectedError("error#1").
+			expectedError("error#2").
+			test()
+	})
+}
+
+func TestNewServer_InvalidMethodRequirements(t *testing.T) {
+	callers := map[string]caller{"0": &mockClient{t: t}, "1": &mockClient{t: t}}
+	_, err := NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithMethodRequirements(map[string]int{"eth_getBalance": 3}),
+	)
+	assert.Error(t, err)
+	_, err = NewServer(
+		withCallers(callers),
+		WithRequirements(2, 10),
+		WithMethodRequirements(map[string]int{"eth_getBalance": 0}),
+	)
+	assert.Error(t, err)
 }
 
 func newAny(j string) *Any {
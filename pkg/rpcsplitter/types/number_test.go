@@ -34,6 +34,10 @@ func Test_NumberType_Unmarshal(t *testing.T) {
 		{arg: `"F"`, want: Uint64ToNumber(15)},
 		{arg: `"foo"`, wantErr: true},
 		{arg: `"0xZ"`, wantErr: true},
+		{arg: `"0x00f"`, want: Uint64ToNumber(15)},
+		{arg: `15`, want: Uint64ToNumber(15)},
+		{arg: `1.5`, wantErr: true},
+		{arg: `0xF`, wantErr: true},
 	}
 	for n, tt := range tests {
 		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
@@ -97,7 +97,18 @@ func numberMarshalText(input *big.Int) []byte {
 // numberUnmarshalJSON decodes the given JSON string where number is resented in
 // hexadecimal format. The hex string may be prefixed with "0x". Negative numbers
 // must start with minus sign.
+//
+// Quantities should always be encoded as hex strings, but some endpoints
+// return them as JSON numbers. Because of that, unquoted numbers are decoded
+// as decimal numbers, so that the same value encoded in both ways is decoded
+// to the same number.
 func numberUnmarshalJSON(input []byte, output *big.Int) error {
+	if len(input) > 0 && input[0] != '"' {
+		if _, ok := output.SetString(string(input), 10); !ok {
+			return fmt.Errorf("invalid number: %s", input)
+		}
+		return nil
+	}
 	return numberUnmarshalText(naiveUnquote(input), output)
 }
 
@@ -0,0 +1,65 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// LoadOrCreatePrivKey loads a peer private key from the given file. If the
+// file does not exist, a new Ed25519 key is generated and saved to the file,
+// so that the node keeps the same peer ID across restarts.
+//
+// The key is stored in the libp2p protobuf format.
+func LoadOrCreatePrivKey(path string) (crypto.PrivKey, error) {
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		key, err := crypto.UnmarshalPrivateKey(b)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse private key from %s: %w", path, err)
+		}
+		return key, nil
+	case errors.Is(err, fs.ErrNotExist):
+		key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate private key: %w", err)
+		}
+		b, err := crypto.MarshalPrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal private key: %w", err)
+		}
+		// O_EXCL prevents overwriting a key created concurrently by
+		// another process.
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to save private key to %s: %w", path, err)
+		}
+		defer f.Close()
+		if _, err := f.Write(b); err != nil {
+			return nil, fmt.Errorf("unable to save private key to %s: %w", path, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unable to read private key from %s: %w", path, err)
+	}
+}
@@ -0,0 +1,55 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOrCreatePrivKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peer.key")
+
+	// The key is generated and saved if the file does not exist:
+	key1, err := LoadOrCreatePrivKey(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// The same key is loaded on the next call:
+	key2, err := LoadOrCreatePrivKey(path)
+	require.NoError(t, err)
+	assert.True(t, key1.Equals(key2))
+
+	id1, err := peer.IDFromPrivateKey(key1)
+	require.NoError(t, err)
+	id2, err := peer.IDFromPrivateKey(key2)
+	require.NoError(t, err)
+	assert.Equal(t, id1, id2)
+}
+
+func TestLoadOrCreatePrivKey_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peer.key")
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	_, err := LoadOrCreatePrivKey(path)
+	assert.Error(t, err)
+}
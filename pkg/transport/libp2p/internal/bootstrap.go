@@ -0,0 +1,106 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	bootstrapCheckInterval = 10 * time.Second
+	bootstrapMinBackoff    = 10 * time.Second
+	bootstrapMaxBackoff    = 10 * time.Minute
+)
+
+// bootstrapReconnector periodically checks the connection to the bootstrap
+// peers and reconnects to them if they are disconnected. Subsequent failed
+// attempts are delayed using an exponential backoff.
+type bootstrapReconnector struct {
+	n          *Node
+	addrs      []peer.AddrInfo
+	interval   time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	backoff map[peer.ID]time.Duration // current backoff per peer
+	next    map[peer.ID]time.Time     // time of the next attempt per peer
+}
+
+func newBootstrapReconnector(n *Node, addrs []peer.AddrInfo) *bootstrapReconnector {
+	return &bootstrapReconnector{
+		n:          n,
+		addrs:      addrs,
+		interval:   bootstrapCheckInterval,
+		minBackoff: bootstrapMinBackoff,
+		maxBackoff: bootstrapMaxBackoff,
+		backoff:    make(map[peer.ID]time.Duration),
+		next:       make(map[peer.ID]time.Time),
+	}
+}
+
+// run checks the connections until the node context is canceled.
+func (b *bootstrapReconnector) run() {
+	t := time.NewTicker(b.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-b.n.ctx.Done():
+			return
+		case <-t.C:
+			b.check(time.Now())
+		}
+	}
+}
+
+func (b *bootstrapReconnector) check(now time.Time) {
+	for _, addr := range b.addrs {
+		if b.n.host.Network().Connectedness(addr.ID) == network.Connected {
+			delete(b.backoff, addr.ID)
+			delete(b.next, addr.ID)
+			continue
+		}
+		if now.Before(b.next[addr.ID]) {
+			continue
+		}
+		b.n.tsLog.get().
+			WithField("peerID", addr.ID.Pretty()).
+			WithField("addrs", addr.Addrs).
+			Info("Reconnecting to the bootstrap peer")
+		if err := b.n.host.Connect(b.n.ctx, addr); err != nil {
+			backoff := b.backoff[addr.ID] * 2
+			if backoff < b.minBackoff {
+				backoff = b.minBackoff
+			}
+			if backoff > b.maxBackoff {
+				backoff = b.maxBackoff
+			}
+			b.backoff[addr.ID] = backoff
+			b.next[addr.ID] = now.Add(backoff)
+			b.n.tsLog.get().
+				WithField("peerID", addr.ID.Pretty()).
+				WithField("addrs", addr.Addrs).
+				WithField("retryIn", backoff).
+				WithError(err).
+				Warn("Unable to reconnect to the bootstrap peer")
+			continue
+		}
+		delete(b.backoff, addr.ID)
+		delete(b.next, addr.ID)
+	}
+}
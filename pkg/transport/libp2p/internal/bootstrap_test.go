@@ -0,0 +1,78 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapReconnector(t *testing.T) {
+	peers, err := getNodeInfo(3)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	// The n2 node is never started, so it is not possible to connect to it.
+	bootstrap := []peer.AddrInfo{
+		{ID: peers[0].ID, Addrs: peers[0].ListenAddrs},
+		{ID: peers[2].ID, Addrs: peers[2].ListenAddrs},
+	}
+	r := newBootstrapReconnector(n1, bootstrap)
+	r.minBackoff = time.Second
+	r.maxBackoff = 3 * time.Second
+
+	// Initial connection:
+	now := time.Now()
+	r.check(now)
+	assert.Equal(t, network.Connected, n1.Host().Network().Connectedness(peers[0].ID))
+	assert.Equal(t, time.Second, r.backoff[peers[2].ID])
+
+	// Reconnect after disconnection:
+	require.NoError(t, n1.Host().Network().ClosePeer(peers[0].ID))
+	require.NotEqual(t, network.Connected, n1.Host().Network().Connectedness(peers[0].ID))
+	r.check(now)
+	assert.Equal(t, network.Connected, n1.Host().Network().Connectedness(peers[0].ID))
+
+	// Failed attempts must be delayed using the exponential backoff:
+	r.check(now.Add(500 * time.Millisecond))
+	assert.Equal(t, time.Second, r.backoff[peers[2].ID])
+	r.check(now.Add(time.Second))
+	assert.Equal(t, 2*time.Second, r.backoff[peers[2].ID])
+	r.check(now.Add(3 * time.Second))
+	assert.Equal(t, 3*time.Second, r.backoff[peers[2].ID])
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -28,6 +29,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	"github.com/multiformats/go-multiaddr"
@@ -37,6 +39,10 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 )
 
+// defaultDrainTimeout is the grace period the node waits for queued messages
+// to be sent before the host is closed.
+const defaultDrainTimeout = time.Second
+
 var ErrConnectionClosed = errors.New("connection is closed")
 var ErrAlreadySubscribed = errors.New("topic is already subscribed")
 var ErrNotSubscribed = errors.New("topic is not subscribed")
@@ -46,14 +52,22 @@ var ErrPubSubDisabled = errors.New("pubsub protocol is disabled")
 // provide an easier to use and use-case agnostic interface for the pubsub
 // system.
 type Node struct {
-	ctx    context.Context
-	mu     sync.Mutex
-	waitCh chan error
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+	mu        sync.Mutex
+	waitCh    chan error
+	closedCh  chan struct{}
+	closeErr  error
+
+	pubSubCtxCancel context.CancelFunc
+	drainTimeout    time.Duration
+	drainCtx        context.Context // context passed to Close, cuts the drain short
 
 	host                  host.Host
 	pubSub                *pubsub.PubSub
 	peerstore             peerstore.Peerstore
 	connmgr               coreConnmgr.ConnManager
+	routingDiscovery      *routing.RoutingDiscovery
 	nodeEventHandler      *sets.NodeEventHandlerSet
 	pubSubEventHandlerSet *sets.PubSubEventHandlerSet
 	notifeeSet            *sets.NotifeeSet
@@ -76,6 +90,8 @@ func NewNode(opts ...Options) (*Node, error) {
 	}
 	n := &Node{
 		waitCh:                make(chan error),
+		closedCh:              make(chan struct{}),
+		drainTimeout:          defaultDrainTimeout,
 		peerstore:             ps,
 		nodeEventHandler:      sets.NewNodeEventHandlerSet(),
 		pubSubEventHandlerSet: sets.NewPubSubEventHandlerSet(),
@@ -116,7 +132,7 @@ func (n *Node) Start(ctx context.Context) error {
 		return errors.New("context must not be nil")
 	}
 	n.tsLog.get().Info("Starting")
-	n.ctx = ctx
+	n.ctx, n.ctxCancel = context.WithCancel(ctx)
 
 	n.nodeEventHandler.Handle(sets.NodeStartingEvent{})
 
@@ -143,7 +159,11 @@ func (n *Node) Start(ctx context.Context) error {
 		Info("Listening")
 
 	if !n.disablePubSub {
-		n.pubSub, err = pubsub.NewGossipSub(n.ctx, n.host, n.pubsubOpts...)
+		// PubSub uses a separate context, so it can send queued messages
+		// while the node is stopping.
+		var pubSubCtx context.Context
+		pubSubCtx, n.pubSubCtxCancel = context.WithCancel(context.Background())
+		n.pubSub, err = pubsub.NewGossipSub(pubSubCtx, n.host, n.pubsubOpts...)
 		if err != nil {
 			return fmt.Errorf("libp2p node error, unable to initialize gosspib pubsub: %w", err)
 		}
@@ -160,6 +180,30 @@ func (n *Node) Wait() <-chan error {
 	return n.waitCh
 }
 
+// Close stops the node. It has the same effect as canceling the context
+// passed to the Start method. Before the host is closed, the node waits for
+// a grace period to send messages that are still queued, see DrainTimeout.
+// If the given context is canceled during the grace period, the host is
+// closed immediately. Close returns after the host is closed or when the
+// given context is canceled.
+func (n *Node) Close(ctx context.Context) error {
+	if n.ctx == nil {
+		return errors.New("service is not started")
+	}
+	n.mu.Lock()
+	if n.drainCtx == nil {
+		n.drainCtx = ctx
+	}
+	n.mu.Unlock()
+	n.ctxCancel()
+	select {
+	case <-n.closedCh:
+		return n.closeErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (n *Node) Addrs() []multiaddr.Multiaddr {
 	var addrs []multiaddr.Multiaddr
 	for _, s := range n.listenAddrStrs() {
@@ -309,15 +353,48 @@ func (n *Node) contextCancelHandler() {
 	<-n.ctx.Done()
 
 	n.nodeEventHandler.Handle(sets.NodeStoppingEvent{})
+	n.mu.Lock()
+	drainCtx := n.drainCtx
+	n.mu.Unlock()
+	if drainCtx == nil {
+		drainCtx = context.Background()
+	}
+	n.drain(drainCtx)
 
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
 	n.subs = nil
 	n.closed = true
-	err := n.host.Close()
-	if err != nil {
-		n.waitCh <- err
+	if n.pubSubCtxCancel != nil {
+		n.pubSubCtxCancel()
+	}
+	if n.host != nil {
+		n.closeErr = n.host.Close()
+	}
+	close(n.closedCh)
+	if n.closeErr != nil {
+		n.waitCh <- n.closeErr
+	}
+}
+
+// drain waits for a fixed grace period to give the pubsub time to send
+// messages that are still queued. The pubsub does not report when its
+// outgoing queues are empty, so there is no guarantee that all messages are
+// sent within that period. It returns immediately if there are no connected
+// peers and early if the given context is canceled.
+func (n *Node) drain(ctx context.Context) {
+	if n.pubSub == nil || n.host == nil || n.drainTimeout <= 0 {
+		return
+	}
+	if len(n.host.Network().Peers()) == 0 {
+		return
+	}
+	t := time.NewTimer(n.drainTimeout)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
 	}
 }
 
@@ -26,6 +26,7 @@ import (
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
@@ -237,3 +238,103 @@ func containsPeerID(ids []peer.ID, id peer.ID) bool {
 	}
 	return false
 }
+
+func TestNode_Close(t *testing.T) {
+	// This test checks if the node is closed gracefully and if queued
+	// messages are sent before the host is closed.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		DirectPeers(peers[1].PeerAddrs),
+	)
+	require.NoError(t, err)
+	assert.Error(t, n0.Close(ctx))
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		DirectPeers(peers[0].PeerAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	_, err = n0.Subscribe("test")
+	require.NoError(t, err)
+	_, err = n1.Subscribe("test")
+	require.NoError(t, err)
+
+	waitFor(t, func() bool {
+		return len(n0.PubSub().ListPeers("test")) > 0 &&
+			len(n1.PubSub().ListPeers("test")) > 0
+	})
+
+	s0, err := n0.Subscription("test")
+	require.NoError(t, err)
+	s1, err := n1.Subscription("test")
+	require.NoError(t, err)
+
+	// The message published right before closing the node must be delivered:
+	require.NoError(t, s0.Publish([]byte("makerdao")))
+	require.NoError(t, n0.Close(ctx))
+	waitForMessage(t, s1.Next(), []byte("makerdao"))
+
+	// The node must be disconnected from the peer and stopped:
+	waitFor(t, func() bool {
+		return n1.Host().Network().Connectedness(peers[0].ID) != network.Connected
+	})
+	_, ok := <-n0.Wait()
+	assert.False(t, ok)
+}
+
+func TestNode_CloseContext(t *testing.T) {
+	// This test checks if the grace period for queued messages is cut short
+	// when the context passed to Close is canceled.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		DirectPeers(peers[1].PeerAddrs),
+		DrainTimeout(time.Minute),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		DirectPeers(peers[0].PeerAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	waitFor(t, func() bool {
+		return len(n0.Host().Network().Peers()) > 0
+	})
+
+	closeCtx, closeCtxCancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer closeCtxCancel()
+	assert.ErrorIs(t, n0.Close(closeCtx), context.DeadlineExceeded)
+
+	// The host must be closed right after the context is canceled, without
+	// waiting for the whole grace period.
+	select {
+	case _, ok := <-n0.Wait():
+		assert.False(t, ok)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "node was not stopped")
+	}
+}
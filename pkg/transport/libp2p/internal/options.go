@@ -16,6 +16,8 @@
 package internal
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
@@ -27,6 +29,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	"github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	libp2pwebtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 	"github.com/multiformats/go-multiaddr"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
@@ -42,6 +50,16 @@ func DialTimeout(t time.Duration) Options {
 	}
 }
 
+// DrainTimeout sets the grace period the node waits for queued messages to
+// be sent before the host is closed. It is a fixed period, because the
+// pubsub does not report when all messages are sent. Zero disables waiting.
+func DrainTimeout(t time.Duration) Options {
+	return func(n *Node) error {
+		n.drainTimeout = t
+		return nil
+	}
+}
+
 // ListenAddrs configures node to listen on the given addresses.
 func ListenAddrs(addrs []multiaddr.Multiaddr) Options {
 	return func(n *Node) error {
@@ -50,6 +68,48 @@ func ListenAddrs(addrs []multiaddr.Multiaddr) Options {
 	}
 }
 
+// Transports configures node to use only the given transports. Supported
+// transports are "tcp", "quic", "websocket" and "webtransport". If the list
+// is empty, the default libp2p transports are used.
+func Transports(names []string) Options {
+	return func(n *Node) error {
+		for _, name := range names {
+			switch strings.ToLower(name) {
+			case "tcp":
+				n.hostOpts = append(n.hostOpts, libp2p.Transport(tcp.NewTCPTransport))
+			case "quic":
+				n.hostOpts = append(n.hostOpts, libp2p.Transport(libp2pquic.NewTransport))
+			case "websocket":
+				n.hostOpts = append(n.hostOpts, libp2p.Transport(websocket.New))
+			case "webtransport":
+				n.hostOpts = append(n.hostOpts, libp2p.Transport(libp2pwebtransport.New))
+			default:
+				return fmt.Errorf("unknown transport: %s", name)
+			}
+		}
+		return nil
+	}
+}
+
+// Security configures node to use only the given security protocols, in
+// order of preference. Supported protocols are "noise" and "tls". If the
+// list is empty, the default libp2p security protocols are used.
+func Security(names []string) Options {
+	return func(n *Node) error {
+		for _, name := range names {
+			switch strings.ToLower(name) {
+			case "noise":
+				n.hostOpts = append(n.hostOpts, libp2p.Security(noise.ID, noise.New))
+			case "tls":
+				n.hostOpts = append(n.hostOpts, libp2p.Security(libp2ptls.ID, libp2ptls.New))
+			default:
+				return fmt.Errorf("unknown security protocol: %s", name)
+			}
+		}
+		return nil
+	}
+}
+
 // PeerPrivKey configures node to use given key as its identity.
 func PeerPrivKey(sk crypto.PrivKey) Options {
 	return func(n *Node) error {
@@ -212,7 +272,11 @@ func Discovery(bootstrapAddrs []multiaddr.Multiaddr) Options {
 						Error("Unable to bootstrap KAD-DHT")
 					return
 				}
-				n.pubsubOpts = append(n.pubsubOpts, pubsub.WithDiscovery(routing.NewRoutingDiscovery(kadDHT)))
+				n.routingDiscovery = routing.NewRoutingDiscovery(kadDHT)
+				n.pubsubOpts = append(n.pubsubOpts, pubsub.WithDiscovery(n.routingDiscovery))
+				if len(addrs) > 0 {
+					go newBootstrapReconnector(n, addrs).run()
+				}
 			case sets.NodeStoppingEvent:
 				if kadDHT == nil {
 					return
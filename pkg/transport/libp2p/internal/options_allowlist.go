@@ -0,0 +1,120 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Allowlist allows connections only with the given peers. If the list is
+// empty, all peers are allowed.
+//
+// An address may contain an IP address, a peer ID, or both. An IP address
+// may be followed by the ipcidr component to allow the whole network, e.g.
+// /ip4/10.0.0.0/ipcidr/8. A connection is allowed if either the peer ID is
+// on the list or the remote IP address is within one of the allowed
+// networks. Other connections are rejected both when dialing and accepting.
+//
+// Note that bootstrap and direct peers must be on the list as well.
+func Allowlist(addrs []multiaddr.Multiaddr) Options {
+	return func(n *Node) error {
+		if len(addrs) == 0 {
+			return nil
+		}
+		nets, pids, err := parseAddrFilters(addrs)
+		if err != nil {
+			return err
+		}
+		cg := &allowlistConnGater{
+			n:       n,
+			filters: multiaddr.Filters{DefaultAction: multiaddr.ActionDeny},
+			pids:    make(map[peer.ID]struct{}, len(pids)),
+			hasNets: len(nets) > 0,
+		}
+		for _, ipnet := range nets {
+			cg.filters.AddFilter(ipnet, multiaddr.ActionAccept)
+		}
+		for _, pid := range pids {
+			cg.pids[pid] = struct{}{}
+		}
+		n.AddConnectionGater(cg)
+		return nil
+	}
+}
+
+type allowlistConnGater struct {
+	n       *Node
+	filters multiaddr.Filters
+	pids    map[peer.ID]struct{}
+	hasNets bool
+}
+
+// InterceptAddrDial implements the connmgr.ConnectionGater interface.
+func (f *allowlistConnGater) InterceptAddrDial(pid peer.ID, addr multiaddr.Multiaddr) bool {
+	if f.pidAllowed(pid) || f.addrAllowed(addr) {
+		return true
+	}
+	logConnBlocked(f.n, "allowlist", pid, addr)
+	return false
+}
+
+// InterceptPeerDial implements the connmgr.ConnectionGater interface.
+func (f *allowlistConnGater) InterceptPeerDial(pid peer.ID) bool {
+	// If there are allowed networks, the decision is deferred until the
+	// address is known.
+	if f.pidAllowed(pid) || f.hasNets {
+		return true
+	}
+	logConnBlocked(f.n, "allowlist", pid, nil)
+	return false
+}
+
+// InterceptAccept implements the connmgr.ConnectionGater interface.
+func (f *allowlistConnGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	// If there are allowed peer IDs, the decision is deferred until the
+	// peer ID is known.
+	if len(f.pids) > 0 || f.addrAllowed(addrs.RemoteMultiaddr()) {
+		return true
+	}
+	logConnBlocked(f.n, "allowlist", "", addrs.RemoteMultiaddr())
+	return false
+}
+
+// InterceptSecured implements the connmgr.ConnectionGater interface.
+func (f *allowlistConnGater) InterceptSecured(_ network.Direction, pid peer.ID, addrs network.ConnMultiaddrs) bool {
+	if f.pidAllowed(pid) || f.addrAllowed(addrs.RemoteMultiaddr()) {
+		return true
+	}
+	logConnBlocked(f.n, "allowlist", pid, addrs.RemoteMultiaddr())
+	return false
+}
+
+// InterceptUpgraded implements the connmgr.ConnectionGater interface.
+func (f *allowlistConnGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+func (f *allowlistConnGater) pidAllowed(pid peer.ID) bool {
+	_, ok := f.pids[pid]
+	return ok
+}
+
+func (f *allowlistConnGater) addrAllowed(addr multiaddr.Multiaddr) bool {
+	return f.hasNets && !f.filters.AddrBlocked(addr)
+}
@@ -17,6 +17,7 @@ package internal
 
 import (
 	"net"
+	"strconv"
 
 	"github.com/libp2p/go-libp2p/core/control"
 	"github.com/libp2p/go-libp2p/core/network"
@@ -27,29 +28,28 @@ import (
 )
 
 // Denylist allows to block peer by their IP addresses or IDs.
+//
+// An address may contain an IP address, a peer ID, or both, in which case
+// both are blocked separately. An IP address may be followed by the ipcidr
+// component to block the whole network, e.g. /ip4/10.0.0.0/ipcidr/8.
+// Blocked peers are rejected both when dialing and accepting connections.
 func Denylist(addrs []multiaddr.Multiaddr) Options {
 	return func(n *Node) error {
 		if len(addrs) == 0 {
 			return nil
 		}
-
+		nets, pids, err := parseAddrFilters(addrs)
+		if err != nil {
+			return err
+		}
 		cg := &denylistConnGater{n: n}
-		n.AddConnectionGater(cg)
-		for _, maddr := range addrs {
-			multiaddr.ForEach(maddr, func(c multiaddr.Component) bool {
-				switch c.Protocol().Code {
-				case multiaddr.P_IP4, multiaddr.P_IP6:
-					cg.BlockIP(net.ParseIP(c.String()))
-				case multiaddr.P_P2P:
-					pid, err := peer.IDFromBytes(c.RawValue())
-					if err != nil {
-						return true
-					}
-					cg.BlockPID(pid)
-				}
-				return true
-			})
+		for _, ipnet := range nets {
+			cg.BlockIPNet(ipnet)
 		}
+		for _, pid := range pids {
+			cg.BlockPID(pid)
+		}
+		n.AddConnectionGater(cg)
 		return nil
 	}
 }
@@ -67,43 +67,50 @@ func (f *denylistConnGater) BlockPID(pid peer.ID) {
 
 // BlockIP blocks connections from given IP address.
 func (f *denylistConnGater) BlockIP(ip net.IP) {
-	f.filters.AddFilter(net.IPNet{
+	f.BlockIPNet(net.IPNet{
 		IP:   ip,
 		Mask: net.CIDRMask(len(ip)*8, len(ip)*8),
-	}, multiaddr.ActionDeny)
+	})
+}
+
+// BlockIPNet blocks connections from given IP network.
+func (f *denylistConnGater) BlockIPNet(ipnet net.IPNet) {
+	f.filters.AddFilter(ipnet, multiaddr.ActionDeny)
 }
 
 // InterceptAddrDial implements the connmgr.ConnectionGater interface.
 func (f *denylistConnGater) InterceptAddrDial(pid peer.ID, addr multiaddr.Multiaddr) bool {
-	if f.filters.AddrBlocked(addr) {
+	if f.filters.AddrBlocked(addr) || f.pidBlocked(pid) {
+		f.logBlocked(pid, addr)
 		return false
 	}
-	for _, p := range f.pids {
-		if p == pid {
-			return false
-		}
-	}
-	f.n.tsLog.get().
-		WithFields(log.Fields{
-			"peerID": pid.String(),
-			"addr":   addr.String(),
-		}).
-		Info("Blocked connection")
 	return true
 }
 
 // InterceptPeerDial implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptPeerDial(peer.ID) bool {
+func (f *denylistConnGater) InterceptPeerDial(pid peer.ID) bool {
+	if f.pidBlocked(pid) {
+		f.logBlocked(pid, nil)
+		return false
+	}
 	return true
 }
 
 // InterceptAccept implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptAccept(network.ConnMultiaddrs) bool {
+func (f *denylistConnGater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	if f.filters.AddrBlocked(addrs.RemoteMultiaddr()) {
+		f.logBlocked("", addrs.RemoteMultiaddr())
+		return false
+	}
 	return true
 }
 
 // InterceptSecured implements the connmgr.ConnectionGater interface.
-func (f *denylistConnGater) InterceptSecured(network.Direction, peer.ID, network.ConnMultiaddrs) bool {
+func (f *denylistConnGater) InterceptSecured(_ network.Direction, pid peer.ID, addrs network.ConnMultiaddrs) bool {
+	if f.pidBlocked(pid) {
+		f.logBlocked(pid, addrs.RemoteMultiaddr())
+		return false
+	}
 	return true
 }
 
@@ -111,3 +118,69 @@ func (f *denylistConnGater) InterceptSecured(network.Direction, peer.ID, network
 func (f *denylistConnGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
 	return true, 0
 }
+
+func (f *denylistConnGater) pidBlocked(pid peer.ID) bool {
+	for _, p := range f.pids {
+		if p == pid {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *denylistConnGater) logBlocked(pid peer.ID, addr multiaddr.Multiaddr) {
+	logConnBlocked(f.n, "denylist", pid, addr)
+}
+
+// logConnBlocked logs a connection rejected by a connection gater. The pid
+// and addr may be empty if they are not known at the time of rejection.
+func logConnBlocked(n *Node, gater string, pid peer.ID, addr multiaddr.Multiaddr) {
+	fields := log.Fields{"gater": gater}
+	if pid != "" {
+		fields["peerID"] = pid.String()
+	}
+	if addr != nil {
+		fields["addr"] = addr.String()
+	}
+	n.tsLog.get().WithFields(fields).Info("Blocked connection")
+}
+
+// parseAddrFilters extracts IP networks and peer IDs from the given
+// multiaddresses. IP addresses without the ipcidr component are converted
+// to single-address networks.
+func parseAddrFilters(addrs []multiaddr.Multiaddr) (nets []net.IPNet, pids []peer.ID, err error) {
+	for _, maddr := range addrs {
+		var ipnet *net.IPNet
+		multiaddr.ForEach(maddr, func(c multiaddr.Component) bool {
+			switch c.Protocol().Code {
+			case multiaddr.P_IP4, multiaddr.P_IP6:
+				ip := net.IP(c.RawValue())
+				ipnet = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+				nets = append(nets, *ipnet)
+			case multiaddr.P_IPCIDR:
+				if ipnet == nil {
+					return true
+				}
+				var ones int
+				ones, err = strconv.Atoi(c.Value())
+				if err != nil {
+					return false
+				}
+				nets[len(nets)-1].Mask = net.CIDRMask(ones, len(ipnet.IP)*8)
+				nets[len(nets)-1].IP = ipnet.IP.Mask(nets[len(nets)-1].Mask)
+			case multiaddr.P_P2P:
+				var pid peer.ID
+				pid, err = peer.IDFromBytes(c.RawValue())
+				if err != nil {
+					return false
+				}
+				pids = append(pids, pid)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return nets, pids, nil
+}
@@ -0,0 +1,153 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startGaterTestNodes starts nodes for the connection gater tests. The
+// options are applied to the first node only.
+func startGaterTestNodes(t *testing.T, ctx context.Context, count int, opts ...Options) ([]*Node, []nodeInfo) { //nolint:revive
+	peers, err := getNodeInfo(count)
+	require.NoError(t, err)
+	var nodes []*Node
+	for i, p := range peers {
+		nodeOpts := []Options{PeerPrivKey(p.PrivKey), ListenAddrs(p.ListenAddrs)}
+		if i == 0 {
+			nodeOpts = append(nodeOpts, opts...)
+		}
+		n, err := NewNode(nodeOpts...)
+		require.NoError(t, err)
+		require.NoError(t, n.Start(ctx))
+		nodes = append(nodes, n)
+	}
+	return nodes, peers
+}
+
+// canConnect checks if the node a can connect to the node b and if the
+// connection is maintained by both sides. The dialing side may consider the
+// connection established before it is rejected by the other side.
+func canConnect(ctx context.Context, a, b *Node) bool {
+	a.Host().Network().ClosePeer(b.Host().ID()) //nolint:errcheck
+	err := a.Host().Connect(ctx, peer.AddrInfo{ID: b.Host().ID(), Addrs: b.Host().Addrs()})
+	if err != nil {
+		return false
+	}
+	time.Sleep(100 * time.Millisecond)
+	return a.Host().Network().Connectedness(b.Host().ID()) == network.Connected &&
+		b.Host().Network().Connectedness(a.Host().ID()) == network.Connected
+}
+
+func TestNode_Denylist(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	peers, err := getNodeInfo(1)
+	require.NoError(t, err)
+	nodes, _ := startGaterTestNodes(t, ctx, 3,
+		Denylist([]multiaddr.Multiaddr{multiaddr.StringCast("/p2p/" + peers[0].ID.String())}),
+	)
+	blocked, err := NewNode(PeerPrivKey(peers[0].PrivKey), ListenAddrs(peers[0].ListenAddrs))
+	require.NoError(t, err)
+	require.NoError(t, blocked.Start(ctx))
+
+	// Blocked peer must be rejected on both dial and accept:
+	assert.False(t, canConnect(ctx, blocked, nodes[0]))
+	assert.False(t, canConnect(ctx, nodes[0], blocked))
+
+	// Other peers must be allowed:
+	assert.True(t, canConnect(ctx, nodes[1], nodes[0]))
+	assert.True(t, canConnect(ctx, nodes[0], nodes[2]))
+}
+
+func TestNode_DenylistCIDR(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	nodes, _ := startGaterTestNodes(t, ctx, 2,
+		Denylist([]multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.0/ipcidr/8")}),
+	)
+	assert.False(t, canConnect(ctx, nodes[1], nodes[0]))
+	assert.False(t, canConnect(ctx, nodes[0], nodes[1]))
+}
+
+func TestNode_Allowlist(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	peers, err := getNodeInfo(1)
+	require.NoError(t, err)
+	nodes, _ := startGaterTestNodes(t, ctx, 3,
+		Allowlist([]multiaddr.Multiaddr{multiaddr.StringCast("/p2p/" + peers[0].ID.String())}),
+	)
+	allowed, err := NewNode(PeerPrivKey(peers[0].PrivKey), ListenAddrs(peers[0].ListenAddrs))
+	require.NoError(t, err)
+	require.NoError(t, allowed.Start(ctx))
+
+	// Allowed peer must be accepted on both dial and accept:
+	assert.True(t, canConnect(ctx, allowed, nodes[0]))
+	assert.True(t, canConnect(ctx, nodes[0], allowed))
+
+	// Other peers must be rejected:
+	assert.False(t, canConnect(ctx, nodes[1], nodes[0]))
+	assert.False(t, canConnect(ctx, nodes[0], nodes[2]))
+}
+
+func TestNode_AllowlistCIDR(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	// Allowed network:
+	nodes, _ := startGaterTestNodes(t, ctx, 2,
+		Allowlist([]multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.0/ipcidr/8")}),
+	)
+	assert.True(t, canConnect(ctx, nodes[1], nodes[0]))
+	assert.True(t, canConnect(ctx, nodes[0], nodes[1]))
+
+	// Other network:
+	nodes, _ = startGaterTestNodes(t, ctx, 2,
+		Allowlist([]multiaddr.Multiaddr{multiaddr.StringCast("/ip4/10.0.0.0/ipcidr/8")}),
+	)
+	assert.False(t, canConnect(ctx, nodes[1], nodes[0]))
+	assert.False(t, canConnect(ctx, nodes[0], nodes[1]))
+}
+
+func TestParseAddrFilters(t *testing.T) {
+	peers, err := getNodeInfo(1)
+	require.NoError(t, err)
+	nets, pids, err := parseAddrFilters([]multiaddr.Multiaddr{
+		multiaddr.StringCast("/ip4/1.2.3.4/p2p/" + peers[0].ID.String()),
+		multiaddr.StringCast("/ip4/10.1.2.3/ipcidr/8"),
+		multiaddr.StringCast("/ip6/2001:db8::/ipcidr/32"),
+	})
+	require.NoError(t, err)
+	require.Len(t, nets, 3)
+	assert.Equal(t, "1.2.3.4/32", nets[0].String())
+	assert.Equal(t, "10.0.0.0/8", nets[1].String())
+	assert.Equal(t, "2001:db8::/32", nets[2].String())
+	assert.True(t, nets[1].Contains(net.ParseIP("10.200.0.1")))
+	assert.Equal(t, []peer.ID{peers[0].ID}, pids)
+}
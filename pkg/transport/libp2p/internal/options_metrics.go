@@ -0,0 +1,87 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// ConnectionEvent describes a connection or a disconnection of a peer.
+type ConnectionEvent struct {
+	PeerID    peer.ID
+	Addr      multiaddr.Multiaddr
+	Direction network.Direction
+	Connected bool // True if a connection was opened, false if closed.
+}
+
+// BandwidthReporter configures the node to report bandwidth usage to the
+// given reporter.
+func BandwidthReporter(rep metrics.Reporter) Options {
+	return func(n *Node) error {
+		n.hostOpts = append(n.hostOpts, libp2p.BandwidthReporter(rep))
+		return nil
+	}
+}
+
+// ConnectionEvents registers a function that is invoked every time a
+// connection to a peer is opened or closed. The function is invoked
+// synchronously by libp2p, so it must not block.
+func ConnectionEvents(fn func(ConnectionEvent)) Options {
+	return func(n *Node) error {
+		n.AddNotifee(&connectionEventsNotifee{fn: fn})
+		return nil
+	}
+}
+
+type connectionEventsNotifee struct {
+	fn func(ConnectionEvent)
+}
+
+// Listen implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) Listen(network.Network, multiaddr.Multiaddr) {}
+
+// ListenClose implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) ListenClose(network.Network, multiaddr.Multiaddr) {}
+
+// Connected implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) Connected(_ network.Network, conn network.Conn) {
+	n.fn(ConnectionEvent{
+		PeerID:    conn.RemotePeer(),
+		Addr:      conn.RemoteMultiaddr(),
+		Direction: conn.Stat().Direction,
+		Connected: true,
+	})
+}
+
+// Disconnected implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) Disconnected(_ network.Network, conn network.Conn) {
+	n.fn(ConnectionEvent{
+		PeerID:    conn.RemotePeer(),
+		Addr:      conn.RemoteMultiaddr(),
+		Direction: conn.Stat().Direction,
+		Connected: false,
+	})
+}
+
+// OpenedStream implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) OpenedStream(network.Network, network.Stream) {}
+
+// ClosedStream implements the network.Notifiee interface.
+func (n *connectionEventsNotifee) ClosedStream(network.Network, network.Stream) {}
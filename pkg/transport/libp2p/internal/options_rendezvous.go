@@ -0,0 +1,109 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
+)
+
+// rendezvousInterval is the interval between searches for peers advertising
+// the rendezvous string.
+const rendezvousInterval = time.Minute
+
+// Rendezvous configures node to advertise itself under the given rendezvous
+// string and to periodically search for other peers advertising the same
+// string. It requires the Discovery option, which must be applied first.
+func Rendezvous(ns string) Options {
+	return func(n *Node) error {
+		advertise := func() time.Duration {
+			ttl, err := n.routingDiscovery.Advertise(n.ctx, ns)
+			if err != nil {
+				n.tsLog.get().
+					WithField("rendezvous", ns).
+					WithError(err).
+					Warn("Unable to advertise the rendezvous string")
+				return rendezvousInterval
+			}
+			// Re-advertise before the advertisement expires.
+			return ttl * 7 / 8
+		}
+		find := func() {
+			peers, err := n.routingDiscovery.FindPeers(n.ctx, ns)
+			if err != nil {
+				n.tsLog.get().
+					WithField("rendezvous", ns).
+					WithError(err).
+					Warn("Unable to find peers advertising the rendezvous string")
+				return
+			}
+			for addrInfo := range peers {
+				if addrInfo.ID == n.host.ID() || len(addrInfo.Addrs) == 0 {
+					continue
+				}
+				if n.host.Network().Connectedness(addrInfo.ID) == network.Connected {
+					continue
+				}
+				n.tsLog.get().
+					WithField("peerID", addrInfo.ID.Pretty()).
+					WithField("addrs", addrInfo.Addrs).
+					Info("Connecting to the peer found using the rendezvous string")
+				if err := n.host.Connect(n.ctx, addrInfo); err != nil {
+					n.tsLog.get().
+						WithField("peerID", addrInfo.ID.Pretty()).
+						WithField("addrs", addrInfo.Addrs).
+						WithError(err).
+						Warn("Unable to connect to the peer found using the rendezvous string")
+				}
+			}
+		}
+		rendezvousRoutine := func() {
+			advertiseTimer := time.NewTimer(advertise())
+			findTicker := time.NewTicker(rendezvousInterval)
+			defer advertiseTimer.Stop()
+			defer findTicker.Stop()
+			find()
+			for {
+				select {
+				case <-n.ctx.Done():
+					return
+				case <-advertiseTimer.C:
+					advertiseTimer.Reset(advertise())
+				case <-findTicker.C:
+					find()
+				}
+			}
+		}
+		n.AddNodeEventHandler(sets.NodeEventHandlerFunc(func(event interface{}) {
+			if _, ok := event.(sets.NodeStartedEvent); ok {
+				if n.routingDiscovery == nil {
+					n.tsLog.get().
+						WithField("rendezvous", ns).
+						Warn("Rendezvous discovery requires KAD-DHT discovery to be enabled")
+					return
+				}
+				n.tsLog.get().
+					WithField("rendezvous", ns).
+					Info("Starting rendezvous discovery")
+				go rendezvousRoutine()
+			}
+		}))
+		return nil
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -305,3 +307,212 @@ func TestNode_DirectPeers(t *testing.T) {
 	time.Sleep(time.Second)
 	assert.Equal(t, network.Connected, n0.Host().Network().Connectedness(n1.Host().ID()))
 }
+
+func TestNode_MDNS(t *testing.T) {
+	// This test checks if nodes in the same network discover each other
+	// using mDNS without any bootstrap or direct peers.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		MDNS(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		MDNS(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	waitFor(t, func() bool {
+		return n0.Host().Network().Connectedness(peers[1].ID) == network.Connected
+	})
+}
+
+func TestNode_Rendezvous(t *testing.T) {
+	// This test checks if nodes advertising the same rendezvous string find
+	// each other using the KAD-DHT.
+	//
+	// Topology:
+	//   n1 --[bootstrap]--> n0 <--[bootstrap]-- n2
+
+	peers, err := getNodeInfo(3)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		Discovery(nil),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		Discovery(peers[0].PeerAddrs),
+		Rendezvous("test"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+	time.Sleep(time.Second)
+
+	n2, err := NewNode(
+		PeerPrivKey(peers[2].PrivKey),
+		ListenAddrs(peers[2].ListenAddrs),
+		Discovery(peers[0].PeerAddrs),
+		Rendezvous("test"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n2.Start(ctx))
+
+	// Both nodes must advertise themselves under the rendezvous string:
+	waitFor(t, func() bool {
+		found := map[peer.ID]bool{}
+		ch, err := n0.routingDiscovery.FindPeers(ctx, "test")
+		if err != nil {
+			return false
+		}
+		for ai := range ch {
+			found[ai.ID] = true
+		}
+		return found[peers[1].ID] && found[peers[2].ID]
+	})
+	waitFor(t, func() bool {
+		return n2.Host().Network().Connectedness(peers[1].ID) == network.Connected
+	})
+}
+
+func TestNode_Transports(t *testing.T) {
+	// This test checks if nodes can connect to each other using only the
+	// QUIC transport.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs([]multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1/udp/0/quic-v1")}),
+		Transports([]string{"quic"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs([]multiaddr.Multiaddr{multiaddr.StringCast("/ip4/127.0.0.1/udp/0/quic-v1")}),
+		Transports([]string{"quic"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	require.NoError(t, n1.Host().Connect(ctx, peer.AddrInfo{ID: n0.Host().ID(), Addrs: n0.Host().Addrs()}))
+	assert.Equal(t, network.Connected, n1.Host().Network().Connectedness(n0.Host().ID()))
+
+	_, err = NewNode(Transports([]string{"unknown"}))
+	assert.Error(t, err)
+}
+
+func TestNode_Security(t *testing.T) {
+	// This test checks if the security protocols are negotiated correctly.
+
+	peers, err := getNodeInfo(3)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		Transports([]string{"tcp"}),
+		Security([]string{"tls"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	// Node with the same security protocol must be able to connect.
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+		Transports([]string{"tcp"}),
+		Security([]string{"noise", "tls"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+	assert.NoError(t, n1.Host().Connect(ctx, peer.AddrInfo{ID: peers[0].ID, Addrs: peers[0].ListenAddrs}))
+
+	// Node without a common security protocol must not be able to connect.
+	n2, err := NewNode(
+		PeerPrivKey(peers[2].PrivKey),
+		ListenAddrs(peers[2].ListenAddrs),
+		Transports([]string{"tcp"}),
+		Security([]string{"noise"}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n2.Start(ctx))
+	assert.Error(t, n2.Host().Connect(ctx, peer.AddrInfo{ID: peers[0].ID, Addrs: peers[0].ListenAddrs}))
+
+	_, err = NewNode(Security([]string{"unknown"}))
+	assert.Error(t, err)
+}
+
+func TestNode_Metrics(t *testing.T) {
+	// This test checks if the bandwidth is reported and if connection
+	// events are emitted.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	bw := metrics.NewBandwidthCounter()
+	eventsCh := make(chan ConnectionEvent, 10)
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		BandwidthReporter(bw),
+		ConnectionEvents(func(event ConnectionEvent) { eventsCh <- event }),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	require.NoError(t, n1.Host().Connect(ctx, peer.AddrInfo{ID: peers[0].ID, Addrs: peers[0].ListenAddrs}))
+	event := <-eventsCh
+	assert.Equal(t, peers[1].ID, event.PeerID)
+	assert.Equal(t, network.DirInbound, event.Direction)
+	assert.True(t, event.Connected)
+
+	waitFor(t, func() bool {
+		return bw.GetBandwidthForPeer(peers[1].ID).TotalIn > 0
+	})
+
+	require.NoError(t, n1.Host().Network().ClosePeer(peers[0].ID))
+	event = <-eventsCh
+	assert.Equal(t, peers[1].ID, event.PeerID)
+	assert.False(t, event.Connected)
+}
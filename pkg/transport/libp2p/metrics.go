@@ -0,0 +1,54 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal"
+)
+
+// ConnectionEvent describes a connection or a disconnection of a peer.
+type ConnectionEvent = internal.ConnectionEvent
+
+// PeerCount returns the number of connected peers. It returns zero if
+// the transport is not started.
+func (p *P2P) PeerCount() int {
+	if p.node.Host() == nil {
+		return 0
+	}
+	return len(p.node.Host().Network().Peers())
+}
+
+// Peers returns the IDs of connected peers. It returns nil if the transport
+// is not started.
+func (p *P2P) Peers() []peer.ID {
+	if p.node.Host() == nil {
+		return nil
+	}
+	return p.node.Host().Network().Peers()
+}
+
+// Bandwidth returns the total bandwidth used by the node.
+func (p *P2P) Bandwidth() metrics.Stats {
+	return p.bwReporter.GetBandwidthTotals()
+}
+
+// BandwidthByPeer returns the bandwidth used by the node for each peer.
+func (p *P2P) BandwidthByPeer() map[peer.ID]metrics.Stats {
+	return p.bwReporter.GetBandwidthByPeer()
+}
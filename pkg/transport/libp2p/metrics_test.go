@@ -0,0 +1,64 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestP2P_Metrics(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	eventsCh := make(chan ConnectionEvent, 10)
+	p0, err := New(Config{
+		Mode:                   BootstrapMode,
+		ListenAddrs:            []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnectionEventHandler: func(event ConnectionEvent) { eventsCh <- event },
+	})
+	require.NoError(t, err)
+	p1, err := New(Config{
+		Mode:        BootstrapMode,
+		ListenAddrs: []string{"/ip4/127.0.0.1/tcp/0"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, p0.PeerCount())
+	require.NoError(t, p0.Start(ctx))
+	require.NoError(t, p1.Start(ctx))
+
+	h0, h1 := p0.node.Host(), p1.node.Host()
+	require.NoError(t, h1.Connect(ctx, peer.AddrInfo{ID: h0.ID(), Addrs: h0.Addrs()}))
+
+	select {
+	case event := <-eventsCh:
+		assert.Equal(t, h1.ID(), event.PeerID)
+		assert.True(t, event.Connected)
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "connection event not received")
+	}
+	assert.Equal(t, 1, p0.PeerCount())
+	assert.Equal(t, []peer.ID{h1.ID()}, p0.Peers())
+	assert.Eventually(t, func() bool {
+		return p0.Bandwidth().TotalIn > 0 && p0.BandwidthByPeer()[h1.ID()].TotalIn > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
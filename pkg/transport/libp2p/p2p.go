@@ -18,6 +18,7 @@ package libp2p
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 
@@ -53,9 +55,10 @@ const (
 	BootstrapMode
 )
 
-// Values for the connection limiter:
-const minConnections = 100
-const maxConnections = 150
+// Default values for the connection limiter:
+const defaultConnLowWater = 100
+const defaultConnHighWater = 150
+const defaultConnGracePeriod = 5 * time.Minute
 
 // Parameters used to calculate peer scoring and rate limiter values:
 const maxBytesPerSecond float64 = 10 * 1024 * 1024 // 10MB/s
@@ -77,12 +80,13 @@ var defaultListenAddrs = []string{"/ip4/0.0.0.0/tcp/0"}
 // P2P is the wrapper for the Node that implements the transport.Transport
 // interface.
 type P2P struct {
-	id        peer.ID
-	node      *internal.Node
-	mode      Mode
-	topics    map[string]transport.Message
-	msgCh     map[string]chan transport.ReceivedMessage
-	msgFanOut map[string]*chanutil.FanOut[transport.ReceivedMessage]
+	id         peer.ID
+	node       *internal.Node
+	mode       Mode
+	topics     map[string]transport.Message
+	msgCh      map[string]chan transport.ReceivedMessage
+	msgFanOut  map[string]*chanutil.FanOut[transport.ReceivedMessage]
+	bwReporter metrics.Reporter
 }
 
 // Config is the configuration for the P2P transport.
@@ -107,6 +111,17 @@ type Config struct {
 	// listening on. If empty, the localhost, and a random port will be used.
 	ListenAddrs []string
 
+	// Transports is a list of transports to use. Supported transports are
+	// "tcp", "quic", "websocket" and "webtransport". If empty, the default
+	// libp2p transports are used. Listen addresses must use one of the
+	// configured transports.
+	Transports []string
+
+	// Security is a list of security protocols to use, in order of
+	// preference. Supported protocols are "noise" and "tls". If empty, the
+	// default libp2p security protocols are used.
+	Security []string
+
 	// BootstrapAddrs is a list multiaddresses of initial peers to connect to.
 	// This option is ignored when discovery is disabled.
 	BootstrapAddrs []string
@@ -118,9 +133,16 @@ type Config struct {
 
 	// BlockedAddrs is a list of multiaddresses to which connection will be
 	// blocked. If an address on that list contains an IP and a peer ID, both
-	// will be blocked separately.
+	// will be blocked separately. An IP may be followed by the ipcidr
+	// component to block the whole network, e.g. /ip4/10.0.0.0/ipcidr/8.
 	BlockedAddrs []string
 
+	// AllowedAddrs is a list of multiaddresses to which connection will be
+	// allowed. If not empty, connections to all other peers will be
+	// rejected. Addresses use the same format as BlockedAddrs. Bootstrap and
+	// direct peers must be on that list as well.
+	AllowedAddrs []string
+
 	// AuthorAllowlist is a list of allowed message authors. Only messages from
 	// these addresses will be accepted.
 	AuthorAllowlist []types.Address
@@ -130,6 +152,42 @@ type Config struct {
 	// to connect to the network. Always enabled in bootstrap mode.
 	Discovery bool
 
+	// EnableMDNS enables peer discovery in the local network using mDNS.
+	// It is useful for local development and LAN deployments. This option
+	// is ignored when discovery is disabled.
+	EnableMDNS bool
+
+	// Rendezvous is an optional rendezvous string. If set, the node
+	// advertises itself under this string using KAD-DHT and periodically
+	// searches for other peers advertising the same string. This option is
+	// ignored when discovery is disabled.
+	Rendezvous string
+
+	// ConnLowWater is the number of connections to which the connection
+	// manager trims connections when ConnHighWater is exceeded. If zero,
+	// the default value of 100 is used.
+	ConnLowWater int
+
+	// ConnHighWater is the number of connections above which the connection
+	// manager starts to close connections. If zero, the default value of
+	// 150 is used.
+	ConnHighWater int
+
+	// ConnGracePeriod is the duration during which new connections are not
+	// closed by the connection manager. If zero, the default value of five
+	// minutes is used.
+	ConnGracePeriod time.Duration
+
+	// BandwidthReporter is an optional bandwidth reporter. If not provided,
+	// the metrics.BandwidthCounter is used. Bandwidth statistics are
+	// available through the Bandwidth and BandwidthByPeer methods.
+	BandwidthReporter metrics.Reporter
+
+	// ConnectionEventHandler is an optional function that is invoked every
+	// time a connection to a peer is opened or closed. The function must
+	// not block.
+	ConnectionEventHandler func(ConnectionEvent)
+
 	// Signer used to verify price messages. Ignored in bootstrap mode.
 	Signer wallet.Key
 
@@ -164,6 +222,24 @@ func New(cfg Config) (*P2P, error) {
 	if cfg.Logger == nil {
 		cfg.Logger = null.New()
 	}
+	if cfg.BandwidthReporter == nil {
+		cfg.BandwidthReporter = metrics.NewBandwidthCounter()
+	}
+	if cfg.ConnLowWater == 0 {
+		cfg.ConnLowWater = defaultConnLowWater
+	}
+	if cfg.ConnHighWater == 0 {
+		cfg.ConnHighWater = defaultConnHighWater
+	}
+	if cfg.ConnGracePeriod == 0 {
+		cfg.ConnGracePeriod = defaultConnGracePeriod
+	}
+	if cfg.ConnLowWater < 0 || cfg.ConnHighWater < 0 || cfg.ConnGracePeriod < 0 {
+		return nil, errors.New("P2P transport error: connection limits cannot be negative")
+	}
+	if cfg.ConnLowWater > cfg.ConnHighWater {
+		return nil, errors.New("P2P transport error: ConnLowWater cannot be greater than ConnHighWater")
+	}
 
 	listenAddrs, err := strsToMaddrs(cfg.ListenAddrs)
 	if err != nil {
@@ -181,6 +257,10 @@ func New(cfg Config) (*P2P, error) {
 	if err != nil {
 		return nil, fmt.Errorf("P2P transport error: unable to parse blockedAddrs: %w", err)
 	}
+	allowedAddrs, err := strsToMaddrs(cfg.AllowedAddrs)
+	if err != nil {
+		return nil, fmt.Errorf("P2P transport error: unable to parse allowedAddrs: %w", err)
+	}
 
 	logger := cfg.Logger.WithField("tag", LoggerTag)
 	opts := []internal.Options{
@@ -190,14 +270,21 @@ func New(cfg Config) (*P2P, error) {
 		internal.PeerLogger(),
 		internal.UserAgent(fmt.Sprintf("%s/%s", cfg.AppName, cfg.AppVersion)),
 		internal.ListenAddrs(listenAddrs),
+		internal.Transports(cfg.Transports),
+		internal.Security(cfg.Security),
 		internal.DirectPeers(directPeersAddrs),
 		internal.Denylist(blockedAddrs),
+		internal.Allowlist(allowedAddrs),
 		internal.ConnectionLimit(
-			minConnections,
-			maxConnections,
-			5*time.Minute,
+			cfg.ConnLowWater,
+			cfg.ConnHighWater,
+			cfg.ConnGracePeriod,
 		),
 		internal.Monitor(),
+		internal.BandwidthReporter(cfg.BandwidthReporter),
+	}
+	if cfg.ConnectionEventHandler != nil {
+		opts = append(opts, internal.ConnectionEvents(cfg.ConnectionEventHandler))
 	}
 	if cfg.PeerPrivKey != nil {
 		opts = append(opts, internal.PeerPrivKey(cfg.PeerPrivKey))
@@ -235,12 +322,24 @@ func New(cfg Config) (*P2P, error) {
 		}
 		if cfg.Discovery {
 			opts = append(opts, internal.Discovery(bootstrapAddrs))
+			if cfg.EnableMDNS {
+				opts = append(opts, internal.MDNS())
+			}
+			if cfg.Rendezvous != "" {
+				opts = append(opts, internal.Rendezvous(cfg.Rendezvous))
+			}
 		}
 	case BootstrapMode:
 		opts = append(opts,
 			internal.DisablePubSub(),
 			internal.Discovery(bootstrapAddrs),
 		)
+		if cfg.EnableMDNS {
+			opts = append(opts, internal.MDNS())
+		}
+		if cfg.Rendezvous != "" {
+			opts = append(opts, internal.Rendezvous(cfg.Rendezvous))
+		}
 	default:
 		return nil, fmt.Errorf("P2P transport error: invalid mode: %d", cfg.Mode)
 	}
@@ -256,12 +355,13 @@ func New(cfg Config) (*P2P, error) {
 	}
 
 	return &P2P{
-		id:        id,
-		node:      n,
-		mode:      cfg.Mode,
-		topics:    cfg.Topics,
-		msgCh:     map[string]chan transport.ReceivedMessage{},
-		msgFanOut: map[string]*chanutil.FanOut[transport.ReceivedMessage]{},
+		id:         id,
+		node:       n,
+		mode:       cfg.Mode,
+		topics:     cfg.Topics,
+		msgCh:      map[string]chan transport.ReceivedMessage{},
+		msgFanOut:  map[string]*chanutil.FanOut[transport.ReceivedMessage]{},
+		bwReporter: cfg.BandwidthReporter,
 	}, nil
 }
 
@@ -288,6 +388,16 @@ func (p *P2P) Wait() <-chan error {
 	return p.node.Wait()
 }
 
+// Close stops the transport gracefully. It has the same effect as canceling
+// the context passed to the Start method, but it waits until the libp2p host
+// is closed or the given context is canceled.
+func (p *P2P) Close(ctx context.Context) error {
+	if err := p.node.Close(ctx); err != nil {
+		return fmt.Errorf("P2P transport error, unable to close node: %w", err)
+	}
+	return nil
+}
+
 // Broadcast implements the transport.Transport interface.
 func (p *P2P) Broadcast(topic string, message transport.Message) error {
 	sub, err := p.node.Subscription(topic)
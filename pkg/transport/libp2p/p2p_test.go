@@ -0,0 +1,54 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_InvalidConnectionLimits(t *testing.T) {
+	tests := []struct {
+		cfg Config
+	}{
+		{cfg: Config{ConnLowWater: 20, ConnHighWater: 10}},
+		{cfg: Config{ConnLowWater: 200}},
+		{cfg: Config{ConnLowWater: -1}},
+		{cfg: Config{ConnGracePeriod: -time.Second}},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.cfg)
+		assert.Error(t, err)
+	}
+}
+
+func TestP2P_Close(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	p, err := New(Config{Mode: BootstrapMode, ListenAddrs: []string{"/ip4/127.0.0.1/tcp/0"}})
+	require.NoError(t, err)
+	assert.Error(t, p.Close(ctx))
+	require.NoError(t, p.Start(ctx))
+	require.NoError(t, p.Close(ctx))
+
+	_, ok := <-p.Wait()
+	assert.False(t, ok)
+}
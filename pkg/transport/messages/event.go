@@ -16,9 +16,13 @@
 package messages
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages/pb"
@@ -28,7 +32,29 @@ const EventV1MessageName = "event/v1"
 
 const eventMessageMaxSize = 1 * 1024 * 1024 // 1MB
 
-var ErrEventMessageTooLarge = errors.New("event message too large")
+var (
+	ErrEventMessageTooLarge   = errors.New("event message too large")
+	ErrEventMissingHash       = errors.New("event data does not contain a hash field")
+	ErrEventQuorumNotMet      = errors.New("event signatures quorum not met")
+	ErrEventInvalidThreshold  = errors.New("event signatures quorum threshold must be at least 1")
+	ErrEventMissingSignedData = errors.New("event signed data rule is not specified")
+)
+
+// SignedDataFunc returns the data that signers sign for the given event
+// data, e.g. the value of a hash field or a digest of multiple fields.
+type SignedDataFunc func(data map[string][]byte) ([]byte, error)
+
+// HashField returns a SignedDataFunc that returns the value of the given
+// event data field.
+func HashField(name string) SignedDataFunc {
+	return func(data map[string][]byte) ([]byte, error) {
+		v, ok := data[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrEventMissingHash, name)
+		}
+		return v, nil
+	}
+}
 
 type EventSignature struct {
 	Signer    []byte
@@ -56,6 +82,12 @@ type Event struct {
 
 	// List of event signatures.
 	Signatures map[string]EventSignature
+
+	// List of signatures of additional signers, keyed by the signature
+	// scheme. Used when signatures from multiple signers are collected in
+	// a single event, in which case the first signature made using a scheme
+	// is stored in Signatures and the remaining ones here.
+	AdditionalSignatures map[string][]EventSignature
 }
 
 // Copy returns a copy of the event.
@@ -79,9 +111,115 @@ func (e *Event) Copy() *Event {
 		copy(evt.Signatures[k].Signer, v.Signer)
 		copy(evt.Signatures[k].Signature, v.Signature)
 	}
+	if e.AdditionalSignatures != nil {
+		evt.AdditionalSignatures = map[string][]EventSignature{}
+		for k, v := range e.AdditionalSignatures {
+			evt.AdditionalSignatures[k] = make([]EventSignature, len(v))
+			for i, s := range v {
+				evt.AdditionalSignatures[k][i] = EventSignature{
+					Signer:    make([]byte, len(s.Signer)),
+					Signature: make([]byte, len(s.Signature)),
+				}
+				copy(evt.AdditionalSignatures[k][i].Signer, s.Signer)
+				copy(evt.AdditionalSignatures[k][i].Signature, s.Signature)
+			}
+		}
+	}
 	return evt
 }
 
+// AddSignature adds a signature made using the given signature scheme, e.g.
+// "ethereum", to the event, so signatures from different signers can be
+// collected in a single event.
+//
+// The first signature is stored in Signatures under the scheme name, which
+// is where single-signer events keep their signature. Signatures of other
+// signers are appended to AdditionalSignatures. A signature from a signer
+// that already signed the event using the same scheme replaces the previous
+// one.
+func (e *Event) AddSignature(scheme string, sig EventSignature) {
+	if e.Signatures == nil {
+		e.Signatures = map[string]EventSignature{}
+	}
+	if s, ok := e.Signatures[scheme]; !ok || bytes.Equal(s.Signer, sig.Signer) {
+		e.Signatures[scheme] = sig
+		return
+	}
+	for i, s := range e.AdditionalSignatures[scheme] {
+		if bytes.Equal(s.Signer, sig.Signer) {
+			e.AdditionalSignatures[scheme][i] = sig
+			return
+		}
+	}
+	if e.AdditionalSignatures == nil {
+		e.AdditionalSignatures = map[string][]EventSignature{}
+	}
+	e.AdditionalSignatures[scheme] = append(e.AdditionalSignatures[scheme], sig)
+}
+
+// SchemeSignatures returns all signatures made using the given signature
+// scheme, starting with the one stored in Signatures.
+func (e *Event) SchemeSignatures(scheme string) []EventSignature {
+	var sigs []EventSignature
+	if s, ok := e.Signatures[scheme]; ok {
+		sigs = append(sigs, s)
+	}
+	return append(sigs, e.AdditionalSignatures[scheme]...)
+}
+
+// VerifyQuorum verifies that at least threshold of the authorized signers
+// signed the event. The signed data is derived from the event data using
+// the given function, which must match the rule used by the signers.
+//
+// All signatures in the event are checked, regardless of the scheme under
+// which they are stored. Signatures that are invalid, were made by a different
+// signer than declared, or were made by an unauthorized signer are ignored.
+// Every signer is counted only once.
+func (e *Event) VerifyQuorum(r crypto.Recoverer, authorized []types.Address, threshold int, signedData SignedDataFunc) error {
+	if threshold < 1 {
+		return ErrEventInvalidThreshold
+	}
+	if signedData == nil {
+		return ErrEventMissingSignedData
+	}
+	h, err := signedData(e.Data)
+	if err != nil {
+		return err
+	}
+	var sigs []EventSignature
+	for _, s := range e.Signatures {
+		sigs = append(sigs, s)
+	}
+	for _, v := range e.AdditionalSignatures {
+		sigs = append(sigs, v...)
+	}
+	signers := map[types.Address]struct{}{}
+	for _, s := range sigs {
+		sig, err := types.SignatureFromBytes(s.Signature)
+		if err != nil {
+			continue
+		}
+		signer, err := types.AddressFromBytes(s.Signer)
+		if err != nil {
+			continue
+		}
+		from, err := r.RecoverMessage(h, sig)
+		if err != nil || *from != signer {
+			continue
+		}
+		for _, a := range authorized {
+			if *from == a {
+				signers[a] = struct{}{}
+				break
+			}
+		}
+	}
+	if len(signers) < threshold {
+		return fmt.Errorf("%w: %d of %d signatures", ErrEventQuorumNotMet, len(signers), threshold)
+	}
+	return nil
+}
+
 // MarshallBinary implements the transport.Message interface.
 func (e *Event) MarshallBinary() ([]byte, error) {
 	signatures := map[string]*pb.Event_Signature{}
@@ -91,14 +229,26 @@ func (e *Event) MarshallBinary() ([]byte, error) {
 			Signature: s.Signature,
 		}
 	}
+	additionalSignatures := map[string]*pb.Event_SignatureList{}
+	for k, v := range e.AdditionalSignatures {
+		list := &pb.Event_SignatureList{}
+		for _, s := range v {
+			list.Signatures = append(list.Signatures, &pb.Event_Signature{
+				Signer:    s.Signer,
+				Signature: s.Signature,
+			})
+		}
+		additionalSignatures[k] = list
+	}
 	data, err := proto.Marshal(&pb.Event{
-		Type:             e.Type,
-		Id:               e.ID,
-		Index:            e.Index,
-		EventTimestamp:   e.EventDate.Unix(),
-		MessageTimestamp: e.MessageDate.Unix(),
-		Data:             e.Data,
-		Signatures:       signatures,
+		Type:                 e.Type,
+		Id:                   e.ID,
+		Index:                e.Index,
+		EventTimestamp:       e.EventDate.Unix(),
+		MessageTimestamp:     e.MessageDate.Unix(),
+		Data:                 e.Data,
+		Signatures:           signatures,
+		AdditionalSignatures: additionalSignatures,
 	})
 	if err != nil {
 		return nil, err
@@ -125,6 +275,18 @@ func (e *Event) UnmarshallBinary(data []byte) error {
 			Signature: s.Signature,
 		}
 	}
+	var additionalSignatures map[string][]EventSignature
+	for k, v := range msg.AdditionalSignatures {
+		if additionalSignatures == nil {
+			additionalSignatures = map[string][]EventSignature{}
+		}
+		for _, s := range v.Signatures {
+			additionalSignatures[k] = append(additionalSignatures[k], EventSignature{
+				Signer:    s.Signer,
+				Signature: s.Signature,
+			})
+		}
+	}
 	e.Type = msg.Type
 	e.ID = msg.Id
 	e.Index = msg.Index
@@ -132,5 +294,6 @@ func (e *Event) UnmarshallBinary(data []byte) error {
 	e.MessageDate = time.Unix(msg.MessageTimestamp, 0)
 	e.Data = msg.Data
 	e.Signatures = signatures
+	e.AdditionalSignatures = additionalSignatures
 	return nil
 }
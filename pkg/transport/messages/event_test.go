@@ -21,6 +21,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -40,6 +43,9 @@ func TestEvent_Copy(t *testing.T) {
 			"c": {Signer: []byte{16}, Signature: []byte{16}},
 			"d": {Signer: []byte{17}, Signature: []byte{17}},
 		},
+		AdditionalSignatures: map[string][]EventSignature{
+			"c": {{Signer: []byte{18}, Signature: []byte{18}}},
+		},
 	}
 
 	copiedEvent := event.Copy()
@@ -58,6 +64,14 @@ func TestEvent_Copy(t *testing.T) {
 		assert.NotSame(t, v.Signer, copiedEvent.Signatures[k].Signer)
 		assert.NotSame(t, v.Signature, copiedEvent.Signatures[k].Signature)
 	}
+	assert.NotSame(t, event.AdditionalSignatures, copiedEvent.AdditionalSignatures)
+	for k, v := range event.AdditionalSignatures {
+		assert.NotSame(t, v, copiedEvent.AdditionalSignatures[k])
+		for i, s := range v {
+			assert.NotSame(t, s.Signer, copiedEvent.AdditionalSignatures[k][i].Signer)
+			assert.NotSame(t, s.Signature, copiedEvent.AdditionalSignatures[k][i].Signature)
+		}
+	}
 }
 
 func TestEvent_Marshalling(t *testing.T) {
@@ -80,6 +94,12 @@ func TestEvent_Marshalling(t *testing.T) {
 					"c": {Signer: []byte{16}, Signature: []byte{16}},
 					"d": {Signer: []byte{17}, Signature: []byte{17}},
 				},
+				AdditionalSignatures: map[string][]EventSignature{
+					"c": {
+						{Signer: []byte{18}, Signature: []byte{18}},
+						{Signer: []byte{19}, Signature: []byte{19}},
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -107,6 +127,7 @@ func TestEvent_Marshalling(t *testing.T) {
 				assert.Equal(t, tt.event.MessageDate, event.MessageDate)
 				assert.Equal(t, tt.event.Data, event.Data)
 				assert.Equal(t, tt.event.Signatures, event.Signatures)
+				assert.Equal(t, tt.event.AdditionalSignatures, event.AdditionalSignatures)
 			}
 		})
 	}
@@ -117,3 +138,78 @@ func FuzzEvent_UnmarshallBinary(f *testing.F) {
 		_ = (&Event{}).UnmarshallBinary(data)
 	})
 }
+
+func TestEvent_AddSignature(t *testing.T) {
+	event := &Event{}
+	event.AddSignature("ethereum", EventSignature{Signer: []byte{1}, Signature: []byte{1}})
+	event.AddSignature("ethereum", EventSignature{Signer: []byte{2}, Signature: []byte{2}})
+	event.AddSignature("other", EventSignature{Signer: []byte{1}, Signature: []byte{3}})
+
+	// The first signature is stored under the scheme name, the remaining
+	// ones are listed as additional signatures.
+	assert.Equal(t, map[string]EventSignature{
+		"ethereum": {Signer: []byte{1}, Signature: []byte{1}},
+		"other":    {Signer: []byte{1}, Signature: []byte{3}},
+	}, event.Signatures)
+	assert.Equal(t, map[string][]EventSignature{
+		"ethereum": {{Signer: []byte{2}, Signature: []byte{2}}},
+	}, event.AdditionalSignatures)
+
+	// A signature from the same signer replaces the previous one.
+	event.AddSignature("ethereum", EventSignature{Signer: []byte{1}, Signature: []byte{4}})
+	event.AddSignature("ethereum", EventSignature{Signer: []byte{2}, Signature: []byte{5}})
+	event.AddSignature("ethereum", EventSignature{Signer: []byte{3}, Signature: []byte{6}})
+	assert.Equal(t, []EventSignature{
+		{Signer: []byte{1}, Signature: []byte{4}},
+		{Signer: []byte{2}, Signature: []byte{5}},
+		{Signer: []byte{3}, Signature: []byte{6}},
+	}, event.SchemeSignatures("ethereum"))
+	assert.Equal(t, []EventSignature{
+		{Signer: []byte{1}, Signature: []byte{3}},
+	}, event.SchemeSignatures("other"))
+	assert.Empty(t, event.SchemeSignatures("unknown"))
+}
+
+func TestEvent_VerifyQuorum(t *testing.T) {
+	hash := []byte("hash")
+	keys := []*wallet.PrivateKey{wallet.NewRandomKey(), wallet.NewRandomKey(), wallet.NewRandomKey()}
+	sign := func(k *wallet.PrivateKey, data []byte) EventSignature {
+		s, err := k.SignMessage(data)
+		require.NoError(t, err)
+		return EventSignature{Signer: k.Address().Bytes(), Signature: s.Bytes()}
+	}
+	authorized := []types.Address{keys[0].Address(), keys[1].Address()}
+	signedData := HashField("hash")
+
+	event := &Event{Data: map[string][]byte{"hash": hash}}
+	event.AddSignature("ethereum", sign(keys[0], hash))
+	event.AddSignature("ethereum", sign(keys[0], hash)) // Same signer must be counted once.
+	event.AddSignature("ethereum", sign(keys[2], hash)) // Unauthorized signer.
+	assert.Len(t, event.SchemeSignatures("ethereum"), 2)
+	assert.NoError(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 1, signedData))
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 2, signedData), ErrEventQuorumNotMet)
+
+	// Signature with a forged signer address must be ignored:
+	forged := sign(keys[2], hash)
+	forged.Signer = keys[1].Address().Bytes()
+	event.Signatures["forged"] = forged
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 2, signedData), ErrEventQuorumNotMet)
+
+	event.AddSignature("ethereum", sign(keys[1], hash))
+	assert.NoError(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 2, signedData))
+
+	// Invalid threshold:
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 0, signedData), ErrEventInvalidThreshold)
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, -1, signedData), ErrEventInvalidThreshold)
+
+	// Missing signed data rule:
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 1, nil), ErrEventMissingSignedData)
+
+	// A different signed data rule does not match the signatures:
+	event.Data["digest"] = []byte("digest")
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 1, HashField("digest")), ErrEventQuorumNotMet)
+
+	// Missing hash:
+	event.Data = nil
+	assert.ErrorIs(t, event.VerifyQuorum(crypto.ECRecoverer, authorized, 1, signedData), ErrEventMissingHash)
+}
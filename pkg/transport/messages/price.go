@@ -83,7 +83,7 @@ func (p *Price) MarshallBinary() ([]byte, error) {
 			return nil, err
 		}
 		if len(data) > priceMessageMaxSize {
-			return nil, ErrEventMessageTooLarge
+			return nil, ErrPriceMessageTooLarge
 		}
 		return data, nil
 	case 0:
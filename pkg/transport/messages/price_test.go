@@ -89,7 +89,7 @@ func TestPrice_Marshalling(t *testing.T) {
 				},
 				Trace:   []byte("{}"),
 				Version: "0.0.1",
-			}).AsV0(),
+			}).AsV1(),
 			wantErr: false,
 		},
 		// Without trace:
@@ -183,6 +183,22 @@ func TestPrice_Marshalling(t *testing.T) {
 	}
 }
 
+func TestPrice_MarshallBinary_UnknownVersion(t *testing.T) {
+	price := &Price{messageVersion: 2, Price: &median.Price{Wat: "AAABBB"}}
+	_, err := price.MarshallBinary()
+	assert.ErrorIs(t, err, ErrUnknownPriceMessageVersion)
+}
+
+func TestPrice_MarshallBinary_TooLarge(t *testing.T) {
+	for _, price := range []*Price{
+		{messageVersion: 0, Price: &median.Price{}, Version: strings.Repeat("a", priceMessageMaxSize)},
+		{messageVersion: 1, Price: &median.Price{}, Version: strings.Repeat("a", priceMessageMaxSize)},
+	} {
+		_, err := price.MarshallBinary()
+		assert.ErrorIs(t, err, ErrPriceMessageTooLarge)
+	}
+}
+
 func FuzzPrice_UnmarshallBinary(f *testing.F) {
 	f.Fuzz(func(t *testing.T, data []byte) {
 		_ = (&Price{}).UnmarshallBinary(data)
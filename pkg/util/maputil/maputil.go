@@ -15,6 +15,12 @@
 
 package maputil
 
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
 // Keys returns the slice of keys for the given map.
 func Keys[T1 comparable, T2 any](m map[T1]T2) []T1 {
 	keys := make([]T1, 0, len(m))
@@ -24,6 +30,16 @@ func Keys[T1 comparable, T2 any](m map[T1]T2) []T1 {
 	return keys
 }
 
+// Values returns the slice of values for the given map. The order of values
+// is unspecified.
+func Values[T1 comparable, T2 any](m map[T1]T2) []T2 {
+	values := make([]T2, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
 // SortKeys returns the slice of keys for the given map, sorted using given
 // sorting function.
 func SortKeys[T1 comparable, T2 any](m map[T1]T2, sort func([]T1)) []T1 {
@@ -32,6 +48,14 @@ func SortKeys[T1 comparable, T2 any](m map[T1]T2, sort func([]T1)) []T1 {
 	return keys
 }
 
+// SortedKeys returns the slice of keys for the given map, sorted in
+// ascending order. Use SortKeys for custom orderings.
+func SortedKeys[T1 constraints.Ordered, T2 any](m map[T1]T2) []T1 {
+	keys := Keys(m)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
 // Copy returns a shallow copy of the given map.
 func Copy[T1 comparable, T2 any](m map[T1]T2) map[T1]T2 {
 	newMap := make(map[T1]T2, len(m))
@@ -40,3 +64,59 @@ func Copy[T1 comparable, T2 any](m map[T1]T2) map[T1]T2 {
 	}
 	return newMap
 }
+
+// Equal reports whether two maps contain the same keys and values. A nil map
+// and an empty map are considered equal.
+func Equal[T1, T2 comparable](a, b map[T1]T2) bool {
+	return EqualFunc(a, b, func(v1, v2 T2) bool { return v1 == v2 })
+}
+
+// EqualFunc works like Equal, but it compares values using the given
+// function. It can be used with maps whose values are not comparable.
+func EqualFunc[T1 comparable, T2, T3 any](a map[T1]T2, b map[T1]T3, eq func(T2, T3) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v1 := range a {
+		v2, ok := b[k]
+		if !ok || !eq(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// Invert returns a new map with keys and values swapped. If multiple keys
+// have the same value, only one of them is kept, and which one is
+// unspecified because the map iteration order is random. Use InvertMulti
+// for maps with duplicate values.
+func Invert[T1, T2 comparable](m map[T1]T2) map[T2]T1 {
+	inv := make(map[T2]T1, len(m))
+	for k, v := range m {
+		inv[v] = k
+	}
+	return inv
+}
+
+// InvertMulti returns a new map with keys and values swapped. All keys that
+// have the same value are collected in a slice. The order of keys in the
+// slice is unspecified.
+func InvertMulti[T1, T2 comparable](m map[T1]T2) map[T2][]T1 {
+	inv := make(map[T2][]T1, len(m))
+	for k, v := range m {
+		inv[v] = append(inv[v], k)
+	}
+	return inv
+}
+
+// GroupBy groups the given items into a map by the key returned by the keyFn
+// function. The order of items within each group is the same as in the
+// input slice.
+func GroupBy[T any, K comparable](items []T, keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := keyFn(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
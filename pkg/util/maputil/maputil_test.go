@@ -16,6 +16,8 @@
 package maputil
 
 import (
+	"bytes"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -31,6 +33,18 @@ func TestKeys(t *testing.T) {
 	})
 }
 
+func TestValues(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"x", "y"}, Values(map[string]string{"a": "x", "b": "y"}))
+	})
+	t.Run("case-2", func(t *testing.T) {
+		assert.ElementsMatch(t, []int{1, 1}, Values(map[int]int{1: 1, 2: 1}))
+	})
+	t.Run("case-3", func(t *testing.T) {
+		assert.Empty(t, Values(map[int]int{}))
+	})
+}
+
 func TestSortKeys(t *testing.T) {
 	t.Run("case-1", func(t *testing.T) {
 		m := map[string]string{"b": "b", "a": "a"}
@@ -42,6 +56,20 @@ func TestSortKeys(t *testing.T) {
 	})
 }
 
+func TestSortedKeys(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		m := map[string]string{"b": "b", "c": "c", "a": "a"}
+		assert.Equal(t, []string{"a", "b", "c"}, SortedKeys(m))
+	})
+	t.Run("case-2", func(t *testing.T) {
+		m := map[float64]int{2.5: 2, -1: 1, 0: 0}
+		assert.Equal(t, []float64{-1, 0, 2.5}, SortedKeys(m))
+	})
+	t.Run("case-3", func(t *testing.T) {
+		assert.Empty(t, SortedKeys(map[int]int{}))
+	})
+}
+
 func TestCopy(t *testing.T) {
 	t.Run("case-1", func(t *testing.T) {
 		m := map[string]string{"a": "a", "b": "b"}
@@ -54,3 +82,88 @@ func TestCopy(t *testing.T) {
 		assert.NotSame(t, m, Copy(m))
 	})
 }
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b map[string]int
+		want bool
+	}{
+		{a: map[string]int{"a": 1, "b": 2}, b: map[string]int{"b": 2, "a": 1}, want: true},
+		{a: map[string]int{"a": 1}, b: map[string]int{"a": 2}, want: false},
+		{a: map[string]int{"a": 1}, b: map[string]int{"b": 1}, want: false},
+		{a: map[string]int{"a": 1}, b: map[string]int{"a": 1, "b": 2}, want: false},
+		{a: map[string]int{"a": 0}, b: map[string]int{"b": 0}, want: false},
+		{a: nil, b: map[string]int{}, want: true},
+		{a: nil, b: nil, want: true},
+		{a: nil, b: map[string]int{"a": 1}, want: false},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			assert.Equal(t, tt.want, Equal(tt.a, tt.b))
+			assert.Equal(t, tt.want, Equal(tt.b, tt.a))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		a := map[string][]byte{"a": {1}, "b": {2}}
+		b := map[string][]byte{"a": {1}, "b": {2}}
+		assert.True(t, EqualFunc(a, b, bytes.Equal))
+	})
+	t.Run("case-2", func(t *testing.T) {
+		a := map[string][]byte{"a": {1}, "b": {2}}
+		b := map[string][]byte{"a": {1}, "b": {3}}
+		assert.False(t, EqualFunc(a, b, bytes.Equal))
+	})
+	t.Run("case-3", func(t *testing.T) {
+		assert.True(t, EqualFunc(nil, map[string][]byte{}, bytes.Equal))
+	})
+}
+
+func TestInvert(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2}
+		assert.Equal(t, map[int]string{1: "a", 2: "b"}, Invert(m))
+	})
+	t.Run("case-2", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 1, "c": 2}
+		inv := Invert(m)
+		assert.Len(t, inv, 2)
+		assert.Contains(t, []string{"a", "b"}, inv[1])
+		assert.Equal(t, "c", inv[2])
+	})
+}
+
+func TestInvertMulti(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2}
+		assert.Equal(t, map[int][]string{1: {"a"}, 2: {"b"}}, InvertMulti(m))
+	})
+	t.Run("case-2", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 1, "c": 2}
+		inv := InvertMulti(m)
+		assert.Len(t, inv, 2)
+		assert.ElementsMatch(t, []string{"a", "b"}, inv[1])
+		assert.Equal(t, []string{"c"}, inv[2])
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Run("case-1", func(t *testing.T) {
+		items := []string{"apple", "bar", "avocado", "baz", "cherry", "almond"}
+		groups := GroupBy(items, func(s string) byte { return s[0] })
+		assert.Equal(t, map[byte][]string{
+			'a': {"apple", "avocado", "almond"},
+			'b': {"bar", "baz"},
+			'c': {"cherry"},
+		}, groups)
+	})
+	t.Run("case-2", func(t *testing.T) {
+		groups := GroupBy([]int{5, 2, 3, 4, 1}, func(n int) bool { return n%2 == 0 })
+		assert.Equal(t, map[bool][]int{true: {2, 4}, false: {5, 3, 1}}, groups)
+	})
+	t.Run("case-3", func(t *testing.T) {
+		assert.Empty(t, GroupBy(nil, func(n int) int { return n }))
+	})
+}
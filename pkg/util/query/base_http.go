@@ -16,6 +16,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -42,14 +43,34 @@ type HTTPRequest struct {
 	Body    io.Reader
 }
 
+// transientError wraps errors for which the request may be retried, like
+// network errors, timeouts, and 429 and 5xx status codes.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+func isTransientError(err error) bool {
+	var tErr *transientError
+	return errors.As(err, &tErr)
+}
+
 // HTTPResponse default query engine response
 type HTTPResponse struct {
 	Body  []byte
 	Error error
 }
 
-// MakeHTTPRequest makes HTTP request to given `url` with `headers` and in case of error
-// it will retry request `retry` amount of times. And only after it (if it's still error) error will be returned.
+// MakeHTTPRequest makes HTTP request to given `url` with `headers` and in case of transient error
+// (network error, timeout, 429 or 5xx status code) it will retry request `retry` amount of times.
+// And only after it (if it's still error) error will be returned.
 // Automatically timeout between requests will be calculated using `random`.
 // Note for `timeout` waiting this function uses `time.Sleep()` so it will block execution flow.
 // Better to be used in go-routine.
@@ -60,8 +81,8 @@ func MakeHTTPRequest(r *HTTPRequest) *HTTPResponse {
 		}
 	}
 
-	// Check for non set Retry
-	if r.Retry == 0 {
+	// Check for non set or invalid Retry
+	if r.Retry < 1 {
 		r.Retry = defaultRetry
 	}
 
@@ -71,14 +92,16 @@ func MakeHTTPRequest(r *HTTPRequest) *HTTPResponse {
 
 	for step <= r.Retry {
 		res, err = doMakeHTTPRequest(r)
-		if err != nil {
+		if err != nil && isTransientError(err) && step < r.Retry {
 			time.Sleep(defaultDelayBetweenRetries)
 			step++
 			continue
 		}
-		// All ok no `err` received
 		break
 	}
+	if err != nil && step > 1 {
+		err = fmt.Errorf("HTTP request to %s failed after %d attempts: %w", r.URL, step, err)
+	}
 
 	return &HTTPResponse{
 		Body:  res,
@@ -115,13 +138,16 @@ func doMakeHTTPRequest(r *HTTPRequest) ([]byte, error) {
 	// Perform HTTP request
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, &transientError{err: err}
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to make HTTP request to %s, got %d status code", r.URL, resp.StatusCode)
+		err := fmt.Errorf("failed to make HTTP request to %s, got %d status code", r.URL, resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return nil, &transientError{err: err}
+		}
+		return nil, err
 	}
 
-	defer resp.Body.Close()
-
 	return ioutil.ReadAll(resp.Body)
 }
@@ -18,7 +18,9 @@ package query
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -119,7 +121,7 @@ func (suite *MakeRequestSuite) TestMakeHTTPRequestWithRetryFails() {
 		assert.EqualValues(suite.T(), requiredHeaderValue, req.Header.Get(requiredHeaderKey))
 		calls++
 		// Send response to be tested.
-		rw.WriteHeader(404)
+		rw.WriteHeader(503)
 	}))
 
 	assert.NotNil(suite.T(), suite.server)
@@ -132,10 +134,105 @@ func (suite *MakeRequestSuite) TestMakeHTTPRequestWithRetryFails() {
 	res := MakeHTTPRequest(r)
 
 	assert.Error(suite.T(), res.Error)
+	assert.Contains(suite.T(), res.Error.Error(), "failed after 3 attempts")
 	assert.EqualValues(suite.T(), []byte(nil), res.Body)
 	assert.EqualValues(suite.T(), 3, calls)
 }
 
+func (suite *MakeRequestSuite) TestMakeHTTPRequestWithoutRetryOnClientError() {
+	calls := 0
+	// Start a local HTTP server
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		// Client errors are not transient, so the request must not be retried.
+		rw.WriteHeader(404)
+	}))
+
+	res := MakeHTTPRequest(&HTTPRequest{URL: suite.server.URL, Retry: 3})
+
+	assert.Error(suite.T(), res.Error)
+	assert.EqualValues(suite.T(), 1, calls)
+}
+
+func (suite *MakeRequestSuite) TestMakeHTTPRequestWithTimeout() {
+	var calls atomic.Int32
+	// Start a local HTTP server
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		rw.Write([]byte(serverResponse))
+	}))
+
+	res := MakeHTTPRequest(&HTTPRequest{URL: suite.server.URL, Retry: 2, Timeout: 50 * time.Millisecond})
+
+	assert.Error(suite.T(), res.Error)
+	assert.Contains(suite.T(), res.Error.Error(), "failed after 2 attempts")
+	assert.EqualValues(suite.T(), 2, calls.Load())
+}
+
+func (suite *MakeRequestSuite) TestHTTPWorkerPoolDefaults() {
+	var calls atomic.Int32
+	// Start a local HTTP server
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		rw.Write([]byte(serverResponse))
+	}))
+
+	wp := NewHTTPWorkerPool(1)
+	wp.SetTimeout(50 * time.Millisecond)
+	wp.SetRetry(1)
+	req := &HTTPRequest{URL: suite.server.URL}
+	res := wp.Query(req)
+
+	assert.Error(suite.T(), res.Error)
+	assert.EqualValues(suite.T(), 1, calls.Load())
+	// The defaults must not be written into the caller's request.
+	assert.Zero(suite.T(), req.Timeout)
+	assert.Zero(suite.T(), req.Retry)
+}
+
+func (suite *MakeRequestSuite) TestMakeHTTPRequestWithNegativeRetry() {
+	calls := 0
+	// Start a local HTTP server
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		rw.WriteHeader(503)
+	}))
+
+	// A negative number of attempts is treated like an unset one, so the
+	// default number of attempts is used.
+	res := MakeHTTPRequest(&HTTPRequest{URL: suite.server.URL, Retry: -1})
+
+	assert.Error(suite.T(), res.Error)
+	assert.EqualValues(suite.T(), defaultRetry, calls)
+}
+
+func (suite *MakeRequestSuite) TestDefaultsWorkerPool() {
+	var calls atomic.Int32
+	// Start a local HTTP server
+	suite.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		rw.Write([]byte(serverResponse))
+	}))
+
+	pool := NewHTTPWorkerPool(1)
+	pool.SetTimeout(time.Second)
+	pool.SetRetry(1)
+	wp := NewDefaultsWorkerPool(pool, 50*time.Millisecond, 2)
+	req := &HTTPRequest{URL: suite.server.URL}
+	res := wp.Query(req)
+
+	// The defaults of the wrapper take precedence over the pool defaults.
+	assert.Error(suite.T(), res.Error)
+	assert.Contains(suite.T(), res.Error.Error(), "failed after 2 attempts")
+	assert.EqualValues(suite.T(), 2, calls.Load())
+	// The defaults must not be written into the caller's request.
+	assert.Zero(suite.T(), req.Timeout)
+	assert.Zero(suite.T(), req.Retry)
+}
+
 func (suite *MakeRequestSuite) TestMakeHTTPRequestWithRetry() {
 	calls := 0
 	// Start a local HTTP server
@@ -145,7 +242,7 @@ func (suite *MakeRequestSuite) TestMakeHTTPRequestWithRetry() {
 		// Send response to be tested.
 		// Successonly on 3rd call
 		if calls < 3 {
-			rw.WriteHeader(404)
+			rw.WriteHeader(503)
 		} else {
 			rw.Write([]byte(serverResponse))
 		}
@@ -15,6 +15,8 @@
 
 package query
 
+import "time"
+
 // WorkerPool interface for any Query Engine worker pools
 type WorkerPool interface {
 	Query(req *HTTPRequest) *HTTPResponse
@@ -25,6 +27,8 @@ type WorkerPool interface {
 type HTTPWorkerPool struct {
 	workerCount int
 	input       chan *asyncHTTPRequest
+	timeout     time.Duration
+	retry       int
 }
 
 type asyncHTTPRequest struct {
@@ -46,6 +50,18 @@ func NewHTTPWorkerPool(workerCount int) *HTTPWorkerPool {
 	return wp
 }
 
+// SetTimeout sets the default timeout for requests that do not specify
+// their own timeout. It must be called before the first query.
+func (wp *HTTPWorkerPool) SetTimeout(timeout time.Duration) {
+	wp.timeout = timeout
+}
+
+// SetRetry sets the default number of attempts for requests that do not
+// specify their own number. It must be called before the first query.
+func (wp *HTTPWorkerPool) SetRetry(retry int) {
+	wp.retry = retry
+}
+
 // Query makes request to given Request
 // Under the hood it will wrap everything to async query and execute it using
 // worker pool.
@@ -65,6 +81,51 @@ func (wp *HTTPWorkerPool) Query(req *HTTPRequest) *HTTPResponse {
 
 func (wp *HTTPWorkerPool) worker() {
 	for req := range wp.input {
-		req.response <- MakeHTTPRequest(req.request)
+		if req.request == nil {
+			req.response <- MakeHTTPRequest(nil)
+			continue
+		}
+		// Defaults are applied to a copy, so the caller's request is not
+		// modified and can be reused with another pool.
+		r := *req.request
+		if r.Timeout == 0 {
+			r.Timeout = wp.timeout
+		}
+		if r.Retry == 0 {
+			r.Retry = wp.retry
+		}
+		req.response <- MakeHTTPRequest(&r)
+	}
+}
+
+// DefaultsWorkerPool wraps another worker pool and sets the timeout and the
+// number of attempts for requests that do not specify their own. It allows
+// using different defaults for different origins that share the same
+// worker pool.
+type DefaultsWorkerPool struct {
+	wp      WorkerPool
+	timeout time.Duration
+	retry   int
+}
+
+// NewDefaultsWorkerPool creates a new worker pool that sets the given
+// timeout and number of attempts on requests before passing them to wp.
+// Zero values are not set, so the defaults of wp are used instead.
+func NewDefaultsWorkerPool(wp WorkerPool, timeout time.Duration, retry int) *DefaultsWorkerPool {
+	return &DefaultsWorkerPool{wp: wp, timeout: timeout, retry: retry}
+}
+
+// Query implements the WorkerPool interface.
+func (wp *DefaultsWorkerPool) Query(req *HTTPRequest) *HTTPResponse {
+	if req == nil {
+		return wp.wp.Query(nil)
+	}
+	r := *req
+	if r.Timeout == 0 {
+		r.Timeout = wp.timeout
+	}
+	if r.Retry == 0 {
+		r.Retry = wp.retry
 	}
+	return wp.wp.Query(&r)
 }
@@ -62,3 +62,34 @@ func TryForever(ctx context.Context, f func() error, delay time.Duration) {
 		t.Stop()
 	}
 }
+
+// TryWithBackoff runs the f function until it returns nil but not more than
+// defined in the attempts argument. If attempts is zero or negative, it
+// retries until the context is canceled. The delay between attempts starts
+// at baseDelay and is doubled after each attempt, but it never exceeds
+// maxDelay. After reaching the max attempts, it returns the last error. If
+// the context is canceled, the function stops and returns the error.
+func TryWithBackoff(ctx context.Context, f func() error, attempts int, baseDelay, maxDelay time.Duration) (err error) {
+	delay := baseDelay
+	for i := 0; attempts <= 0 || i < attempts; i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err = f(); err == nil {
+			return nil
+		}
+		if attempts > 0 && i == attempts-1 {
+			break
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+		case <-t.C:
+		}
+		t.Stop()
+		if delay *= 2; delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	return err
+}
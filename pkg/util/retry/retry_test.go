@@ -81,3 +81,43 @@ func TestTryForever_ctxCancel(t *testing.T) {
 
 	require.Equal(t, tries, 4)
 }
+
+func TestTryWithBackoff_error(t *testing.T) {
+	var calls []time.Time
+
+	require.Error(t, TryWithBackoff(context.Background(), func() error {
+		calls = append(calls, time.Now())
+		return errors.New("error")
+	}, 4, time.Millisecond*20, time.Millisecond*50))
+
+	require.Len(t, calls, 4)
+	require.GreaterOrEqual(t, calls[1].Sub(calls[0]), time.Millisecond*20)
+	require.GreaterOrEqual(t, calls[2].Sub(calls[1]), time.Millisecond*40)
+	require.GreaterOrEqual(t, calls[3].Sub(calls[2]), time.Millisecond*50)
+	require.Less(t, calls[3].Sub(calls[2]), time.Millisecond*80)
+}
+
+func TestTryWithBackoff_noerror(t *testing.T) {
+	c := 0
+
+	require.NoError(t, TryWithBackoff(context.Background(), func() error {
+		if c++; c < 3 {
+			return errors.New("error")
+		}
+		return nil
+	}, 0, time.Millisecond, time.Millisecond*10))
+
+	require.Equal(t, 3, c)
+}
+
+func TestTryWithBackoff_ctxCancel(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	n := time.Now()
+	time.AfterFunc(time.Millisecond*100, ctxCancel)
+
+	require.Error(t, TryWithBackoff(ctx, func() error {
+		return errors.New("error")
+	}, 0, time.Second, time.Second))
+
+	require.Less(t, time.Since(n), time.Millisecond*200)
+}
@@ -0,0 +1,1148 @@
+package anymapper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func builtInTypesMapper(_ *Mapper, src, dst reflect.Type) MapFunc {
+	switch src.Kind() {
+	case reflect.Bool:
+		switch dst.Kind() {
+		case reflect.Bool:
+			return mapBoolToBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mapBoolToInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return mapBoolToUint
+		case reflect.Float32, reflect.Float64:
+			return mapBoolToFloat
+		case reflect.String:
+			return mapBoolToString
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch dst.Kind() {
+		case reflect.Bool:
+			return mapIntToBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mapIntToInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return mapIntToUint
+		case reflect.Float32, reflect.Float64:
+			return mapIntToFloat
+		case reflect.String:
+			return mapIntToString
+		case reflect.Slice, reflect.Array:
+			if dst.Elem().Kind() == reflect.Uint8 {
+				return mapIntToByteSliceOrByteArray
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch dst.Kind() {
+		case reflect.Bool:
+			return mapUintToBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mapUintToInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return mapUintToUint
+		case reflect.Float32, reflect.Float64:
+			return mapUintToFloat
+		case reflect.String:
+			return mapUintToString
+		case reflect.Slice, reflect.Array:
+			if dst.Elem().Kind() == reflect.Uint8 {
+				return mapUintToByteSliceOrByteArray
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		switch dst.Kind() {
+		case reflect.Bool:
+			return mapFloatToBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mapFloatToInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return mapFloatToUint
+		case reflect.Float32, reflect.Float64:
+			return mapFloatToFloat
+		case reflect.String:
+			return mapFloatToString
+		case reflect.Slice, reflect.Array:
+			if dst.Elem().Kind() == reflect.Uint8 {
+				return mapFloatToByteSliceOrByteArray
+			}
+		}
+	case reflect.Complex64, reflect.Complex128:
+		switch dst.Kind() {
+		case reflect.Complex64, reflect.Complex128:
+			return mapComplexToComplex
+		case reflect.Slice, reflect.Array:
+			if isFloatKind(dst.Elem().Kind()) {
+				return mapComplexToFloatPair
+			}
+		}
+	case reflect.String:
+		switch dst.Kind() {
+		case reflect.Bool:
+			return mapStringToBool
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return mapStringToInt
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return mapStringToUint
+		case reflect.Float32, reflect.Float64:
+			return mapStringToFloat
+		case reflect.String:
+			return mapStringToString
+		case reflect.Slice:
+			if dst.Elem().Kind() == reflect.Uint8 {
+				return mapStringToByteSlice
+			}
+		case reflect.Array:
+			if dst.Elem().Kind() == reflect.Uint8 {
+				return mapStringToByteArray
+			}
+		}
+	case reflect.Slice:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if src.Elem().Kind() == reflect.Uint8 {
+				return mapByteSliceToNumber
+			}
+		case reflect.String:
+			if src.Elem().Kind() == reflect.Uint8 {
+				return mapByteSliceToString
+			}
+		case reflect.Complex64, reflect.Complex128:
+			if isFloatKind(src.Elem().Kind()) {
+				return mapFloatPairToComplex
+			}
+		case reflect.Slice:
+			return mapSliceToSlice
+		case reflect.Array:
+			return mapSliceToArray
+		}
+	case reflect.Array:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if src.Elem().Kind() == reflect.Uint8 {
+				return mapByteArrayToNumber
+			}
+		case reflect.String:
+			if src.Elem().Kind() == reflect.Uint8 {
+				return mapByteArrayToString
+			}
+		case reflect.Complex64, reflect.Complex128:
+			if isFloatKind(src.Elem().Kind()) {
+				return mapFloatPairToComplex
+			}
+		case reflect.Slice:
+			return mapArrayToSlice
+		case reflect.Array:
+			return mapArrayToArray
+		}
+	case reflect.Map:
+		switch dst.Kind() {
+		case reflect.Map:
+			return mapMapToMap
+		case reflect.Struct:
+			return mapMapToStruct
+		}
+	case reflect.Struct:
+		switch dst.Kind() {
+		case reflect.Struct:
+			switch {
+			case src == dst:
+				return mapStructsOfSameType
+			default:
+				return mapStructsOfDifferentTypes
+			}
+		case reflect.Map:
+			if dst.Key().Kind() == reflect.String {
+				return mapStructToMap
+			}
+		}
+	default:
+		return nil
+	}
+	return nil
+}
+
+func mapBoolToBool(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetBool(src.Bool())
+	return nil
+}
+
+func mapBoolToInt(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Bool() {
+		dst.SetInt(1)
+	} else {
+		dst.SetInt(0)
+	}
+	return nil
+}
+
+func mapBoolToUint(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Bool() {
+		dst.SetUint(1)
+	} else {
+		dst.SetUint(0)
+	}
+	return nil
+}
+
+func mapBoolToFloat(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Bool() {
+		dst.SetFloat(1)
+	} else {
+		dst.SetFloat(0)
+	}
+	return nil
+}
+
+func mapBoolToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Bool() {
+		dst.SetString("true")
+	} else {
+		dst.SetString("false")
+	}
+	return nil
+}
+
+func mapIntToBool(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetBool(src.Int() != 0)
+	return nil
+}
+
+func mapIntToInt(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if dst.OverflowInt(src.Int()) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetInt(src.Int())
+	return nil
+}
+
+func mapIntToUint(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Int() < 0 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "negative value")
+	}
+	if dst.OverflowUint(uint64(src.Int())) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetUint(uint64(src.Int()))
+	return nil
+}
+
+func mapIntToFloat(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetFloat(float64(src.Int()))
+	return nil
+}
+
+func mapIntToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetString(strconv.FormatInt(src.Int(), 10))
+	return nil
+}
+
+func mapIntToByteSliceOrByteArray(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	return numberToBytes(ctx, src, dst)
+}
+
+func mapUintToBool(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetBool(src.Uint() != 0)
+	return nil
+}
+
+func mapUintToInt(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Uint() > math.MaxInt64 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	if dst.OverflowInt(int64(src.Uint())) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetInt(int64(src.Uint()))
+	return nil
+}
+
+func mapUintToUint(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if dst.OverflowUint(src.Uint()) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetUint(src.Uint())
+	return nil
+}
+
+func mapUintToFloat(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetFloat(float64(src.Uint()))
+	return nil
+}
+
+func mapUintToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetString(strconv.FormatUint(src.Uint(), 10))
+	return nil
+}
+
+func mapUintToByteSliceOrByteArray(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	return numberToBytes(ctx, src, dst)
+}
+
+func mapFloatToBool(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetBool(src.Float() != 0)
+	return nil
+}
+
+func mapFloatToInt(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Float() > math.MaxInt64 || src.Float() < math.MinInt64 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	if dst.OverflowInt(int64(src.Float())) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetInt(int64(src.Float()))
+	return nil
+}
+
+func mapFloatToUint(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Float() < 0 || src.Float() > math.MaxUint64 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	if dst.OverflowUint(uint64(src.Float())) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetUint(uint64(src.Float()))
+	return nil
+}
+
+func mapFloatToFloat(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if dst.OverflowFloat(src.Float()) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetFloat(src.Float())
+	return nil
+}
+
+func mapFloatToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetString(strconv.FormatFloat(src.Float(), 'f', -1, 64))
+	return nil
+}
+
+func mapFloatToByteSliceOrByteArray(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	return numberToBytes(ctx, src, dst)
+}
+
+func mapComplexToComplex(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if dst.OverflowComplex(src.Complex()) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetComplex(src.Complex())
+	return nil
+}
+
+// mapComplexToFloatPair maps a complex number to a two-element float slice
+// or array containing the real and imaginary parts.
+func mapComplexToFloatPair(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	c := src.Complex()
+	if dst.Kind() == reflect.Slice {
+		dst.Set(reflect.MakeSlice(dst.Type(), 2, 2))
+	} else if dst.Len() != 2 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "invalid array length")
+	}
+	if dst.Index(0).OverflowFloat(real(c)) || dst.Index(1).OverflowFloat(imag(c)) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.Index(0).SetFloat(real(c))
+	dst.Index(1).SetFloat(imag(c))
+	return nil
+}
+
+// mapFloatPairToComplex maps a two-element float slice or array containing
+// the real and imaginary parts to a complex number.
+func mapFloatPairToComplex(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	if src.Len() != 2 {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "invalid length")
+	}
+	c := complex(src.Index(0).Float(), src.Index(1).Float())
+	if dst.OverflowComplex(c) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetComplex(c)
+	return nil
+}
+
+func mapStringToBool(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	switch src.String() {
+	case "true":
+		dst.SetBool(true)
+	case "false":
+		dst.SetBool(false)
+	default:
+		return NewInvalidMappingError(src.Type(), dst.Type(), "invalid string value")
+	}
+	return nil
+}
+
+func mapStringToInt(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	v, err := strconv.ParseInt(src.String(), 10, 64)
+	if err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	if dst.OverflowInt(v) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetInt(v)
+	return nil
+}
+
+func mapStringToUint(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	v, err := strconv.ParseUint(src.String(), 10, 64)
+	if err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	if dst.OverflowUint(v) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetUint(v)
+	return nil
+}
+
+func mapStringToFloat(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	v, err := strconv.ParseFloat(src.String(), 64)
+	if err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	if dst.OverflowFloat(v) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetFloat(v)
+	return nil
+}
+
+func mapStringToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetString(src.String())
+	return nil
+}
+
+func mapStringToByteArray(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	b := []byte(src.String())
+	if len(b) != dst.Len() {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "length mismatch")
+	}
+	for i := 0; i < len(b); i++ {
+		dst.Index(i).SetUint(uint64(b[i]))
+	}
+	return nil
+}
+
+func mapStringToByteSlice(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetBytes([]byte(src.String()))
+	return nil
+}
+
+func mapByteSliceToNumber(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	return numberFromBytes(ctx, src.Bytes(), dst)
+}
+
+func mapByteSliceToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	dst.SetString(string(src.Bytes()))
+	return nil
+}
+
+func mapByteArrayToNumber(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	b := make([]byte, src.Len())
+	for i := 0; i < src.Len(); i++ {
+		b[i] = byte(src.Index(i).Uint())
+	}
+	return numberFromBytes(ctx, b, dst)
+}
+
+func mapByteArrayToString(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	b := make([]byte, src.Len())
+	for i := 0; i < src.Len(); i++ {
+		b[i] = byte(src.Index(i).Uint())
+	}
+	dst.SetString(string(b))
+	return nil
+}
+
+func mapSliceToSlice(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	mapper := m.mapperFor(ctx, src.Type().Elem(), dst.Type().Elem())
+	if src.Type() == dst.Type() && dst.CanSet() {
+		if ctx.deepCopy {
+			return copyValue(m, ctx, src, dst)
+		}
+		dst.Set(src)
+		return nil
+	}
+	if src.Len() > dst.Len() {
+		if dst.Cap() >= src.Len() {
+			dst.SetLen(src.Len())
+		} else {
+			dst.Set(reflect.AppendSlice(
+				dst,
+				reflect.MakeSlice(dst.Type(), src.Len()-dst.Len(), src.Len()-dst.Len())),
+			)
+		}
+	}
+	for i := 0; i < src.Len(); i++ {
+		srcVal := m.srcValue(src.Index(i))
+		dstVal := m.dstValue(dst.Index(i))
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx, srcVal, dstVal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mapSliceToArray(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	n, err := arrayLength(ctx, src, dst)
+	if err != nil {
+		return err
+	}
+	srcTyp := src.Type().Elem()
+	dstTyp := dst.Type().Elem()
+	mapper := m.mapperFor(ctx, srcTyp, dstTyp)
+	if srcTyp == dstTyp && dst.CanSet() {
+		if err := copyElems(m, ctx, src, dst); err != nil {
+			return err
+		}
+		zeroArrayTail(dst, n)
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		srcVal := m.srcValue(src.Index(i))
+		dstVal := m.dstValue(dst.Index(i))
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx, m.srcValue(src.Index(i)), m.dstValue(dst.Index(i))); err != nil {
+			return err
+		}
+	}
+	zeroArrayTail(dst, n)
+	return nil
+}
+
+func mapArrayToSlice(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	srcTyp := src.Type().Elem()
+	dstTyp := dst.Type().Elem()
+	mapper := m.mapperFor(ctx, srcTyp, dstTyp)
+	if srcTyp == dstTyp && dst.CanSet() {
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		if err := copyElems(m, ctx, src, dst); err != nil {
+			return err
+		}
+	} else {
+		if src.Len() > dst.Len() {
+			if dst.Cap() >= src.Len() {
+				dst.SetLen(src.Len())
+			} else {
+				dst.Set(reflect.AppendSlice(
+					dst,
+					reflect.MakeSlice(dst.Type(), src.Len()-dst.Len(), src.Len()-dst.Len())),
+				)
+			}
+		}
+		for i := 0; i < src.Len(); i++ {
+			srcVal := m.srcValue(src.Index(i))
+			dstVal := m.dstValue(dst.Index(i))
+			srcValTyp := srcVal.Type()
+			dstValTyp := dstVal.Type()
+			if !mapper.match(srcValTyp, dstValTyp) {
+				mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+			}
+			if err := mapper.mapRefl(m, ctx, srcVal, dstVal); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func mapArrayToArray(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes && src.Type() != dst.Type() {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	n, err := arrayLength(ctx, src, dst)
+	if err != nil {
+		return err
+	}
+	srcTyp := src.Type().Elem()
+	dstTyp := dst.Type().Elem()
+	mapper := m.mapperFor(ctx, srcTyp, dstTyp)
+	if srcTyp == dstTyp && dst.CanSet() {
+		if err := copyElems(m, ctx, src, dst); err != nil {
+			return err
+		}
+		zeroArrayTail(dst, n)
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		srcVal := m.srcValue(src.Index(i))
+		dstVal := m.dstValue(dst.Index(i))
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx, srcVal, dstVal); err != nil {
+			return err
+		}
+	}
+	zeroArrayTail(dst, n)
+	return nil
+}
+
+// unknownKeys returns sorted keys of the src map that are not present in
+// the matched set.
+func unknownKeys(m *Mapper, src reflect.Value, matched map[string]struct{}) []string {
+	var keys []string
+	for _, srcKey := range src.MapKeys() {
+		key := m.srcValue(srcKey)
+		if key.Kind() == reflect.String {
+			if _, ok := matched[key.String()]; ok {
+				continue
+			}
+			keys = append(keys, key.String())
+			continue
+		}
+		keys = append(keys, fmt.Sprint(key.Interface()))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// arrayLength returns the number of elements to be mapped from the src slice
+// or array to the dst array according to the ArrayLengthMode.
+func arrayLength(ctx *Context, src, dst reflect.Value) (int, error) {
+	srcLen, dstLen := src.Len(), dst.Len()
+	switch {
+	case srcLen == dstLen:
+		return srcLen, nil
+	case srcLen > dstLen && ctx.ArrayLengthMode == ArrayLengthTruncate:
+		return dstLen, nil
+	case srcLen < dstLen && ctx.ArrayLengthMode == ArrayLengthZeroPad:
+		return srcLen, nil
+	}
+	return 0, NewInvalidMappingError(
+		src.Type(),
+		dst.Type(),
+		fmt.Sprintf("length mismatch: %d != %d", srcLen, dstLen),
+	)
+}
+
+// copyElems copies elements from src to dst, which must have the same element
+// type. If a deep copy is requested, elements are copied using copyNested,
+// otherwise reflect.Copy is used.
+func copyElems(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if !ctx.deepCopy {
+		reflect.Copy(dst, src)
+		return nil
+	}
+	n := src.Len()
+	if dst.Len() < n {
+		n = dst.Len()
+	}
+	for i := 0; i < n; i++ {
+		if err := copyNested(m, ctx, src.Index(i), dst.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zeroArrayTail sets the elements of the dst array starting from the n-th
+// element to zero values.
+func zeroArrayTail(dst reflect.Value, n int) {
+	for i := n; i < dst.Len(); i++ {
+		dst.Index(i).Set(reflect.Zero(dst.Type().Elem()))
+	}
+}
+
+func mapMapToStruct(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.DottedKeys {
+		src = m.expandDottedKeys(ctx, src, dst.Type())
+	}
+	var (
+		mapper  = &typeMapper{}
+		remain  *structField
+		matched = map[string]struct{}{}
+		names   map[string]struct{}
+		fields  = m.structFields(ctx, dst.Type(), m.parseKey)
+	)
+	for i := range fields {
+		dstFld := fields[i].field
+		tag, opts := fields[i].name, fields[i].opts
+		if opts.has("remain") {
+			// The remain field is populated after all other fields.
+			remain = &fields[i]
+			continue
+		}
+		srcKey := reflect.ValueOf(tag)
+		srcVal := m.srcValue(src.MapIndex(srcKey))
+		if !srcVal.IsValid() && ctx.CaseInsensitiveFields {
+			if names == nil {
+				names = m.fieldNames(ctx, dst.Type(), m.parseKey)
+			}
+			if srcKey = findKeyFold(src, tag, names); srcKey.IsValid() {
+				srcVal = m.srcValue(src.MapIndex(srcKey))
+				tag = m.srcValue(srcKey).String()
+			}
+		}
+		if !srcVal.IsValid() {
+			// If the source map doesn't have a value for the key, skip it.
+			continue
+		}
+		matched[tag] = struct{}{}
+		dstVal := m.dstValue(fieldByIndexAlloc(dst, dstFld.Index))
+		if !dstVal.IsValid() {
+			continue
+		}
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx.withField(dstFld), srcVal, dstVal); err != nil {
+			return err
+		}
+	}
+	if remain == nil && ctx.DisallowUnknownFields {
+		if keys := unknownKeys(m, src, matched); len(keys) > 0 {
+			return NewInvalidMappingError(
+				src.Type(),
+				dst.Type(),
+				fmt.Sprintf("unknown fields: %s", strings.Join(keys, ", ")),
+			)
+		}
+	}
+	if remain != nil {
+		// Collect all keys that do not match any field into the remain field.
+		extra := reflect.MakeMap(src.Type())
+		for _, srcKey := range src.MapKeys() {
+			if key := m.srcValue(srcKey); key.Kind() == reflect.String {
+				if _, ok := matched[key.String()]; ok {
+					continue
+				}
+			}
+			extra.SetMapIndex(srcKey, src.MapIndex(srcKey))
+		}
+		if extra.Len() > 0 {
+			dstVal := fieldByIndexAlloc(dst, remain.field.Index)
+			if !dstVal.IsValid() {
+				return m.postMap(ctx, dst)
+			}
+			if err := m.MapReflContext(ctx.withField(remain.field), extra, dstVal.Addr()); err != nil {
+				return err
+			}
+		}
+	}
+	return m.postMap(ctx, dst)
+}
+
+func mapMapToMap(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	var (
+		srcKeyTyp  = src.Type().Key()
+		dstKeyTyp  = dst.Type().Key()
+		srcElemTyp = src.Type().Elem()
+		dstElemTyp = dst.Type().Elem()
+		keyMapper  = m.mapperFor(ctx, srcKeyTyp, dstKeyTyp)
+		elemMapper = m.mapperFor(ctx, srcElemTyp, dstElemTyp)
+		sameKeys   = srcKeyTyp == dstKeyTyp
+	)
+	if dst.IsNil() && dst.CanSet() {
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+	}
+	for _, srcKey := range src.MapKeys() {
+		dstKey := srcKey
+		if !sameKeys {
+			dstKey = reflect.New(dstKeyTyp).Elem()
+			if err := keyMapper.mapRefl(m, ctx, m.srcValue(srcKey), m.dstValue(dstKey)); err != nil {
+				return NewInvalidMappingError(srcKey.Type(), dstKeyTyp, "unable to map key")
+			}
+		}
+		srcVal := m.srcValue(src.MapIndex(srcKey))
+		dstVal := m.dstValue(dst.MapIndex(dstKey))
+		if dstVal.IsValid() {
+			// If the destination map already has a value for the key.
+			srcValTyp := srcVal.Type()
+			dstValTyp := dstVal.Type()
+			if !elemMapper.match(srcValTyp, dstValTyp) {
+				elemMapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+			}
+			if err := elemMapper.mapRefl(m, ctx, srcVal, dstVal); err != nil {
+				return err
+			}
+		} else {
+			// If the destination map doesn't have a value for the key.
+			newVal := reflect.New(dstElemTyp).Elem()
+			dstVal := m.dstValue(newVal)
+			srcValTyp := srcVal.Type()
+			dstValTyp := dstVal.Type()
+			if !dstVal.IsValid() {
+				continue
+			}
+			if !elemMapper.match(srcValTyp, dstValTyp) {
+				elemMapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+			}
+			if err := elemMapper.mapRefl(m, ctx, srcVal, dstVal); err != nil {
+				return err
+			}
+			dst.SetMapIndex(dstKey, newVal)
+		}
+	}
+	return nil
+}
+
+func mapStructsOfSameType(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	var (
+		mapper = &typeMapper{}
+		srcTyp = src.Type()
+		srcNum = src.NumField()
+	)
+	for i := 0; i < srcNum; i++ {
+		srcFld := srcTyp.Field(i)
+		if !srcFld.IsExported() {
+			continue
+		}
+		if _, _, skip := m.parseTag(ctx, srcFld); skip {
+			// If the tag is "-", skip it.
+			continue
+		}
+		srcVal := m.srcValue(src.Field(i))
+		if !srcVal.IsValid() {
+			// If the source field is a nil pointer or interface, the
+			// destination field is set to a zero value.
+			dst.Field(i).Set(reflect.Zero(srcFld.Type))
+			continue
+		}
+		dstVal := m.dstValue(dst.Field(i))
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx.withField(srcFld), srcVal, dstVal); err != nil {
+			return err
+		}
+	}
+	return m.postMap(ctx, dst)
+}
+
+func mapStructsOfDifferentTypes(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	var (
+		mapper = &typeMapper{}
+		srcTyp = src.Type()
+		dstTyp = dst.Type()
+		valMap = map[string]reflect.Value{}
+		names  map[string]struct{}
+	)
+	// Map the source struct to a map of values.
+	for _, f := range m.structFields(ctx, srcTyp, m.parseTag) {
+		if srcVal := fieldByIndex(src, f.field.Index); srcVal.IsValid() {
+			valMap[f.name] = srcVal
+		}
+	}
+	// Map the values to the destination struct.
+	for _, f := range m.structFields(ctx, dstTyp, m.parseTag) {
+		dstFld, tag := f.field, f.name
+		val, ok := valMap[tag]
+		if !ok && ctx.CaseInsensitiveFields {
+			if names == nil {
+				names = m.fieldNames(ctx, dstTyp, m.parseTag)
+			}
+			if key := findKeyFold(reflect.ValueOf(valMap), tag, names); key.IsValid() {
+				val, ok = valMap[key.String()]
+			}
+		}
+		if !ok {
+			// If the source struct doesn't have a value for the key, skip it.
+			continue
+		}
+		srcVal := m.srcValue(val)
+		dstVal := m.dstValue(fieldByIndexAlloc(dst, dstFld.Index))
+		if !dstVal.IsValid() {
+			continue
+		}
+		srcValTyp := srcVal.Type()
+		dstValTyp := dstVal.Type()
+		if !mapper.match(srcValTyp, dstValTyp) {
+			mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+		}
+		if err := mapper.mapRefl(m, ctx.withField(dstFld), srcVal, dstVal); err != nil {
+			return err
+		}
+	}
+	return m.postMap(ctx, dst)
+}
+
+func mapStructToMap(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	var (
+		mapper     = &typeMapper{}
+		dstElemTyp = dst.Type().Elem()
+		names      map[string]struct{}
+		fields     = m.structFields(ctx, src.Type(), m.parseKey)
+	)
+	for _, f := range fields {
+		srcFldVal := fieldByIndex(src, f.field.Index)
+		if f.opts.has("remain") && srcFldVal.IsValid() && !srcFldVal.IsZero() {
+			// The content of the remain field is mapped directly to the
+			// destination map. It is done before other fields, so they
+			// take precedence in case of a key collision.
+			if err := m.MapReflContext(ctx.withField(f.field), srcFldVal, dst); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range fields {
+		srcFld, tag, opts := f.field, f.name, f.opts
+		srcFldVal := fieldByIndex(src, srcFld.Index)
+		if !srcFldVal.IsValid() || opts.has("remain") {
+			// If the field is inside a nil embedded pointer or it is the
+			// remain field, skip it.
+			continue
+		}
+		if opts.has("omitempty") && isEmptyValue(srcFldVal) {
+			continue
+		}
+		dstKey := reflect.ValueOf(tag)
+		if ctx.CaseInsensitiveFields && !dst.MapIndex(dstKey).IsValid() {
+			if names == nil {
+				names = m.fieldNames(ctx, src.Type(), m.parseKey)
+			}
+			if key := findKeyFold(dst, tag, names); key.IsValid() {
+				dstKey = key
+			}
+		}
+		srcVal := m.srcValue(srcFldVal)
+		dstVal := m.dstValue(dst.MapIndex(dstKey))
+		if dstVal.IsValid() {
+			// If the destination map already has a value for the key.
+			srcValTyp := srcVal.Type()
+			dstValTyp := dstVal.Type()
+			if !mapper.match(srcValTyp, dstValTyp) {
+				mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+			}
+			if err := mapper.mapRefl(m, ctx.withField(srcFld), srcVal, dstVal); err != nil {
+				return err
+			}
+		} else {
+			// If the destination map doesn't have a value for the key.
+			newVal := reflect.New(dstElemTyp).Elem()
+			dstVal := m.dstValue(newVal)
+			srcValTyp := srcVal.Type()
+			dstValTyp := dstVal.Type()
+			if !dstVal.IsValid() {
+				continue
+			}
+			if !mapper.match(srcValTyp, dstValTyp) {
+				mapper = m.mapperFor(ctx, srcValTyp, dstValTyp)
+			}
+			if err := mapper.mapRefl(m, ctx.withField(srcFld), srcVal, dstVal); err != nil {
+				return err
+			}
+			dst.SetMapIndex(dstKey, newVal)
+		}
+	}
+	return nil
+}
+
+// numberToBytes converts an int or uint to a byte slice using binary.Write.
+func numberToBytes(ctx *Context, src, dst reflect.Value) error {
+	// binary.Write does not work with Int and Uint types, so we need to
+	// convert them to int64 and uint64. To make mapped values compatible
+	// between 32 and 64-bit architectures, we always use int64 and uint64.
+	switch src.Kind() {
+	case reflect.Int:
+		src = reflect.ValueOf(src.Int())
+	case reflect.Uint:
+		src = reflect.ValueOf(src.Uint())
+	}
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, ctx.ByteOrder, src.Interface()); err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	switch dst.Kind() {
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "")
+		}
+		dst.SetBytes(buf.Bytes())
+	case reflect.Array:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "")
+		}
+		if dst.Len() != buf.Len() {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "invalid array length")
+		}
+		reflect.Copy(dst, reflect.ValueOf(buf.Bytes()))
+	default:
+		return NewInvalidMappingError(src.Type(), dst.Type(), "")
+	}
+	return nil
+}
+
+// numberFromBytes converts a byte slice to an int ot uint using binary.Read.
+func numberFromBytes(ctx *Context, src []byte, dst reflect.Value) error {
+	if len(src) != int(dst.Type().Size()) {
+		return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), "invalid byte slice length")
+	}
+	switch dst.Kind() {
+	case reflect.Int:
+		var v int64
+		if err := binary.Read(bytes.NewReader(src), ctx.ByteOrder, &v); err != nil {
+			return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), err.Error())
+		}
+		if dst.OverflowInt(v) {
+			return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), "overflow")
+		}
+		dst.SetInt(v)
+	case reflect.Uint:
+		var v uint64
+		if err := binary.Read(bytes.NewReader(src), ctx.ByteOrder, &v); err != nil {
+			return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), err.Error())
+		}
+		if dst.OverflowUint(v) {
+			return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), "overflow")
+		}
+		dst.SetUint(v)
+	default:
+		if err := binary.Read(bytes.NewBuffer(src), ctx.ByteOrder, dst.Addr().Interface()); err != nil {
+			return NewInvalidMappingError(reflect.TypeOf(src), dst.Type(), err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package anymapper
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// DeepCopy returns a deep copy of src.
+//
+// It is shorthand for Default.DeepCopy(src).
+func DeepCopy(src any) (any, error) {
+	return Default.DeepCopy(src)
+}
+
+// DeepCopy returns a deep copy of src. It allocates a new value of the same
+// type as src and maps src into it. Pointers, slices and maps are newly
+// allocated, so the copy does not share memory with the source.
+//
+// Because the copy is created using the mapper, it follows the same rules
+// as any other struct ⇒ struct mapping: unexported fields and fields with
+// the "-" tag are not copied. Cyclic references, also the ones reachable
+// through slices, maps and interfaces, result in an error.
+func (m *Mapper) DeepCopy(src any) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	ctx := *m.Context
+	ctx.deepCopy = true
+	srcVal := reflect.ValueOf(src)
+	dstVal := reflect.New(srcVal.Type())
+	if srcVal.Kind() == reflect.Pointer && srcVal.IsNil() {
+		return dstVal.Elem().Interface(), nil
+	}
+	if err := m.MapReflContext(&ctx, srcVal, dstVal); err != nil {
+		return nil, err
+	}
+	return dstVal.Elem().Interface(), nil
+}
+
+// copyValue copies src to dst, allocating new pointers, slices and maps.
+// Exported struct fields are copied recursively, unexported fields and fields
+// skipped by the struct tag are left zero. Structs without exported fields,
+// such as time.Time, are copied as a whole.
+//
+// The src value itself is expected to be already tracked by the caller,
+// nested values are tracked by copyNested.
+func copyValue(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		ptr := reflect.New(src.Type().Elem())
+		if err := copyNested(m, ctx, src.Elem(), ptr.Elem()); err != nil {
+			return err
+		}
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		val := reflect.New(src.Elem().Type()).Elem()
+		if err := copyNested(m, ctx, src.Elem(), val); err != nil {
+			return err
+		}
+		dst.Set(val)
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := copyNested(m, ctx, src.Index(i), slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := copyNested(m, ctx, src.Index(i), dst.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		mp := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for _, key := range src.MapKeys() {
+			val := reflect.New(src.Type().Elem()).Elem()
+			if err := copyNested(m, ctx, src.MapIndex(key), val); err != nil {
+				return err
+			}
+			mp.SetMapIndex(key, val)
+		}
+		dst.Set(mp)
+	case reflect.Struct:
+		if copyBigValue(src, dst) {
+			return nil
+		}
+		if !hasExportedFields(src.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		dst.Set(reflect.Zero(dst.Type()))
+		for i := 0; i < src.NumField(); i++ {
+			f := src.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if _, _, skip := m.parseTag(ctx, f); skip {
+				continue
+			}
+			if err := copyNested(m, ctx, src.Field(i), dst.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+	return nil
+}
+
+// copyNested copies a value nested in another value using copyValue. It
+// tracks the visited values in the same way as the mapper does, so cyclic
+// references return an error instead of recursing infinitely.
+func copyNested(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if key, ok := visitKeyOf(src); ok && ctx.visited != nil {
+		if _, ok := ctx.visited[key]; ok {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "cyclic reference detected")
+		}
+		ctx.visited[key] = struct{}{}
+		defer delete(ctx.visited, key)
+	}
+	return copyValue(m, ctx, src, dst)
+}
+
+// hasExportedFields returns true if the struct type has at least one
+// exported field.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// copyBigValue copies big.Int, big.Float and big.Rat values using their Set
+// methods, because they hold their data in unexported slices.
+func copyBigValue(src, dst reflect.Value) bool {
+	switch src.Type() {
+	case bigIntTy, bigFloatTy, bigRatTy:
+	default:
+		return false
+	}
+	// The src value may not be addressable, so it is copied first to be able
+	// to call methods with pointer receivers.
+	tmp := reflect.New(src.Type())
+	tmp.Elem().Set(src)
+	switch x := tmp.Interface().(type) {
+	case *big.Int:
+		dst.Set(reflect.ValueOf(*new(big.Int).Set(x)))
+	case *big.Float:
+		dst.Set(reflect.ValueOf(*new(big.Float).Copy(x)))
+	case *big.Rat:
+		dst.Set(reflect.ValueOf(*new(big.Rat).Set(x)))
+	}
+	return true
+}
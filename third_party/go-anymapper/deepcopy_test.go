@@ -0,0 +1,117 @@
+package anymapper
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopy(t *testing.T) {
+	type Inner struct {
+		Val *big.Int
+	}
+	type Str struct {
+		Ptr   *Inner
+		Slice []int
+		Map   map[string]*Inner
+		Any   any
+		Array [2]*int
+		Ptrs  []*int
+	}
+	one := 1
+	src := &Str{
+		Ptr:   &Inner{Val: big.NewInt(1)},
+		Slice: []int{1, 2},
+		Map:   map[string]*Inner{"a": {Val: big.NewInt(2)}},
+		Any:   []string{"a"},
+		Array: [2]*int{&one, nil},
+		Ptrs:  []*int{&one, nil},
+	}
+	cpyAny, err := DeepCopy(src)
+	require.NoError(t, err)
+	cpy := cpyAny.(*Str)
+	assert.Equal(t, src, cpy)
+
+	// The copy must not share memory with the source:
+	assert.NotSame(t, src, cpy)
+	assert.NotSame(t, src.Ptr, cpy.Ptr)
+	assert.NotSame(t, src.Ptr.Val, cpy.Ptr.Val)
+	assert.NotSame(t, src.Map["a"], cpy.Map["a"])
+	assert.NotSame(t, src.Array[0], cpy.Array[0])
+	assert.NotSame(t, src.Ptrs[0], cpy.Ptrs[0])
+	cpy.Slice[0] = 10
+	cpy.Any.([]string)[0] = "b"
+	cpy.Ptr.Val.SetInt64(10)
+	assert.Equal(t, 1, src.Slice[0])
+	assert.Equal(t, "a", src.Any.([]string)[0])
+	assert.Equal(t, int64(1), src.Ptr.Val.Int64())
+}
+
+func TestDeepCopy_Nil(t *testing.T) {
+	cpy, err := DeepCopy(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cpy)
+
+	var ptr *big.Int
+	cpy, err = DeepCopy(ptr)
+	require.NoError(t, err)
+	assert.Equal(t, (*big.Int)(nil), cpy)
+}
+
+func TestDeepCopy_Cyclic(t *testing.T) {
+	type Node struct {
+		Next     *Node
+		Children []*Node
+		Map      map[string]*Node
+		Any      any
+	}
+	tests := []struct {
+		name string
+		src  func() any
+	}{
+		{name: "pointer", src: func() any { n := &Node{}; n.Next = n; return n }},
+		{name: "slice", src: func() any { n := &Node{}; n.Next = n; return []*Node{n} }},
+		{name: "slice-field", src: func() any { n := &Node{}; n.Children = []*Node{n}; return n }},
+		{name: "map-field", src: func() any { n := &Node{}; n.Map = map[string]*Node{"a": n}; return n }},
+		{name: "map", src: func() any { n := &Node{}; n.Next = n; return map[string]*Node{"a": n} }},
+		{name: "interface", src: func() any { n := &Node{}; n.Any = n; return []any{n} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DeepCopy(tt.src())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "cyclic reference detected")
+		})
+	}
+
+	// The same value referenced twice is not a cycle.
+	n := &Node{}
+	_, err := DeepCopy([]*Node{n, n})
+	require.NoError(t, err)
+}
+
+func TestDeepCopy_Fields(t *testing.T) {
+	type Inner struct {
+		Exported   int
+		unexported *int
+		Skipped    int `map:"-"`
+		Time       time.Time
+	}
+	one := 1
+	now := time.Now()
+	src := []Inner{{Exported: 1, unexported: &one, Skipped: 1, Time: now}}
+	cpyAny, err := DeepCopy(src)
+	require.NoError(t, err)
+	cpy := cpyAny.([]Inner)
+
+	// Unexported fields and fields with the "-" tag are not copied, but
+	// structs without exported fields are copied as a whole.
+	require.Len(t, cpy, 1)
+	assert.Equal(t, 1, cpy[0].Exported)
+	assert.Nil(t, cpy[0].unexported)
+	assert.Equal(t, 0, cpy[0].Skipped)
+	assert.True(t, now.Equal(cpy[0].Time))
+}
@@ -0,0 +1,82 @@
+package anymapper
+
+import (
+	"reflect"
+	"strings"
+)
+
+var anyMapTy = reflect.TypeOf(map[string]any{})
+
+// expandDottedKeys converts keys containing dots into nested maps, so that
+// the key "a.b" is mapped to the field "b" of the nested struct in the
+// field "a". Keys that exactly match a field name are not split.
+//
+// If there is both a dotted key and a nested map under its prefix, entries
+// of the nested map are merged with the dotted keys. Dotted keys take
+// precedence over the nested map entries with the same name. If the value
+// under the prefix is not a map, it is replaced.
+//
+// If no key needs to be split, the source map is returned unchanged.
+func (m *Mapper) expandDottedKeys(ctx *Context, src reflect.Value, dst reflect.Type) reflect.Value {
+	var (
+		names  map[string]struct{}
+		nested map[string]reflect.Value
+	)
+	for _, srcKey := range src.MapKeys() {
+		key := m.srcValue(srcKey)
+		if key.Kind() != reflect.String || !strings.Contains(key.String(), ".") {
+			continue
+		}
+		if names == nil {
+			names = m.fieldNames(ctx, dst, m.parseKey)
+		}
+		if _, ok := names[key.String()]; ok {
+			continue
+		}
+		prefix, rest, _ := strings.Cut(key.String(), ".")
+		if nested == nil {
+			nested = map[string]reflect.Value{}
+		}
+		if _, ok := nested[prefix]; !ok {
+			nested[prefix] = reflect.MakeMap(anyMapTy)
+		}
+		nested[prefix].SetMapIndex(reflect.ValueOf(rest), src.MapIndex(srcKey))
+	}
+	if nested == nil {
+		return src
+	}
+	exp := reflect.MakeMapWithSize(anyMapTy, src.Len())
+	for _, srcKey := range src.MapKeys() {
+		key := m.srcValue(srcKey)
+		if key.Kind() != reflect.String {
+			continue
+		}
+		if sub, ok := nested[key.String()]; ok {
+			// Merge the nested map with the dotted keys. Dotted keys are
+			// already in the sub map, so they take precedence.
+			if val := m.srcValue(src.MapIndex(srcKey)); val.Kind() == reflect.Map {
+				for _, k := range val.MapKeys() {
+					subKey := m.srcValue(k)
+					if subKey.Kind() != reflect.String {
+						continue
+					}
+					if sub.MapIndex(reflect.ValueOf(subKey.String())).IsValid() {
+						continue
+					}
+					sub.SetMapIndex(reflect.ValueOf(subKey.String()), val.MapIndex(k))
+				}
+			}
+			continue
+		}
+		if _, _, dotted := strings.Cut(key.String(), "."); dotted {
+			if _, ok := names[key.String()]; !ok {
+				continue
+			}
+		}
+		exp.SetMapIndex(reflect.ValueOf(key.String()), src.MapIndex(srcKey))
+	}
+	for prefix, sub := range nested {
+		exp.SetMapIndex(reflect.ValueOf(prefix), sub)
+	}
+	return exp
+}
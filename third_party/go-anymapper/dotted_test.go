@@ -0,0 +1,46 @@
+package anymapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDottedKeys(t *testing.T) {
+	type DB struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Name string
+		DB   DB
+		Dot  string `map:"a.b"`
+	}
+	m := Default.Copy()
+	m.Context.DottedKeys = true
+	t.Run("nested", func(t *testing.T) {
+		var dst Config
+		require.NoError(t, m.Map(map[string]any{"Name": "x", "DB.Host": "localhost", "DB.Port": 5432}, &dst))
+		assert.Equal(t, Config{Name: "x", DB: DB{Host: "localhost", Port: 5432}}, dst)
+	})
+	t.Run("field-name-with-dot", func(t *testing.T) {
+		var dst Config
+		require.NoError(t, m.Map(map[string]any{"a.b": "x"}, &dst))
+		assert.Equal(t, Config{Dot: "x"}, dst)
+	})
+	t.Run("merge-with-nested-map", func(t *testing.T) {
+		var dst Config
+		src := map[string]any{
+			"DB":      map[string]any{"Host": "nested", "Port": 1},
+			"DB.Host": "dotted",
+		}
+		require.NoError(t, m.Map(src, &dst))
+		assert.Equal(t, Config{DB: DB{Host: "dotted", Port: 1}}, dst)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		var dst Config
+		require.NoError(t, Map(map[string]any{"DB.Host": "localhost"}, &dst))
+		assert.Equal(t, Config{}, dst)
+	})
+}
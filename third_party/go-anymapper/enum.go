@@ -0,0 +1,182 @@
+package anymapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterEnum registers an enum type. The typ must be a named integer type,
+// and the names map must contain all valid enum names and their values.
+//
+// After registration, the mapper will map the enum type to and from its
+// string name and its underlying integer value, depending on the other type:
+//
+//   - string ⇒ enum: the name is looked up in the names map.
+//   - intX, uintX ⇒ enum: the value must be one of the values in the names map.
+//   - enum ⇒ string: the name of the value is used.
+//   - enum ⇒ intX, uintX, floatX: the underlying integer value is used.
+//
+// Unknown names and values result in an error. To map them to a default
+// enum value instead, use RegisterEnumWithFallback.
+func (m *Mapper) RegisterEnum(typ reflect.Type, names map[string]int64) {
+	m.registerEnum(typ, names, nil)
+}
+
+// RegisterEnumWithFallback works like RegisterEnum, but unknown names and
+// values are mapped to the fallback enum instead of returning an error.
+// The fallback must be one of the names in the names map.
+func (m *Mapper) RegisterEnumWithFallback(typ reflect.Type, names map[string]int64, fallback string) {
+	if _, ok := names[fallback]; !ok {
+		panic(fmt.Sprintf("mapper: fallback %q is not a valid name of enum %v", fallback, typ))
+	}
+	m.registerEnum(typ, names, &fallback)
+}
+
+func (m *Mapper) registerEnum(typ reflect.Type, names map[string]int64, fallback *string) {
+	if !isIntKind(typ.Kind()) && !isUintKind(typ.Kind()) {
+		panic(fmt.Sprintf("mapper: enum type %v must be an integer type", typ))
+	}
+	e := &enumTable{
+		typ:    typ,
+		names:  make(map[int64]string, len(names)),
+		values: make(map[string]int64, len(names)),
+	}
+	for n, v := range names {
+		e.names[v] = n
+		e.values[n] = v
+	}
+	if fallback != nil {
+		e.hasFallback = true
+		e.fallbackName = *fallback
+		e.fallbackValue = names[*fallback]
+	}
+	if m.Mappers == nil {
+		m.Mappers = make(map[reflect.Type]MapFuncProvider)
+	}
+	m.Mappers[typ] = e.typeMapper
+	m.resetCache()
+}
+
+// enumTable holds the names and values of a registered enum type.
+type enumTable struct {
+	typ    reflect.Type
+	names  map[int64]string
+	values map[string]int64
+
+	// Fallback used for unknown names and values, if hasFallback is set.
+	hasFallback   bool
+	fallbackName  string
+	fallbackValue int64
+}
+
+func (e *enumTable) typeMapper(_ *Mapper, src, dst reflect.Type) MapFunc {
+	if src == dst {
+		return mapDirect
+	}
+	switch {
+	case src == e.typ:
+		switch {
+		case dst == anyTy:
+			return mapAny
+		case dst.Kind() == reflect.String:
+			return e.mapEnumToString
+		case isIntKind(dst.Kind()) || isUintKind(dst.Kind()) || isFloatKind(dst.Kind()):
+			return e.mapEnumToNumber
+		}
+	case dst == e.typ:
+		switch {
+		case src.Kind() == reflect.String:
+			return e.mapStringToEnum
+		case isIntKind(src.Kind()) || isUintKind(src.Kind()):
+			return e.mapNumberToEnum
+		}
+	}
+	return nil
+}
+
+func (e *enumTable) mapEnumToString(_ *Mapper, _ *Context, src, dst reflect.Value) error {
+	v := enumValue(src)
+	n, ok := e.names[v]
+	if !ok && e.hasFallback {
+		n, ok = e.fallbackName, true
+	}
+	if !ok {
+		return NewInvalidMappingError(src.Type(), dst.Type(), fmt.Sprintf("unknown enum value %d", v))
+	}
+	dst.SetString(n)
+	return nil
+}
+
+func (e *enumTable) mapEnumToNumber(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	v := enumValue(src)
+	if _, ok := e.names[v]; !ok {
+		if !e.hasFallback {
+			return NewInvalidMappingError(src.Type(), dst.Type(), fmt.Sprintf("unknown enum value %d", v))
+		}
+		v = e.fallbackValue
+	}
+	return m.MapReflContext(ctx.WithStrictTypes(false), reflect.ValueOf(v), dst)
+}
+
+func (e *enumTable) mapStringToEnum(_ *Mapper, _ *Context, src, dst reflect.Value) error {
+	v, ok := e.values[src.String()]
+	if !ok && e.hasFallback {
+		v, ok = e.fallbackValue, true
+	}
+	if !ok {
+		return NewInvalidMappingError(src.Type(), dst.Type(), fmt.Sprintf("unknown enum name %q", src.String()))
+	}
+	return setEnumValue(src, dst, v)
+}
+
+func (e *enumTable) mapNumberToEnum(_ *Mapper, _ *Context, src, dst reflect.Value) error {
+	var v int64
+	if isUintKind(src.Kind()) {
+		v = int64(src.Uint())
+	} else {
+		v = src.Int()
+	}
+	if _, ok := e.names[v]; !ok {
+		if !e.hasFallback {
+			return NewInvalidMappingError(src.Type(), dst.Type(), fmt.Sprintf("unknown enum value %d", v))
+		}
+		v = e.fallbackValue
+	}
+	return setEnumValue(src, dst, v)
+}
+
+// enumValue returns the integer value of an enum.
+func enumValue(v reflect.Value) int64 {
+	if isUintKind(v.Kind()) {
+		return int64(v.Uint())
+	}
+	return v.Int()
+}
+
+// setEnumValue sets the integer value of an enum.
+func setEnumValue(src, dst reflect.Value, v int64) error {
+	if isUintKind(dst.Kind()) {
+		if v < 0 || dst.OverflowUint(uint64(v)) {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+		}
+		dst.SetUint(uint64(v))
+		return nil
+	}
+	if dst.OverflowInt(v) {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "overflow")
+	}
+	dst.SetInt(v)
+	return nil
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uint64
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
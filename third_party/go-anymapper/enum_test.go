@@ -0,0 +1,112 @@
+package anymapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnum uint8
+
+const (
+	testEnumFoo testEnum = iota + 1
+	testEnumBar
+)
+
+var testEnumNames = map[string]int64{
+	"foo": int64(testEnumFoo),
+	"bar": int64(testEnumBar),
+}
+
+func TestRegisterEnum(t *testing.T) {
+	m := Default.Copy()
+	m.RegisterEnum(reflect.TypeOf(testEnum(0)), testEnumNames)
+
+	t.Run("string-to-enum", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map("bar", &dst))
+		assert.Equal(t, testEnumBar, dst)
+	})
+	t.Run("int-to-enum", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map(1, &dst))
+		assert.Equal(t, testEnumFoo, dst)
+	})
+	t.Run("enum-to-string", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(testEnumBar, &dst))
+		assert.Equal(t, "bar", dst)
+	})
+	t.Run("enum-to-int", func(t *testing.T) {
+		var dst int
+		require.NoError(t, m.Map(testEnumBar, &dst))
+		assert.Equal(t, 2, dst)
+	})
+	t.Run("enum-to-enum", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map(testEnumBar, &dst))
+		assert.Equal(t, testEnumBar, dst)
+	})
+	t.Run("unknown-name", func(t *testing.T) {
+		var dst testEnum
+		assert.Error(t, m.Map("baz", &dst))
+	})
+	t.Run("unknown-value", func(t *testing.T) {
+		var dst testEnum
+		assert.Error(t, m.Map(3, &dst))
+	})
+	t.Run("unknown-enum-value", func(t *testing.T) {
+		var dst string
+		assert.Error(t, m.Map(testEnum(3), &dst))
+	})
+	t.Run("struct-field", func(t *testing.T) {
+		var dst struct {
+			Enum testEnum `map:"enum"`
+		}
+		require.NoError(t, m.Map(map[string]any{"enum": "foo"}, &dst))
+		assert.Equal(t, testEnumFoo, dst.Enum)
+	})
+	t.Run("not-integer-type", func(t *testing.T) {
+		assert.Panics(t, func() {
+			m.RegisterEnum(reflect.TypeOf(""), testEnumNames)
+		})
+	})
+}
+
+func TestRegisterEnumWithFallback(t *testing.T) {
+	m := Default.Copy()
+	m.RegisterEnumWithFallback(reflect.TypeOf(testEnum(0)), testEnumNames, "foo")
+
+	t.Run("known-name", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map("bar", &dst))
+		assert.Equal(t, testEnumBar, dst)
+	})
+	t.Run("unknown-name", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map("baz", &dst))
+		assert.Equal(t, testEnumFoo, dst)
+	})
+	t.Run("unknown-value", func(t *testing.T) {
+		var dst testEnum
+		require.NoError(t, m.Map(3, &dst))
+		assert.Equal(t, testEnumFoo, dst)
+	})
+	t.Run("unknown-enum-value-to-string", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(testEnum(3), &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("unknown-enum-value-to-int", func(t *testing.T) {
+		var dst int
+		require.NoError(t, m.Map(testEnum(3), &dst))
+		assert.Equal(t, int(testEnumFoo), dst)
+	})
+	t.Run("invalid-fallback", func(t *testing.T) {
+		assert.Panics(t, func() {
+			m.RegisterEnumWithFallback(reflect.TypeOf(testEnum(0)), testEnumNames, "baz")
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package anymapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterFieldMapping registers an explicit field mapping between two
+// struct types. The spec maps source field names to destination field names.
+// Go field names are used, tags are ignored.
+//
+// When a value of the src type is mapped to a value of the dst type, fields
+// listed in the spec are mapped to their corresponding destination fields
+// instead of using the name and tag matching.
+//
+// If fallback is true, fields that are not present in the spec are mapped
+// using the usual name and tag matching. Otherwise, they are skipped.
+//
+// The method panics if any of the types is not a struct or if the spec
+// contains fields that do not exist or are not exported.
+func (m *Mapper) RegisterFieldMapping(src, dst reflect.Type, spec map[string]string, fallback bool) {
+	if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("mapper: field mapping types %v and %v must be structs", src, dst))
+	}
+	f := &fieldMapping{
+		fallback: fallback,
+		srcUsed:  make(map[int]struct{}, len(spec)),
+		dstUsed:  make(map[int]struct{}, len(spec)),
+	}
+	for srcName, dstName := range spec {
+		srcFld, ok := src.FieldByName(srcName)
+		if !ok || !srcFld.IsExported() || len(srcFld.Index) != 1 {
+			panic(fmt.Sprintf("mapper: field %s is not an exported field of %v", srcName, src))
+		}
+		dstFld, ok := dst.FieldByName(dstName)
+		if !ok || !dstFld.IsExported() || len(dstFld.Index) != 1 {
+			panic(fmt.Sprintf("mapper: field %s is not an exported field of %v", dstName, dst))
+		}
+		if _, ok := f.dstUsed[dstFld.Index[0]]; ok {
+			panic(fmt.Sprintf("mapper: field %s of %v is mapped more than once", dstName, dst))
+		}
+		f.pairs = append(f.pairs, [2]int{srcFld.Index[0], dstFld.Index[0]})
+		f.srcUsed[srcFld.Index[0]] = struct{}{}
+		f.dstUsed[dstFld.Index[0]] = struct{}{}
+	}
+	if m.fieldMappings == nil {
+		m.fieldMappings = make(map[typePair]MapFunc)
+	}
+	m.fieldMappings[typePair{src: src, dst: dst}] = f.mapStructs
+	m.resetCache()
+}
+
+// fieldMapping holds an explicit field mapping between two struct types.
+type fieldMapping struct {
+	pairs    [][2]int         // source and destination field indices
+	srcUsed  map[int]struct{} // source fields present in the spec
+	dstUsed  map[int]struct{} // destination fields present in the spec
+	fallback bool             // map remaining fields by name
+}
+
+func (f *fieldMapping) mapStructs(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	for _, p := range f.pairs {
+		if err := mapField(m, ctx.withField(dst.Type().Field(p[1])), src.Field(p[0]), dst.Field(p[1])); err != nil {
+			return err
+		}
+	}
+	if !f.fallback {
+		return m.postMap(ctx, dst)
+	}
+	valMap := map[string]reflect.Value{}
+	for i := 0; i < src.NumField(); i++ {
+		srcFld := src.Type().Field(i)
+		if _, ok := f.srcUsed[i]; ok || !srcFld.IsExported() {
+			continue
+		}
+		if tag, _, skip := m.parseTag(ctx, srcFld); !skip {
+			valMap[tag] = src.Field(i)
+		}
+	}
+	for i := 0; i < dst.NumField(); i++ {
+		dstFld := dst.Type().Field(i)
+		if _, ok := f.dstUsed[i]; ok || !dstFld.IsExported() {
+			continue
+		}
+		tag, _, skip := m.parseTag(ctx, dstFld)
+		if skip {
+			continue
+		}
+		val, ok := valMap[tag]
+		if !ok {
+			continue
+		}
+		if err := mapField(m, ctx.withField(dstFld), val, dst.Field(i)); err != nil {
+			return err
+		}
+	}
+	return m.postMap(ctx, dst)
+}
+
+// mapField maps a single struct field.
+func mapField(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	srcVal := m.srcValue(src)
+	dstVal := m.dstValue(dst)
+	return m.mapperFor(ctx, srcVal.Type(), dstVal.Type()).mapRefl(m, ctx, srcVal, dstVal)
+}
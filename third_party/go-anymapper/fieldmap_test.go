@@ -0,0 +1,75 @@
+package anymapper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterFieldMapping(t *testing.T) {
+	type UserDTO struct {
+		FullName string
+		Mail     string
+		Age      int
+	}
+	type User struct {
+		Name  string
+		Email string
+		Age   int64
+	}
+	src := UserDTO{FullName: "foo", Mail: "foo@example.com", Age: 42}
+
+	t.Run("with-fallback", func(t *testing.T) {
+		m := Default.Copy()
+		m.RegisterFieldMapping(
+			reflect.TypeOf(UserDTO{}),
+			reflect.TypeOf(User{}),
+			map[string]string{"FullName": "Name", "Mail": "Email"},
+			true,
+		)
+		var dst User
+		require.NoError(t, m.Map(src, &dst))
+		assert.Equal(t, User{Name: "foo", Email: "foo@example.com", Age: 42}, dst)
+	})
+	t.Run("without-fallback", func(t *testing.T) {
+		m := Default.Copy()
+		m.RegisterFieldMapping(
+			reflect.TypeOf(UserDTO{}),
+			reflect.TypeOf(User{}),
+			map[string]string{"FullName": "Name"},
+			false,
+		)
+		var dst User
+		require.NoError(t, m.Map(src, &dst))
+		assert.Equal(t, User{Name: "foo"}, dst)
+	})
+	t.Run("opposite-direction-not-affected", func(t *testing.T) {
+		m := Default.Copy()
+		m.RegisterFieldMapping(
+			reflect.TypeOf(UserDTO{}),
+			reflect.TypeOf(User{}),
+			map[string]string{"FullName": "Name"},
+			false,
+		)
+		var dst UserDTO
+		require.NoError(t, m.Map(User{Name: "foo", Age: 1}, &dst))
+		assert.Equal(t, UserDTO{Age: 1}, dst)
+	})
+	t.Run("invalid-spec", func(t *testing.T) {
+		m := Default.Copy()
+		assert.Panics(t, func() {
+			m.RegisterFieldMapping(reflect.TypeOf(UserDTO{}), reflect.TypeOf(User{}), map[string]string{"Foo": "Name"}, false)
+		})
+		assert.Panics(t, func() {
+			m.RegisterFieldMapping(reflect.TypeOf(UserDTO{}), reflect.TypeOf(User{}), map[string]string{"FullName": "Foo"}, false)
+		})
+		assert.Panics(t, func() {
+			m.RegisterFieldMapping(reflect.TypeOf(UserDTO{}), reflect.TypeOf(User{}), map[string]string{"FullName": "Name", "Mail": "Name"}, false)
+		})
+		assert.Panics(t, func() {
+			m.RegisterFieldMapping(reflect.TypeOf(""), reflect.TypeOf(User{}), nil, false)
+		})
+	})
+}
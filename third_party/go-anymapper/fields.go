@@ -0,0 +1,181 @@
+package anymapper
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// structField describes a struct field that can be matched by name during
+// struct ⇔ struct and struct ⇔ map mappings.
+type structField struct {
+	field  reflect.StructField // Field.Index holds the full index sequence.
+	name   string
+	opts   tagOptions
+	depth  int
+	tagged bool
+}
+
+// structFields returns the fields of the given struct type that can be
+// matched by name. The parse function is either parseTag or parseKey.
+//
+// Fields of embedded structs without a tag name are promoted to the parent
+// struct, similar to encoding/json. If more than one field has the same
+// name, the shallower field is used. If there are multiple fields with the
+// same name at the same depth, the tagged one is used, and if that does not
+// resolve the conflict, all of them are ignored.
+//
+// Embedded types that have a custom mapper registered are not promoted.
+func (m *Mapper) structFields(
+	ctx *Context,
+	t reflect.Type,
+	parse func(*Context, reflect.StructField) (string, tagOptions, bool),
+) []structField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	var (
+		fields  []structField
+		current []embedded
+		next    = []embedded{{typ: t}}
+		visited = map[reflect.Type]struct{}{}
+	)
+	for depth := 0; len(next) > 0; depth++ {
+		current, next = next, nil
+		for _, e := range current {
+			if _, ok := visited[e.typ]; ok {
+				continue
+			}
+			visited[e.typ] = struct{}{}
+			for i := 0; i < e.typ.NumField(); i++ {
+				f := e.typ.Field(i)
+				f.Index = append(append([]int{}, e.index...), i)
+				if f.Anonymous && m.isPromoted(ctx, f) {
+					ft := f.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					next = append(next, embedded{typ: ft, index: f.Index})
+					continue
+				}
+				if !f.IsExported() {
+					continue
+				}
+				name, opts, skip := parse(ctx, f)
+				if skip {
+					continue
+				}
+				tag, _, _ := strings.Cut(f.Tag.Get(ctx.Tag), ",")
+				fields = append(fields, structField{
+					field:  f,
+					name:   name,
+					opts:   opts,
+					depth:  depth,
+					tagged: len(tag) > 0,
+				})
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	// Resolve name conflicts. Fields are sorted by name, depth and whether
+	// they are tagged, so the dominant field is always the first one.
+	sort.SliceStable(fields, func(i, j int) bool {
+		switch {
+		case fields[i].name != fields[j].name:
+			return fields[i].name < fields[j].name
+		case fields[i].depth != fields[j].depth:
+			return fields[i].depth < fields[j].depth
+		default:
+			return fields[i].tagged && !fields[j].tagged
+		}
+	})
+	res := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || fields[i].depth < fields[i+1].depth || fields[i].tagged != fields[i+1].tagged {
+			res = append(res, fields[i])
+		}
+		i = j
+	}
+	// Restore the order of fields as they appear in the struct.
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i].field.Index, res[j].field.Index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return res
+}
+
+// isPromoted reports whether the fields of the given embedded field should
+// be promoted to the parent struct.
+func (m *Mapper) isPromoted(ctx *Context, f reflect.StructField) bool {
+	tag, _ := f.Tag.Lookup(ctx.Tag)
+	if tag == "-" {
+		return false
+	}
+	if name, _, _ := strings.Cut(tag, ","); len(name) > 0 {
+		// Explicitly named embedded fields are treated as regular fields.
+		return false
+	}
+	ft := f.Type
+	if ft.Kind() == reflect.Pointer {
+		ft = ft.Elem()
+	}
+	if ft.Kind() != reflect.Struct {
+		return false
+	}
+	if !f.IsExported() && f.Type.Kind() == reflect.Pointer {
+		// Fields of an unexported embedded pointer cannot be set because
+		// the pointer cannot be initialized.
+		return false
+	}
+	if _, ok := m.Mappers[ft]; ok {
+		return false
+	}
+	return true
+}
+
+// fieldByIndex returns the field of the struct v with the given index
+// sequence. It returns an invalid value if one of the embedded pointers on
+// the path is nil.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldByIndexAlloc works like fieldByIndex, but it initializes nil embedded
+// pointers on the path. It returns an invalid value if a pointer cannot be
+// initialized.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+package anymapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedFields(t *testing.T) {
+	type Base struct {
+		ID   int
+		Name string
+	}
+	type Other struct {
+		Name string
+	}
+	t.Run("struct-to-map", func(t *testing.T) {
+		type Str struct {
+			Base
+			Foo int
+		}
+		var dst map[string]any
+		require.NoError(t, Map(Str{Base: Base{ID: 1, Name: "a"}, Foo: 2}, &dst))
+		assert.Equal(t, map[string]any{"ID": 1, "Name": "a", "Foo": 2}, dst)
+	})
+	t.Run("map-to-struct", func(t *testing.T) {
+		type Str struct {
+			Base
+			Foo int
+		}
+		var dst Str
+		require.NoError(t, Map(map[string]any{"ID": 1, "Name": "a", "Foo": 2}, &dst))
+		assert.Equal(t, Str{Base: Base{ID: 1, Name: "a"}, Foo: 2}, dst)
+	})
+	t.Run("pointer", func(t *testing.T) {
+		type Str struct {
+			*Base
+		}
+		var dst Str
+		require.NoError(t, Map(map[string]any{"ID": 1}, &dst))
+		require.NotNil(t, dst.Base)
+		assert.Equal(t, 1, dst.ID)
+
+		var out map[string]any
+		require.NoError(t, Map(Str{}, &out))
+		assert.Empty(t, out)
+	})
+	t.Run("tagged", func(t *testing.T) {
+		type Str struct {
+			Base `map:"base"`
+		}
+		var dst map[string]any
+		require.NoError(t, Map(Str{Base: Base{ID: 1}}, &dst))
+		assert.Equal(t, map[string]any{"base": Base{ID: 1}}, dst)
+	})
+	t.Run("shallower-field-wins", func(t *testing.T) {
+		type Str struct {
+			Base
+			Name string
+		}
+		var dst Str
+		require.NoError(t, Map(map[string]any{"ID": 1, "Name": "a"}, &dst))
+		assert.Equal(t, Str{Base: Base{ID: 1}, Name: "a"}, dst)
+	})
+	t.Run("ambiguous-field", func(t *testing.T) {
+		type Str struct {
+			Base
+			Other
+		}
+		var dst Str
+		require.NoError(t, Map(map[string]any{"ID": 1, "Name": "a"}, &dst))
+		assert.Equal(t, Str{Base: Base{ID: 1}}, dst)
+	})
+	t.Run("struct-to-struct", func(t *testing.T) {
+		type Src struct {
+			Base
+		}
+		type Dst struct {
+			ID   int
+			Name string
+		}
+		var dst Dst
+		require.NoError(t, Map(Src{Base: Base{ID: 1, Name: "a"}}, &dst))
+		assert.Equal(t, Dst{ID: 1, Name: "a"}, dst)
+	})
+}
@@ -0,0 +1,97 @@
+package anymapper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type customType struct {
+	foo string
+}
+
+func (c *customType) MapFrom(m *Mapper, src reflect.Value) error {
+	return m.MapRefl(src, reflect.ValueOf(&c.foo))
+}
+
+func (c customType) MapTo(m *Mapper, dst reflect.Value) error {
+	return m.MapRefl(reflect.ValueOf(c.foo), dst)
+}
+
+func TestCustomType(t *testing.T) {
+	m := New()
+	m.Hooks = MappingInterfaceHooks
+
+	t.Run("mapFrom", func(t *testing.T) {
+		var dst customType
+		require.NoError(t, m.Map("foo", &dst))
+		assert.Equal(t, "foo", dst.foo)
+	})
+	t.Run("mapTo", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(customType{foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("mapFromPtr", func(t *testing.T) {
+		var dst *customType
+		require.NoError(t, m.Map("foo", &dst))
+		assert.Equal(t, "foo", dst.foo)
+	})
+	t.Run("mapToPtr", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(&customType{foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("mapToAny", func(t *testing.T) {
+		var dst any
+		require.NoError(t, m.Map(&customType{foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("both", func(t *testing.T) {
+		var dst customType
+		require.NoError(t, m.Map(customType{foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst.foo)
+	})
+}
+
+func TestPostMapHook(t *testing.T) {
+	type Inner struct {
+		Port int
+	}
+	type Outer struct {
+		Host  string
+		Inner Inner
+	}
+	var visited []reflect.Type
+	m := New()
+	m.Hooks.PostMapHook = func(ctx *Context, dst reflect.Value) error {
+		visited = append(visited, dst.Type())
+		if dst.Type() == reflect.TypeOf(Inner{}) && dst.FieldByName("Port").Int() == 0 {
+			return errors.New("port is required")
+		}
+		return nil
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		visited = nil
+		var dst Outer
+		src := map[string]any{"Host": "localhost", "Inner": map[string]any{"Port": 80}}
+		require.NoError(t, m.Map(src, &dst))
+		assert.Equal(t, 80, dst.Inner.Port)
+		// The nested struct must be reported before its parent, after its
+		// fields are set.
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(Inner{}), reflect.TypeOf(Outer{})}, visited)
+	})
+	t.Run("error", func(t *testing.T) {
+		visited = nil
+		var dst Outer
+		src := map[string]any{"Host": "localhost", "Inner": map[string]any{}}
+		err := m.Map(src, &dst)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "port is required")
+		assert.Equal(t, []reflect.Type{reflect.TypeOf(Inner{})}, visited)
+	})
+}
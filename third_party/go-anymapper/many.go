@@ -0,0 +1,105 @@
+package anymapper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ElementErr is returned by MapMany when an element cannot be mapped. It
+// holds the index of the element and the original error.
+type ElementErr struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementErr) Error() string {
+	return fmt.Sprintf("mapper: element %d: %v", e.Index, e.Err)
+}
+
+func (e *ElementErr) Unwrap() error {
+	return e.Err
+}
+
+// MapMany maps every element of the source slice to the destination slice.
+//
+// It is shorthand for Default.MapMany(src, dst).
+func MapMany(src, dst any) error {
+	return Default.MapMany(src, dst)
+}
+
+// MapMany maps every element of the source slice to the destination slice.
+//
+// See MapManyContext for more information.
+func (m *Mapper) MapMany(src, dst any) error {
+	return m.MapManyContext(m.Context, src, dst)
+}
+
+// MapManyContext maps every element of the source slice to the destination
+// slice.
+//
+// The src must be a slice, an array, or a pointer to one of them. The dst
+// must be a pointer to a slice or an array. A destination slice is allocated
+// with the same length as the source, a destination array must have the same
+// length as the source.
+//
+// Unlike mapping slices using Map, the errors are wrapped in ElementErr
+// holding the index of the element that failed.
+func (m *Mapper) MapManyContext(ctx *Context, src, dst any) error {
+	if ctx == nil {
+		ctx = m.Context
+	}
+	if ctx.visited == nil {
+		cpy := *ctx
+		cpy.visited = make(map[visitKey]struct{})
+		ctx = &cpy
+	}
+	srcVal := reflect.ValueOf(src)
+	dstVal := reflect.ValueOf(dst)
+	for srcVal.Kind() == reflect.Pointer || srcVal.Kind() == reflect.Interface {
+		srcVal = srcVal.Elem()
+	}
+	if !srcVal.IsValid() {
+		return InvalidSrcErr
+	}
+	if dstVal.Kind() != reflect.Pointer || dstVal.IsNil() {
+		return InvalidDstErr
+	}
+	dstVal = dstVal.Elem()
+	if srcVal.Kind() != reflect.Slice && srcVal.Kind() != reflect.Array {
+		return NewInvalidMappingError(srcVal.Type(), dstVal.Type(), "source must be a slice or an array")
+	}
+	switch dstVal.Kind() {
+	case reflect.Slice:
+		dstVal.Set(reflect.MakeSlice(dstVal.Type(), srcVal.Len(), srcVal.Len()))
+	case reflect.Array:
+		if srcVal.Len() != dstVal.Len() {
+			return NewInvalidMappingError(
+				srcVal.Type(),
+				dstVal.Type(),
+				fmt.Sprintf("length mismatch: %d != %d", srcVal.Len(), dstVal.Len()),
+			)
+		}
+	default:
+		return NewInvalidMappingError(srcVal.Type(), dstVal.Type(), "destination must be a slice or an array")
+	}
+	mapper := m.mapperFor(ctx, srcVal.Type().Elem(), dstVal.Type().Elem())
+	for i := 0; i < srcVal.Len(); i++ {
+		srcElem := m.srcValue(srcVal.Index(i))
+		dstElem := m.dstValue(dstVal.Index(i))
+		if !srcElem.IsValid() {
+			return &ElementErr{Index: i, Err: InvalidSrcErr}
+		}
+		if !dstElem.IsValid() {
+			return &ElementErr{Index: i, Err: InvalidDstErr}
+		}
+		srcElemTyp := srcElem.Type()
+		dstElemTyp := dstElem.Type()
+		if !mapper.match(srcElemTyp, dstElemTyp) {
+			mapper = m.mapperFor(ctx, srcElemTyp, dstElemTyp)
+		}
+		if err := mapper.mapRefl(m, ctx, srcElem, dstElem); err != nil {
+			return &ElementErr{Index: i, Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package anymapper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapMany(t *testing.T) {
+	type Src struct {
+		ID   string
+		Name string
+	}
+	type Dst struct {
+		ID   int
+		Name string
+	}
+	t.Run("slice-to-slice", func(t *testing.T) {
+		src := []Src{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}
+		dst := []Dst{{ID: 9}, {ID: 9}, {ID: 9}}
+		require.NoError(t, MapMany(src, &dst))
+		assert.Equal(t, []Dst{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, dst)
+	})
+	t.Run("pointer-to-slice", func(t *testing.T) {
+		src := []*Src{{ID: "1"}}
+		var dst []*Dst
+		require.NoError(t, MapMany(&src, &dst))
+		require.Len(t, dst, 1)
+		assert.Equal(t, &Dst{ID: 1}, dst[0])
+	})
+	t.Run("array", func(t *testing.T) {
+		var dst [2]int
+		require.NoError(t, MapMany([]string{"1", "2"}, &dst))
+		assert.Equal(t, [2]int{1, 2}, dst)
+	})
+	t.Run("empty", func(t *testing.T) {
+		var dst []Dst
+		require.NoError(t, MapMany([]Src{}, &dst))
+		assert.Empty(t, dst)
+	})
+	t.Run("element-error", func(t *testing.T) {
+		src := []Src{{ID: "1"}, {ID: "2"}, {ID: "x"}}
+		var dst []Dst
+		err := MapMany(src, &dst)
+		var eErr *ElementErr
+		require.ErrorAs(t, err, &eErr)
+		assert.Equal(t, 2, eErr.Index)
+		var mErr *InvalidMappingErr
+		assert.ErrorAs(t, err, &mErr)
+	})
+	t.Run("nil-element", func(t *testing.T) {
+		src := []*Src{{ID: "1"}, nil}
+		var dst []Dst
+		err := MapMany(src, &dst)
+		var eErr *ElementErr
+		require.ErrorAs(t, err, &eErr)
+		assert.Equal(t, 1, eErr.Index)
+		assert.ErrorIs(t, err, InvalidSrcErr)
+	})
+	t.Run("array-length-mismatch", func(t *testing.T) {
+		var dst [3]int
+		assert.Error(t, MapMany([]int{1, 2}, &dst))
+	})
+	t.Run("invalid-src", func(t *testing.T) {
+		var dst []int
+		assert.Error(t, MapMany(1, &dst))
+		assert.ErrorIs(t, MapMany(nil, &dst), InvalidSrcErr)
+	})
+	t.Run("invalid-dst", func(t *testing.T) {
+		var dst []int
+		assert.ErrorIs(t, MapMany([]int{1}, dst), InvalidDstErr)
+		var i int
+		assert.Error(t, MapMany([]int{1}, &i))
+	})
+}
@@ -0,0 +1,942 @@
+package anymapper
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MapFunc is a function that maps a src value to a dst value. It returns an
+// error if the mapping is not possible. The src and dst values are never
+// pointers.
+type MapFunc func(m *Mapper, ctx *Context, src, dst reflect.Value) error
+
+// MapFuncProvider is a function that returns a MapFunc for given src and dst
+// types. If mapping is not supported, it returns nil.
+type MapFuncProvider func(m *Mapper, src, dst reflect.Type) MapFunc
+
+// Default is the default Mapper used by the Map and MapRefl functions.
+// It also provides additional mapping rules for time.Time, big.Int, big.Float
+// and big.Rat. It can be modified to change the default behavior, but if the
+// mapper is used by other packages, it is recommended to create a copy of the
+// default mapper and modify the copy.
+var Default = New()
+
+// ArrayLengthMode specifies how a slice or an array is mapped to an array of
+// a different length.
+type ArrayLengthMode int
+
+const (
+	// ArrayLengthStrict returns an error if the lengths are different.
+	ArrayLengthStrict ArrayLengthMode = iota
+
+	// ArrayLengthTruncate allows to map longer values to shorter arrays,
+	// the excess elements are dropped. Shorter values result in an error.
+	ArrayLengthTruncate
+
+	// ArrayLengthZeroPad allows to map shorter values to longer arrays, the
+	// remaining elements are set to zero values. Longer values result in an
+	// error.
+	ArrayLengthZeroPad
+)
+
+// Context is a context that is passed to the mapping functions. It can be
+// used to pass additional information to the mapping functions or to change
+// the behavior of the mapper without modifying the global state or creating
+// a copy of the mapper.
+type Context struct {
+	// StrictTypes enables strict type checking. If enabled, the source and
+	// destination types must be exactly the same for the mapping to be
+	// successful. However, mapping between different data structures, such as
+	// `struct` ⇔ `struct`, `struct` ⇔ `map` and `map` ⇔ `map` is always
+	// allowed. If the destination type is an empty interface, the source value
+	// will be assigned to it regardless of the strict type check setting.
+	StrictTypes bool
+
+	// Tag is the name of the struct tag that is used by the mapper to
+	// determine the name of the field to map to.
+	Tag string
+
+	// TaggedFieldsOnly limits mapping to struct fields that have the tag
+	// specified in the Tag field. Fields without that tag are skipped, even
+	// if they have other tags. This allows the same struct to be mapped
+	// differently by switching the Tag field.
+	TaggedFieldsOnly bool
+
+	// ByteOrder is the byte order used to map numbers to and from byte slices.
+	ByteOrder binary.ByteOrder
+
+	// ArrayLengthMode specifies how a slice or an array is mapped to an array
+	// of a different length. By default, the lengths must be equal.
+	ArrayLengthMode ArrayLengthMode
+
+	// DisableCache disables the cache of the type mappers.
+	DisableCache bool
+
+	// FieldMapper is a function that maps a struct field name to another name,
+	// it is used only when the tag is not present.
+	FieldMapper func(string) string
+
+	// KeyMapper is a function that maps a struct field name to a map key. It
+	// is used for struct ⇔ map mappings instead of the FieldMapper, but only
+	// when the tag does not specify a name.
+	KeyMapper func(string) string
+
+	// CaseInsensitiveFields enables case-insensitive matching of struct field
+	// names and map keys. Exact matches are always preferred, case-insensitive
+	// comparison is used only as a fallback if there is no exact match.
+	//
+	// Keys that exactly match another field are never used as a fallback.
+	// If more than one key matches a field case-insensitively, or there are
+	// fields whose names differ only by case, the match is ambiguous and
+	// the field is treated as if it had no match at all.
+	CaseInsensitiveFields bool
+
+	// DisallowUnknownFields causes map ⇒ struct mapping to return an error
+	// if the map contains keys that do not match any struct field. The error
+	// lists all unknown keys. If the struct has a field with the "remain"
+	// option, unknown keys are stored in that field instead.
+	DisallowUnknownFields bool
+
+	// DottedKeys enables interpreting dots in map keys as paths to nested
+	// struct fields when mapping a map to a struct. For example, the key
+	// "db.host" is mapped to the Host field of the struct in the DB field.
+	// Keys that exactly match a field name are not split. If both a dotted
+	// key and a nested map with the same entry exist, the dotted key takes
+	// precedence.
+	DottedKeys bool
+
+	// Custom is a custom value that can be used to pass additional information
+	// to the mapping functions.
+	Custom any
+
+	// Field is the struct field that is currently being mapped. It is set by
+	// the mapper during struct mapping, so that MapFunc implementations can
+	// use the field tag to customize the mapping. For struct ⇒ struct and
+	// map ⇒ struct mappings it is the destination field, for struct ⇒ map
+	// mapping it is the source field. Outside of struct mapping, it is
+	// a zero value.
+	Field reflect.StructField
+
+	// goCtx is a standard context that can be used by MapFunc
+	// implementations for cancellation and deadlines. It is set by
+	// WithGoContext and returned by GoContext.
+	goCtx context.Context
+
+	// deepCopy is set by DeepCopy. If set, values that would otherwise be
+	// assigned directly are copied, so that the destination does not share
+	// memory with the source.
+	deepCopy bool
+
+	// visited holds the source values that are currently being mapped. It is
+	// used to detect cyclic references. It is initialized by MapReflContext.
+	visited map[visitKey]struct{}
+}
+
+// WithStrictTypes returns a copy of the context with the StrictTypes field
+// set to the given value.
+func (c *Context) WithStrictTypes(strictTypes bool) *Context {
+	cpy := *c
+	cpy.StrictTypes = strictTypes
+	return &cpy
+}
+
+// WithTag returns a copy of the context with the Tag field set to the given
+// value.
+func (c *Context) WithTag(tag string) *Context {
+	cpy := *c
+	cpy.Tag = tag
+	return &cpy
+}
+
+// WithTaggedFieldsOnly returns a copy of the context with the
+// TaggedFieldsOnly field set to the given value.
+func (c *Context) WithTaggedFieldsOnly(taggedFieldsOnly bool) *Context {
+	cpy := *c
+	cpy.TaggedFieldsOnly = taggedFieldsOnly
+	return &cpy
+}
+
+// WithByteOrder returns a copy of the context with the ByteOrder field set
+// to the given value.
+func (c *Context) WithByteOrder(byteOrder binary.ByteOrder) *Context {
+	cpy := *c
+	cpy.ByteOrder = byteOrder
+	return &cpy
+}
+
+// WithArrayLengthMode returns a copy of the context with the ArrayLengthMode
+// field set to the given value.
+func (c *Context) WithArrayLengthMode(mode ArrayLengthMode) *Context {
+	cpy := *c
+	cpy.ArrayLengthMode = mode
+	return &cpy
+}
+
+// WithDisableCache returns a copy of the context with the DisableCache field
+// set to the given value.
+func (c *Context) WithDisableCache(disableCache bool) *Context {
+	cpy := *c
+	cpy.DisableCache = disableCache
+	return &cpy
+}
+
+// WithFieldMapper returns a copy of the context with the FieldMapper field
+// set to the given value.
+func (c *Context) WithFieldMapper(fieldMapper func(string) string) *Context {
+	cpy := *c
+	cpy.FieldMapper = fieldMapper
+	return &cpy
+}
+
+// WithKeyMapper returns a copy of the context with the KeyMapper field set
+// to the given value.
+func (c *Context) WithKeyMapper(keyMapper func(string) string) *Context {
+	cpy := *c
+	cpy.KeyMapper = keyMapper
+	return &cpy
+}
+
+// WithCaseInsensitiveFields returns a copy of the context with the
+// CaseInsensitiveFields field set to the given value.
+func (c *Context) WithCaseInsensitiveFields(caseInsensitiveFields bool) *Context {
+	cpy := *c
+	cpy.CaseInsensitiveFields = caseInsensitiveFields
+	return &cpy
+}
+
+// WithDisallowUnknownFields returns a copy of the context with the
+// DisallowUnknownFields field set to the given value.
+func (c *Context) WithDisallowUnknownFields(disallowUnknownFields bool) *Context {
+	cpy := *c
+	cpy.DisallowUnknownFields = disallowUnknownFields
+	return &cpy
+}
+
+// WithDottedKeys returns a copy of the context with the DottedKeys field set
+// to the given value.
+func (c *Context) WithDottedKeys(dottedKeys bool) *Context {
+	cpy := *c
+	cpy.DottedKeys = dottedKeys
+	return &cpy
+}
+
+// WithCustom returns a copy of the context with the Custom field set to the
+// given value.
+func (c *Context) WithCustom(custom any) *Context {
+	cpy := *c
+	cpy.Custom = custom
+	return &cpy
+}
+
+// WithGoContext returns a copy of the context that carries the given
+// standard context. Custom MapFunc implementations can obtain it using
+// the GoContext method, e.g. to check for cancellation.
+func (c *Context) WithGoContext(goCtx context.Context) *Context {
+	cpy := *c
+	cpy.goCtx = goCtx
+	return &cpy
+}
+
+// GoContext returns the standard context set by WithGoContext. If it was not
+// set, context.Background is returned.
+func (c *Context) GoContext() context.Context {
+	if c.goCtx == nil {
+		return context.Background()
+	}
+	return c.goCtx
+}
+
+// withField returns a copy of the context with the Field field set to the
+// given value.
+func (c *Context) withField(field reflect.StructField) *Context {
+	cpy := *c
+	cpy.Field = field
+	return &cpy
+}
+
+// Mapper hold the mapper configuration.
+type Mapper struct {
+	// Context is the default context used by the mapper.
+	Context *Context
+
+	// Mappers is a map of custom mapper providers. The key is the type that
+	// the mapper can map to and from. The value is a function that returns
+	// a MapFunc that maps the source type to the destination type. Provider
+	// can return nil if the mapping is not possible.
+	//
+	// If both source and destination types have defined providers, then
+	// the provider for source value is used first, and if it returns nil,
+	// then the provider for destination value is used.
+	Mappers map[reflect.Type]MapFuncProvider
+
+	// Hooks are functions that are called during the mapping process. They
+	// can modify the behavior of the mapper. See Hooks for more information.
+	Hooks Hooks
+
+	// fieldMappings holds explicit field mappings registered with
+	// RegisterFieldMapping.
+	fieldMappings map[typePair]MapFunc
+
+	// Cache of type mappers, keys are typePair and values are *typeMapper.
+	// The sync.Map is used because the cache is read far more often than it
+	// is written, so that concurrent lookups do not contend.
+	cacheMap sync.Map
+}
+
+// Hooks are functions that are called during the mapping process. They can
+// modify the behavior of the mapper.
+type Hooks struct {
+	// MapFuncHook allows to bypass the default mapping rules and use a custom
+	// mapping function. If the hook returns nil, then the default mapping
+	// rules are used.
+	//
+	// Returned MapFunc is cached.
+	MapFuncHook MapFuncProvider
+
+	// SourceValueHook returns a value that should be used as the source
+	// value. It is called before the source value is used in the mapping.
+	//
+	// If the hook returns an invalid value, then the default function is used.
+	//
+	// By default, mapper unpacks pointers and dereferences interfaces. This
+	// hook can be used to change this behavior.
+	SourceValueHook func(reflect.Value) reflect.Value
+
+	// DestinationValueHook returns a value that should be used as the destination
+	// value. It is called before the destination value is used in the mapping.
+	//
+	// If the hook returns an invalid value, then the default function is used.
+	//
+	// By default, mapper unpacks pointers and dereferences interfaces. This
+	// hook can be used to change this behavior.
+	DestinationValueHook func(reflect.Value) reflect.Value
+
+	// PostMapHook is called after a destination struct has been populated.
+	// It is called for every struct, including nested ones, so it can be
+	// used to validate mapped values. If the hook returns an error, mapping
+	// is aborted and the error is returned.
+	PostMapHook func(ctx *Context, dst reflect.Value) error
+}
+
+// New returns a new Mapper with default configuration.
+func New() *Mapper {
+	return &Mapper{
+		Context: &Context{
+			Tag:       `map`,
+			ByteOrder: binary.BigEndian,
+		},
+		Mappers: map[reflect.Type]MapFuncProvider{
+			timeTy:     timeTypeMapper,
+			bigIntTy:   bigIntTypeMapper,
+			bigFloatTy: bigFloatTypeMapper,
+			bigRatTy:   bigRatTypeMapper,
+			ipTy:       ipTypeMapper,
+			netipTy:    netipTypeMapper,
+			jsonNumTy:  jsonNumberTypeMapper,
+		},
+	}
+}
+
+// Map maps the source value to the destination value.
+//
+// It is shorthand for Default.mapRefl(src, dst).
+func Map(src, dst any) error {
+	return Default.Map(src, dst)
+}
+
+// MapContext maps the source value to the destination value.
+//
+// It is shorthand for Default.MapContext(ctx, src, dst).
+func MapContext(ctx *Context, src, dst any) error {
+	return Default.MapContext(ctx, src, dst)
+}
+
+// MapRefl maps the source value to the destination value.
+//
+// It is shorthand for Default.MapRefl(src, dst).
+func MapRefl(src, dst reflect.Value) error {
+	return Default.MapRefl(src, dst)
+}
+
+// MapReflContext maps the source value to the destination value.
+//
+// It is shorthand for Default.MapReflContext(ctx, src, dst).
+func MapReflContext(ctx *Context, src, dst reflect.Value) error {
+	return Default.MapReflContext(ctx, src, dst)
+}
+
+// Map maps the source value to the destination value.
+func (m *Mapper) Map(src, dst any) error {
+	return m.MapRefl(reflect.ValueOf(src), reflect.ValueOf(dst))
+}
+
+// MapContext maps the source value to the destination value.
+func (m *Mapper) MapContext(ctx *Context, src, dst any) error {
+	return m.MapReflContext(ctx, reflect.ValueOf(src), reflect.ValueOf(dst))
+}
+
+// MapRefl maps the source value to the destination value.
+func (m *Mapper) MapRefl(src, dst reflect.Value) error {
+	return m.MapReflContext(m.Context, src, dst)
+}
+
+// MapReflContext maps the source value to the destination value.
+func (m *Mapper) MapReflContext(ctx *Context, src, dst reflect.Value) error {
+	if ctx == nil {
+		ctx = m.Context
+	}
+	if ctx.visited == nil {
+		cpy := *ctx
+		cpy.visited = make(map[visitKey]struct{})
+		ctx = &cpy
+	}
+	srcVal := m.srcValue(src)
+	dstVal := m.dstValue(dst)
+	if !srcVal.IsValid() {
+		return InvalidSrcErr
+	}
+	if !dstVal.IsValid() {
+		return InvalidDstErr
+	}
+	return m.mapperFor(ctx, srcVal.Type(), dstVal.Type()).mapRefl(m, ctx, srcVal, dstVal)
+}
+
+// Copy creates a copy of the current Mapper with the same configuration.
+func (m *Mapper) Copy() *Mapper {
+	cpy := &Mapper{
+		Context: &Context{
+			StrictTypes:  m.Context.StrictTypes,
+			Tag:          m.Context.Tag,
+			ByteOrder:    m.Context.ByteOrder,
+			DisableCache: m.Context.DisableCache,
+			FieldMapper:  m.Context.FieldMapper,
+			KeyMapper:    m.Context.KeyMapper,
+			Custom:       m.Context.Custom,
+
+			CaseInsensitiveFields: m.Context.CaseInsensitiveFields,
+			TaggedFieldsOnly:      m.Context.TaggedFieldsOnly,
+			DottedKeys:            m.Context.DottedKeys,
+			DisallowUnknownFields: m.Context.DisallowUnknownFields,
+			ArrayLengthMode:       m.Context.ArrayLengthMode,
+		},
+		Hooks: m.Hooks,
+	}
+	if m.Mappers != nil {
+		cpy.Mappers = make(map[reflect.Type]MapFuncProvider)
+		for k, v := range m.Mappers {
+			cpy.Mappers[k] = v
+		}
+	}
+	if m.fieldMappings != nil {
+		cpy.fieldMappings = make(map[typePair]MapFunc)
+		for k, v := range m.fieldMappings {
+			cpy.fieldMappings[k] = v
+		}
+	}
+	return cpy
+}
+
+// mapperFor returns the typeMapper that can map values of the given types.
+// If mapping is not possible, the returned typeMapper has a nil MapFunc.
+func (m *Mapper) mapperFor(ctx *Context, src, dst reflect.Type) (tm *typeMapper) {
+	if !ctx.DisableCache {
+		key := typePair{src: src, dst: dst}
+		if v, ok := m.cacheMap.Load(key); ok {
+			return v.(*typeMapper)
+		}
+		defer func() {
+			// If another goroutine stored a mapper for the same types in the
+			// meantime, use it, so that all callers share the same instance.
+			v, _ := m.cacheMap.LoadOrStore(key, tm)
+			tm = v.(*typeMapper)
+		}()
+	}
+
+	tm = &typeMapper{
+		SrcType: src,
+		DstType: dst,
+	}
+
+	// If MapFuncHook is set, then use it to get the mapping function.
+	if m.Hooks.MapFuncHook != nil {
+		if fn := m.Hooks.MapFuncHook(m, src, dst); fn != nil {
+			tm.MapFunc = fn
+			return
+		}
+	}
+
+	// If there is an explicit field mapping for the given types, use it.
+	if fn, ok := m.fieldMappings[typePair{src: src, dst: dst}]; ok {
+		tm.MapFunc = fn
+		return
+	}
+
+	var isSrcSimple, isDstSimple, sameTypes bool
+	if src == dst {
+		isSrcSimple = isSimpleType(src)
+		isDstSimple = isSrcSimple
+		sameTypes = true
+	} else {
+		isSrcSimple = isSimpleType(src)
+		isDstSimple = isSimpleType(dst)
+	}
+
+	// If both types are simple, e.g. int, string, etc. map the value directly
+	// using reflect.Set.
+	if sameTypes && isSrcSimple {
+		tm.MapFunc = mapDirect
+		return
+	}
+
+	// Try to find a mapper using mapper providers. It looks for providers
+	// for src and dst types. First it tries to use providers for src. If
+	// it returns a mapper, it uses it. If it returns nil, it tries to use
+	// providers for dst. If both return nil, then mapping is not possible.
+	var srcMapper, dstMapper MapFuncProvider
+	var hasSrcMapper, hasDstMapper bool
+	if !isSrcSimple {
+		srcMapper, hasSrcMapper = m.Mappers[src]
+	}
+	if hasSrcMapper {
+		tm.MapFunc = srcMapper(m, src, dst)
+		if tm.MapFunc != nil {
+			return
+		}
+	}
+	if !sameTypes && !isDstSimple {
+		dstMapper, hasDstMapper = m.Mappers[dst]
+	}
+	if hasDstMapper {
+		tm.MapFunc = dstMapper(m, src, dst)
+		if tm.MapFunc != nil {
+			return
+		}
+	}
+	if hasSrcMapper || hasDstMapper {
+		return
+	}
+
+	// If destination type is an any interface, map the value directly using
+	// reflect.Set, if the destination interface is not nil, map the value
+	// to the same type as the value in the interface.
+	if dst == anyTy {
+		tm.MapFunc = mapAny
+		return
+	}
+
+	// If the source type implements encoding.TextMarshaler or the destination
+	// type implements encoding.TextUnmarshaler, use them to map the value
+	// to or from a string or a byte slice.
+	if fn := textTypeMapper(m, src, dst); fn != nil {
+		tm.MapFunc = fn
+		return
+	}
+
+	// If there are no custom mappers and hooks, use the default mappers.
+	tm.MapFunc = builtInTypesMapper(m, src, dst)
+	return
+}
+
+// resetCache removes all cached type mappers. It must be called after
+// the mapper configuration is changed.
+func (m *Mapper) resetCache() {
+	m.cacheMap.Range(func(k, _ any) bool {
+		m.cacheMap.Delete(k)
+		return true
+	})
+}
+
+// srcValue unpacks values from pointers and interfaces until it reaches a
+// non-pointer or non-interface value, or a type that has a custom mapper.
+func (m *Mapper) srcValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+	if m.Hooks.SourceValueHook != nil {
+		if v := m.Hooks.SourceValueHook(v); v.IsValid() {
+			return v
+		}
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if isSimpleType(v.Type()) {
+			return v
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// dstValue unpacks values from pointers and interfaces until it reaches a
+// settable non-pointer or non-interface value, value that has a custom mapper,
+// or a value that is a map, slice or array. It returns an invalid value if it
+// cannot find a value that meets these conditions. If the value is a pointer,
+// map or slice, it will be initialized if needed.
+func (m *Mapper) dstValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+	if m.Hooks.DestinationValueHook != nil {
+		if v := m.Hooks.DestinationValueHook(v); v.IsValid() {
+			return v
+		}
+	}
+	if v.Kind() != reflect.Interface && v.Kind() != reflect.Pointer && v.CanSet() {
+		return v
+	}
+	settable := reflect.Value{}
+	for {
+		if !v.IsValid() {
+			break
+		}
+		m.initValue(v)
+		if v.CanSet() && isSimpleType(v.Type()) {
+			return v
+		}
+		if m.Mappers[v.Type()] != nil {
+			return v
+		}
+		if v.Kind() == reflect.Map && !v.IsNil() {
+			return v
+		}
+		if v.CanSet() {
+			settable = v
+		}
+		if v.Kind() != reflect.Interface && v.Kind() != reflect.Pointer {
+			break
+		}
+		v = v.Elem()
+	}
+	return settable
+}
+
+// postMap calls the PostMapHook, if set, for the given destination value.
+func (m *Mapper) postMap(ctx *Context, dst reflect.Value) error {
+	if m.Hooks.PostMapHook == nil {
+		return nil
+	}
+	return m.Hooks.PostMapHook(ctx, dst)
+}
+
+// initValue initializes a value if it is a pointer, map or slice.
+func (m *Mapper) initValue(v reflect.Value) {
+	if v.Kind() < reflect.Map || v.Kind() > reflect.Slice || !v.IsNil() || !v.CanSet() {
+		return
+	}
+	switch {
+	case v.Kind() == reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+	case v.Kind() == reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+	case v.Kind() == reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+}
+
+// parseTag parses the tag of the given field and returns the tag name, tag
+// options and whether the field should be skipped.
+//
+// The tag has the form "name,opt1,opt2". If the name is empty, the field
+// name is used. If the TaggedFieldsOnly option is enabled, fields without
+// the tag are skipped.
+func (m *Mapper) parseTag(ctx *Context, f reflect.StructField) (name string, opts tagOptions, skip bool) {
+	tag, ok := f.Tag.Lookup(ctx.Tag)
+	if tag == "-" || (!ok && ctx.TaggedFieldsOnly) {
+		return "", nil, true
+	}
+	if ok {
+		parts := strings.Split(tag, ",")
+		name, opts = parts[0], parts[1:]
+	}
+	if len(name) == 0 {
+		if ctx.FieldMapper != nil {
+			name = ctx.FieldMapper(f.Name)
+		} else {
+			name = f.Name
+		}
+	}
+	return name, opts, false
+}
+
+// parseKey works like parseTag, but it returns the map key for the given
+// field. If the tag does not specify a name and the KeyMapper is set, the
+// key is derived from the field name using the KeyMapper.
+func (m *Mapper) parseKey(ctx *Context, f reflect.StructField) (name string, opts tagOptions, skip bool) {
+	name, opts, skip = m.parseTag(ctx, f)
+	if skip || ctx.KeyMapper == nil {
+		return name, opts, skip
+	}
+	if tag, _, _ := strings.Cut(f.Tag.Get(ctx.Tag), ","); len(tag) == 0 {
+		name = ctx.KeyMapper(f.Name)
+	}
+	return name, opts, false
+}
+
+// fieldNames returns the names of all fields of the given struct type that
+// can be matched by name. The parse function is either parseTag or parseKey.
+func (m *Mapper) fieldNames(
+	ctx *Context,
+	t reflect.Type,
+	parse func(*Context, reflect.StructField) (string, tagOptions, bool),
+) map[string]struct{} {
+	fields := m.structFields(ctx, t, parse)
+	names := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		names[f.name] = struct{}{}
+	}
+	return names
+}
+
+// findKeyFold looks for a string map key that is equal to the given name
+// under Unicode case-folding. The names set must contain names of all fields
+// of the struct, keys that are present in that set are ignored. It returns
+// an invalid value if there is no such key, if more than one key matches or
+// if the key matches more than one field.
+func findKeyFold(m reflect.Value, name string, names map[string]struct{}) reflect.Value {
+	var found reflect.Value
+	for _, key := range m.MapKeys() {
+		str := key
+		if str.Kind() == reflect.Interface {
+			str = str.Elem()
+		}
+		if str.Kind() != reflect.String {
+			continue
+		}
+		if _, ok := names[str.String()]; ok {
+			continue
+		}
+		if strings.EqualFold(str.String(), name) {
+			if found.IsValid() {
+				return reflect.Value{}
+			}
+			found = key
+		}
+	}
+	if found.IsValid() {
+		for n := range names {
+			if n != name && strings.EqualFold(n, name) {
+				return reflect.Value{}
+			}
+		}
+	}
+	return found
+}
+
+// tagOptions is a list of options defined in a struct tag.
+type tagOptions []string
+
+// has returns true if the given option is present.
+func (o tagOptions) has(opt string) bool {
+	for _, v := range o {
+		if v == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyValue reports whether v is empty as defined by the "omitempty" tag
+// option. Arrays, maps, slices and strings are empty if their length is zero,
+// other values are empty if they are zero values.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// isSimpleType indicates whether a type is simple type.
+//
+// A type is considered simple if it is a built-in type, or it is a slice,
+// array or map that is composed of build-in types.
+//
+// Structs are never considered simple because they are rarely used without a
+// custom type, and verifying if a struct is simple is too expensive.
+func isSimpleType(p reflect.Type) bool {
+	switch p.Kind() {
+	case reflect.Bool:
+		return p == boolTy
+	case reflect.Int:
+		return p == intTy
+	case reflect.Int8:
+		return p == int8Ty
+	case reflect.Int16:
+		return p == int16Ty
+	case reflect.Int32:
+		return p == int32Ty
+	case reflect.Int64:
+		return p == int64Ty
+	case reflect.Uint:
+		return p == uintTy
+	case reflect.Uint8:
+		return p == uint8Ty
+	case reflect.Uint16:
+		return p == uint16Ty
+	case reflect.Uint32:
+		return p == uint32Ty
+	case reflect.Uint64:
+		return p == uint64Ty
+	case reflect.Float32:
+		return p == float32Ty
+	case reflect.Float64:
+		return p == float64Ty
+	case reflect.Complex64:
+		return p == complex64Ty
+	case reflect.Complex128:
+		return p == complex128Ty
+	case reflect.String:
+		return p == stringTy
+	case reflect.Slice:
+		return strings.HasPrefix(p.String(), "[") && isSimpleType(p.Elem())
+	case reflect.Array:
+		return strings.HasPrefix(p.String(), "[") && isSimpleType(p.Elem())
+	case reflect.Map:
+		return strings.HasPrefix(p.String(), "map[") && isSimpleType(p.Elem()) && isSimpleType(p.Key())
+	}
+	return false
+}
+
+// mapAny map src to dst assuming dst is an empty interface.
+func mapAny(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.deepCopy {
+		val := reflect.New(src.Type()).Elem()
+		if err := copyValue(m, ctx, src, val); err != nil {
+			return err
+		}
+		dst.Set(val)
+		return nil
+	}
+	if !dst.IsNil() && !dst.Elem().CanSet() {
+		// Mapper always tries to reuse the destination value if possible, but
+		// if destination value is not settable, we need to cheat a little and
+		// create a new value of the same type and then set it back to the
+		// destination.
+		auxVal := reflect.New(dst.Elem().Type())
+		auxDst := m.dstValue(auxVal)
+		if err := m.MapRefl(src, auxDst); err != nil {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "")
+		}
+		dst.Set(auxVal.Elem())
+		return nil
+	}
+	dst.Set(src)
+	return nil
+}
+
+// mapDirect maps src to dst using a direct assignment.
+func mapDirect(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.deepCopy {
+		return copyValue(m, ctx, src, dst)
+	}
+	dst.Set(src)
+	return nil
+}
+
+type typeMapper struct {
+	SrcType reflect.Type
+	DstType reflect.Type
+	MapFunc MapFunc
+}
+
+func (tm *typeMapper) match(src, dst reflect.Type) bool {
+	if tm == nil {
+		return false
+	}
+	return tm.SrcType == src && tm.DstType == dst
+}
+
+func (tm *typeMapper) mapRefl(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if tm == nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "unknown mapper")
+	}
+	if tm.MapFunc == nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), "")
+	}
+	if key, ok := visitKeyOf(src); ok && ctx.visited != nil {
+		if _, ok := ctx.visited[key]; ok {
+			return NewInvalidMappingError(src.Type(), dst.Type(), "cyclic reference detected")
+		}
+		ctx.visited[key] = struct{}{}
+		defer delete(ctx.visited, key)
+	}
+	return tm.MapFunc(m, ctx, src, dst)
+}
+
+// visitKey identifies a value in memory. Type is a part of the key because
+// a struct and its first field share the same address.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// visitKeyOf returns the visitKey for the given value. Only addressable
+// structs and non-nil maps can be part of a cycle, for other values it
+// returns false.
+func visitKeyOf(v reflect.Value) (visitKey, bool) {
+	switch {
+	case v.Kind() == reflect.Struct && v.CanAddr():
+		return visitKey{ptr: v.UnsafeAddr(), typ: v.Type()}, true
+	case v.Kind() == reflect.Map && !v.IsNil():
+		return visitKey{ptr: v.Pointer(), typ: v.Type()}, true
+	}
+	return visitKey{}, false
+}
+
+// InvalidSrcErr is returned when reflect.IsValid returns false for the source
+// value.
+var InvalidSrcErr = errors.New("mapper: invalid source value")
+
+// InvalidDstErr is returned when reflect.IsValid returns false for the
+// destination value. It may happen when the destination value was not
+// passed as a pointer.
+var InvalidDstErr = errors.New("mapper: invalid destination value")
+
+type InvalidMappingErr struct {
+	From, To reflect.Type
+	Reason   string
+}
+
+func NewStrictMappingError(from, to reflect.Type) *InvalidMappingErr {
+	return &InvalidMappingErr{From: from, To: to, Reason: "strict mode"}
+}
+
+func NewInvalidMappingError(from, to reflect.Type, reason string) *InvalidMappingErr {
+	return &InvalidMappingErr{From: from, To: to, Reason: reason}
+}
+
+func (e *InvalidMappingErr) Error() string {
+	if len(e.Reason) == 0 {
+		return fmt.Sprintf("mapper: cannot map %v to %v", e.From, e.To)
+	}
+	return fmt.Sprintf("mapper: cannot map %v to %v: %s", e.From, e.To, e.Reason)
+}
+
+type typePair struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+var (
+	anyTy        = reflect.TypeOf((*any)(nil)).Elem()
+	boolTy       = reflect.TypeOf((*bool)(nil)).Elem()
+	intTy        = reflect.TypeOf((*int)(nil)).Elem()
+	int8Ty       = reflect.TypeOf((*int8)(nil)).Elem()
+	int16Ty      = reflect.TypeOf((*int16)(nil)).Elem()
+	int32Ty      = reflect.TypeOf((*int32)(nil)).Elem()
+	int64Ty      = reflect.TypeOf((*int64)(nil)).Elem()
+	uintTy       = reflect.TypeOf((*uint)(nil)).Elem()
+	uint8Ty      = reflect.TypeOf((*uint8)(nil)).Elem()
+	uint16Ty     = reflect.TypeOf((*uint16)(nil)).Elem()
+	uint32Ty     = reflect.TypeOf((*uint32)(nil)).Elem()
+	uint64Ty     = reflect.TypeOf((*uint64)(nil)).Elem()
+	float32Ty    = reflect.TypeOf((*float32)(nil)).Elem()
+	float64Ty    = reflect.TypeOf((*float64)(nil)).Elem()
+	complex64Ty  = reflect.TypeOf((*complex64)(nil)).Elem()
+	complex128Ty = reflect.TypeOf((*complex128)(nil)).Elem()
+	stringTy     = reflect.TypeOf((*string)(nil)).Elem()
+)
@@ -0,0 +1,433 @@
+package anymapper
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidValues(t *testing.T) {
+	t.Run("invalid-src", func(t *testing.T) {
+		var dst string
+		err := MapRefl(reflect.Value{}, reflect.ValueOf(&dst))
+		assert.Error(t, err)
+	})
+	t.Run("invalid-dst", func(t *testing.T) {
+		err := MapRefl(reflect.ValueOf("foo"), reflect.Value{})
+		assert.Error(t, err)
+	})
+	t.Run("unaddressable-dst", func(t *testing.T) {
+		var dst string
+		err := MapRefl(reflect.ValueOf("foo"), reflect.ValueOf(dst))
+		assert.Error(t, err)
+	})
+}
+
+func TestCustomMapFunc(t *testing.T) {
+	type customType struct {
+		Foo string
+	}
+	typ := reflect.TypeOf(customType{})
+	m := Default.Copy()
+	m.Mappers[typ] = func(m *Mapper, src, dst reflect.Type) MapFunc {
+		if src == typ {
+			return func(m *Mapper, _ *Context, src, dst reflect.Value) error {
+				return m.MapRefl(src.FieldByName("Foo"), dst)
+			}
+		}
+		if dst == typ {
+			return func(m *Mapper, _ *Context, src, dst reflect.Value) error {
+				return m.MapRefl(src, reflect.ValueOf(&dst.Addr().Interface().(*customType).Foo))
+			}
+		}
+		return nil
+	}
+	t.Run("mapFrom", func(t *testing.T) {
+		var dst customType
+		require.NoError(t, m.Map("foo", &dst))
+		assert.Equal(t, "foo", dst.Foo)
+	})
+	t.Run("mapTo", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(customType{Foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("mapFromPtr", func(t *testing.T) {
+		var dst *customType
+		require.NoError(t, m.Map("foo", &dst))
+		assert.Equal(t, "foo", dst.Foo)
+	})
+	t.Run("mapToPtr", func(t *testing.T) {
+		var dst string
+		require.NoError(t, m.Map(&customType{Foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst)
+	})
+	t.Run("both", func(t *testing.T) {
+		var dst customType
+		require.NoError(t, m.Map(customType{Foo: "foo"}, &dst))
+		assert.Equal(t, "foo", dst.Foo)
+	})
+}
+
+func TestCustomMapFuncAny(t *testing.T) {
+	type customType struct {
+		Foo string
+	}
+	typ := reflect.TypeOf(customType{})
+	m := Default.Copy()
+	m.Mappers[typ] = func(m *Mapper, src, dst reflect.Type) MapFunc {
+		if dst == anyTy {
+			return func(m *Mapper, _ *Context, src, dst reflect.Value) error {
+				dst.Set(reflect.ValueOf(src.FieldByName("Foo").Interface()))
+				return nil
+			}
+		}
+		return nil
+	}
+	src := customType{Foo: "foo"}
+	dst := any(nil)
+	require.NoError(t, m.Map(src, &dst))
+	assert.Equal(t, "foo", dst.(string))
+}
+
+func TestFieldMapper(t *testing.T) {
+	m := Default.Copy()
+	m.Context.FieldMapper = func(name string) string {
+		return strings.ToLower(name)
+	}
+	type Src struct {
+		FOO string
+		BAR string `map:"BAR"` // field mapper is ignored for tagged fields
+	}
+	var dst map[string]any
+	err := m.Map(Src{
+		FOO: "foo",
+		BAR: "bar",
+	}, &dst)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"foo": "foo",
+		"BAR": "bar",
+	}, dst)
+}
+
+func TestEmptyTag(t *testing.T) {
+	m := Default.Copy()
+	m.Context.Tag = ""
+	type Src struct {
+		Foo string `map:"bar"`
+	}
+	var dst map[string]any
+	err := m.Map(Src{
+		Foo: "foo",
+	}, &dst)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"Foo": "foo"}, dst)
+}
+
+func TestCopy(t *testing.T) {
+	cpy := Default.Copy()
+	assert.Equal(t, Default.Context, cpy.Context)
+	assert.Equal(t, &Default.Context.FieldMapper, &cpy.Context.FieldMapper)
+	assert.Equal(t, len(Default.Mappers), len(cpy.Mappers))
+	for k, v := range Default.Mappers {
+		rv1 := reflect.ValueOf(v)
+		rv2 := reflect.ValueOf(cpy.Mappers[k])
+		assert.Equal(t, rv1.Pointer(), rv2.Pointer())
+	}
+}
+
+func TestInvalidMappingErr_WithReason(t *testing.T) {
+	err := InvalidMappingErr{From: reflect.TypeOf(1), To: reflect.TypeOf("a"), Reason: "reason"}
+	assert.Equal(t, "mapper: cannot map int to string: reason", err.Error())
+}
+
+func TestInvalidMappingErr_WithoutReason(t *testing.T) {
+	err := InvalidMappingErr{From: reflect.TypeOf(1), To: reflect.TypeOf("a")}
+	assert.Equal(t, "mapper: cannot map int to string", err.Error())
+}
+
+func Benchmark(b *testing.B) {
+	b.Run("struct->struct", func(b *testing.B) {
+		type Src struct {
+			A int
+			B int
+			C int
+			D int
+		}
+		type Dst struct {
+			A string
+			B string
+			C string
+			D string
+		}
+		src := Src{
+			A: 1,
+			B: 2,
+			C: 3,
+			D: 4,
+		}
+		dst := Dst{}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("struct->map", func(b *testing.B) {
+		type Src struct {
+			A int
+			B int
+			C int
+			D int
+		}
+		src := Src{
+			A: 1,
+			B: 2,
+			C: 3,
+			D: 4,
+		}
+		dst := map[string]string{}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("map->struct", func(b *testing.B) {
+		src := map[string]int{
+			"A": 1,
+			"B": 2,
+			"C": 3,
+			"D": 4,
+		}
+		type Dst struct {
+			A string
+			B string
+			C string
+			D string
+		}
+		dst := Dst{}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("map->map", func(b *testing.B) {
+		src := map[string]int{
+			"A": 1,
+			"B": 2,
+			"C": 3,
+			"D": 4,
+		}
+		dst := map[string]string{}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("[]int->[]int", func(b *testing.B) {
+		src := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		var dst []int
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("[]int->MyInt", func(b *testing.B) {
+		type MyInt int
+		src := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		var dst []MyInt
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+	b.Run("[]int->any", func(b *testing.B) {
+		src := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		var dst []any
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = Map(src, &dst)
+		}
+	})
+}
+
+func ptr(v any) any {
+	r := reflect.New(reflect.TypeOf(v)).Elem()
+	r.Set(reflect.ValueOf(v))
+	return r.Addr().Interface()
+}
+
+func exp(v any) any {
+	r := reflect.ValueOf(v)
+	for r.Kind() == reflect.Interface {
+		r = r.Elem()
+	}
+	if r.Kind() == reflect.Ptr {
+		return r.Interface()
+	}
+	return ptr(r.Interface())
+}
+
+func dst(v any) any {
+	r := reflect.ValueOf(v)
+	for r.Kind() == reflect.Interface {
+		r = r.Elem()
+	}
+	return r.Interface()
+}
+
+func anySlice() any {
+	return []any{}
+}
+
+func TestCyclicReference(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		type Node struct {
+			Name string
+			Next *Node
+		}
+		n := &Node{Name: "a"}
+		n.Next = n
+		var dst map[string]any
+		err := Map(n, &dst)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cyclic reference detected")
+	})
+	t.Run("map", func(t *testing.T) {
+		src := map[string]any{"a": 1}
+		src["self"] = src
+		var dst map[string]any
+		err := Map(src, &dst)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cyclic reference detected")
+	})
+	t.Run("shared-value", func(t *testing.T) {
+		// The same value referenced twice is not a cycle.
+		type Leaf struct{ Val int }
+		type Str struct{ A, B *Leaf }
+		l := &Leaf{Val: 1}
+		var dst map[string]any
+		require.NoError(t, Map(Str{A: l, B: l}, &dst))
+		assert.Len(t, dst, 2)
+	})
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	m := Default.Copy()
+	type Str struct {
+		Foo int
+		Bar string
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var dst Str
+				if assert.NoError(t, m.Map(map[string]any{"Foo": i, "Bar": "bar"}, &dst)) {
+					assert.Equal(t, Str{Foo: i, Bar: "bar"}, dst)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCacheReset(t *testing.T) {
+	m := Default.Copy()
+	var dst testEnum
+	require.NoError(t, m.Map(3, &dst))
+
+	// Registering a mapper must invalidate cached mappers:
+	m.RegisterEnum(reflect.TypeOf(testEnum(0)), testEnumNames)
+	assert.Error(t, m.Map(3, &dst))
+}
+
+func TestContextField(t *testing.T) {
+	durTy := reflect.TypeOf(time.Duration(0))
+	m := Default.Copy()
+	m.Mappers[durTy] = func(m *Mapper, src, dst reflect.Type) MapFunc {
+		if dst != durTy || src.Kind() != reflect.Int {
+			return nil
+		}
+		return func(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+			unit := time.Second
+			if ctx.Field.Tag.Get("unit") == "ms" {
+				unit = time.Millisecond
+			}
+			dst.Set(reflect.ValueOf(time.Duration(src.Int()) * unit))
+			return nil
+		}
+	}
+	type Dst struct {
+		Timeout  time.Duration `map:"timeout" unit:"ms"`
+		Interval time.Duration `map:"interval"`
+	}
+	t.Run("map-to-struct", func(t *testing.T) {
+		var dst Dst
+		require.NoError(t, m.Map(map[string]any{"timeout": 5, "interval": 2}, &dst))
+		assert.Equal(t, Dst{Timeout: 5 * time.Millisecond, Interval: 2 * time.Second}, dst)
+	})
+	t.Run("struct-to-struct", func(t *testing.T) {
+		type Src struct {
+			Timeout  int `map:"timeout"`
+			Interval int `map:"interval"`
+		}
+		var dst Dst
+		require.NoError(t, m.Map(Src{Timeout: 5, Interval: 2}, &dst))
+		assert.Equal(t, Dst{Timeout: 5 * time.Millisecond, Interval: 2 * time.Second}, dst)
+	})
+	t.Run("outside-struct", func(t *testing.T) {
+		var dst time.Duration
+		require.NoError(t, m.Map(5, &dst))
+		assert.Equal(t, 5*time.Second, dst)
+	})
+}
+
+func TestGoContext(t *testing.T) {
+	type ctxKey struct{}
+	durTy := reflect.TypeOf(time.Duration(0))
+	m := Default.Copy()
+	m.Mappers[durTy] = func(m *Mapper, src, dst reflect.Type) MapFunc {
+		if dst != durTy {
+			return nil
+		}
+		return func(m *Mapper, ctx *Context, src, dst reflect.Value) error {
+			if err := ctx.GoContext().Err(); err != nil {
+				return err
+			}
+			if v, ok := ctx.GoContext().Value(ctxKey{}).(time.Duration); ok {
+				dst.Set(reflect.ValueOf(v))
+			}
+			return nil
+		}
+	}
+	type Dst struct {
+		Timeout time.Duration
+	}
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, context.Background(), Default.Context.GoContext())
+	})
+	t.Run("value", func(t *testing.T) {
+		var dst Dst
+		ctx := m.Context.WithGoContext(context.WithValue(context.Background(), ctxKey{}, time.Second))
+		require.NoError(t, m.MapContext(ctx, map[string]any{"Timeout": 1}, &dst))
+		assert.Equal(t, Dst{Timeout: time.Second}, dst)
+		// WithGoContext must not modify the original context.
+		assert.Equal(t, context.Background(), m.Context.GoContext())
+	})
+	t.Run("canceled", func(t *testing.T) {
+		var dst Dst
+		goCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := m.MapContext(m.Context.WithGoContext(goCtx), map[string]any{"Timeout": 1}, &dst)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,103 @@
+package anymapper
+
+import (
+	"encoding"
+	"reflect"
+)
+
+var (
+	textMarshalerTy   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerTy = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// textTypeMapper returns a MapFunc that uses the encoding.TextMarshaler and
+// encoding.TextUnmarshaler interfaces to map values to and from strings or
+// byte slices. It returns nil if neither of the types implements these
+// interfaces. Both value and pointer receivers are supported.
+//
+// For byte slices, the interfaces are used only if the value cannot be mapped
+// using the built-in rules, because types like hashes or addresses are
+// usually expected to be mapped to and from their raw bytes.
+func textTypeMapper(m *Mapper, src, dst reflect.Type) MapFunc {
+	if src == dst {
+		return nil
+	}
+	if isStringOrBytes(dst) && implText(src, textMarshalerTy) {
+		if dst.Kind() == reflect.String || builtInTypesMapper(m, src, dst) == nil {
+			return mapTextMarshaler
+		}
+	}
+	if isStringOrBytes(src) && implText(dst, textUnmarshalerTy) {
+		if src.Kind() == reflect.String || builtInTypesMapper(m, src, dst) == nil {
+			return mapTextUnmarshaler
+		}
+	}
+	return nil
+}
+
+func mapTextMarshaler(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	var tm encoding.TextMarshaler
+	switch {
+	case src.Type().Implements(textMarshalerTy):
+		tm = src.Interface().(encoding.TextMarshaler)
+	case src.CanAddr():
+		tm = src.Addr().Interface().(encoding.TextMarshaler)
+	default:
+		// If the value is not addressable, we need to create a copy of it
+		// to be able to call a method with a pointer receiver.
+		cpy := reflect.New(src.Type())
+		cpy.Elem().Set(src)
+		tm = cpy.Interface().(encoding.TextMarshaler)
+	}
+	b, err := tm.MarshalText()
+	if err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	if dst.Kind() == reflect.String {
+		dst.SetString(string(b))
+	} else {
+		dst.SetBytes(b)
+	}
+	return nil
+}
+
+func mapTextUnmarshaler(_ *Mapper, ctx *Context, src, dst reflect.Value) error {
+	if ctx.StrictTypes {
+		return NewStrictMappingError(src.Type(), dst.Type())
+	}
+	var b []byte
+	if src.Kind() == reflect.String {
+		b = []byte(src.String())
+	} else {
+		b = src.Bytes()
+	}
+	// The destination may be a pointer if the DestinationValueHook returned
+	// it, otherwise it is always a settable, hence addressable, value.
+	var tu encoding.TextUnmarshaler
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		tu = dst.Interface().(encoding.TextUnmarshaler)
+	} else {
+		tu = dst.Addr().Interface().(encoding.TextUnmarshaler)
+	}
+	if err := tu.UnmarshalText(b); err != nil {
+		return NewInvalidMappingError(src.Type(), dst.Type(), err.Error())
+	}
+	return nil
+}
+
+// implText returns true if the type t or a pointer to t implements the
+// given interface.
+func implText(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(iface))
+}
+
+// isStringOrBytes returns true if the type is a string or a byte slice.
+func isStringOrBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
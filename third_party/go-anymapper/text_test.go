@@ -0,0 +1,87 @@
+package anymapper
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testText struct {
+	val string
+}
+
+func (t testText) MarshalText() ([]byte, error) {
+	if t.val == "" {
+		return nil, errors.New("empty value")
+	}
+	return []byte("text:" + t.val), nil
+}
+
+func (t *testText) UnmarshalText(b []byte) error {
+	if !strings.HasPrefix(string(b), "text:") {
+		return errors.New("missing prefix")
+	}
+	t.val = strings.TrimPrefix(string(b), "text:")
+	return nil
+}
+
+func TestTextMarshaler(t *testing.T) {
+	t.Run("to-string", func(t *testing.T) {
+		var dst string
+		require.NoError(t, Map(testText{val: "foo"}, &dst))
+		assert.Equal(t, "text:foo", dst)
+	})
+	t.Run("pointer-to-string", func(t *testing.T) {
+		var dst string
+		require.NoError(t, Map(&testText{val: "foo"}, &dst))
+		assert.Equal(t, "text:foo", dst)
+	})
+	t.Run("to-bytes", func(t *testing.T) {
+		var dst []byte
+		require.NoError(t, Map(testText{val: "foo"}, &dst))
+		assert.Equal(t, []byte("text:foo"), dst)
+	})
+	t.Run("marshal-error", func(t *testing.T) {
+		var dst string
+		assert.Error(t, Map(testText{}, &dst))
+	})
+	t.Run("strict-types", func(t *testing.T) {
+		m := Default.Copy()
+		m.Context.StrictTypes = true
+		var dst string
+		assert.Error(t, m.Map(testText{val: "foo"}, &dst))
+	})
+}
+
+func TestTextUnmarshaler(t *testing.T) {
+	t.Run("from-string", func(t *testing.T) {
+		var dst testText
+		require.NoError(t, Map("text:foo", &dst))
+		assert.Equal(t, testText{val: "foo"}, dst)
+	})
+	t.Run("from-bytes", func(t *testing.T) {
+		var dst testText
+		require.NoError(t, Map([]byte("text:foo"), &dst))
+		assert.Equal(t, testText{val: "foo"}, dst)
+	})
+	t.Run("to-nil-pointer", func(t *testing.T) {
+		var dst *testText
+		require.NoError(t, Map("text:foo", &dst))
+		require.NotNil(t, dst)
+		assert.Equal(t, testText{val: "foo"}, *dst)
+	})
+	t.Run("struct-field", func(t *testing.T) {
+		var dst struct {
+			Text testText `map:"text"`
+		}
+		require.NoError(t, Map(map[string]any{"text": "text:foo"}, &dst))
+		assert.Equal(t, testText{val: "foo"}, dst.Text)
+	})
+	t.Run("unmarshal-error", func(t *testing.T) {
+		var dst testText
+		assert.Error(t, Map("foo", &dst))
+	})
+}
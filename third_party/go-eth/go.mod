@@ -0,0 +1,30 @@
+module github.com/defiweb/go-eth
+
+go 1.18
+
+require (
+	github.com/btcsuite/btcd v0.22.1
+	github.com/btcsuite/btcutil v1.0.3-0.20201208143702-a53e38424cce
+	github.com/defiweb/go-anymapper v0.0.0-20230411235658-fe3bd78a1f8e
+	github.com/defiweb/go-rlp v0.0.0-20221110234728-569c5d013937
+	github.com/defiweb/go-sigparser v0.0.0-20221125211146-2e4b90d8e269
+	github.com/google/uuid v1.3.0
+	github.com/stretchr/testify v1.8.2
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.4.0
+	nhooyr.io/websocket v1.8.7
+)
+
+require (
+	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.15.15 // indirect
+	github.com/kr/pretty v0.1.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.6.0 // indirect
+	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/defiweb/go-anymapper => ../go-anymapper
@@ -0,0 +1,332 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// HTTP is a Transport implementation that uses the HTTP protocol.
+type HTTP struct {
+	opts HTTPOptions
+	id   uint64
+}
+
+// HTTPOptions contains options for the HTTP transport.
+type HTTPOptions struct {
+	// URL of the HTTP endpoint.
+	URL string
+
+	// HTTPClient is the HTTP client to use. If nil, http.DefaultClient is
+	// used, unless one of the connection pool options below is set.
+	HTTPClient *http.Client
+
+	// MaxIdleConnsPerHost, MaxConnsPerHost and IdleConnTimeout configure the
+	// connection pool, as described in the http.Transport documentation. If
+	// any of them is set, a client with a copy of http.DefaultTransport is
+	// created using these options. They cannot be used together with
+	// HTTPClient.
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+
+	// TLSConfig is an optional TLS configuration used for HTTPS connections,
+	// e.g. to trust a self-signed certificate. It cannot be used together
+	// with HTTPClient.
+	TLSConfig *tls.Config
+
+	// HTTPHeader specifies the HTTP headers to send with each request.
+	HTTPHeader http.Header
+
+	// CompressRequests enables gzip compression of request bodies. The
+	// server must support compressed request bodies.
+	CompressRequests bool
+
+	// DisableResponseDecompression disables gzip compression of responses.
+	// By default, responses are requested with the "Accept-Encoding: gzip"
+	// header and compressed responses are decompressed transparently.
+	// Uncompressed responses are accepted as well.
+	DisableResponseDecompression bool
+
+	// HTTPHeaderFunc is an optional function called before each request
+	// with the request headers, after HTTPHeader is applied. It may modify
+	// the headers, e.g. to set a short-lived authorization token. If it
+	// returns an error, the request is not sent.
+	HTTPHeaderFunc func(ctx context.Context, header http.Header) error
+
+	// OnRequest is an optional function called before a request is sent.
+	OnRequest func(method string)
+
+	// OnResponse is an optional function called after a response is
+	// received or the request failed.
+	OnResponse func(method string, latency time.Duration, err error)
+}
+
+// NewHTTP creates a new HTTP instance.
+func NewHTTP(opts HTTPOptions) (*HTTP, error) {
+	if opts.URL == "" {
+		return nil, errors.New("URL cannot be empty")
+	}
+	pool := opts.MaxIdleConnsPerHost != 0 || opts.MaxConnsPerHost != 0 || opts.IdleConnTimeout != 0
+	if opts.MaxIdleConnsPerHost < 0 || opts.MaxConnsPerHost < 0 || opts.IdleConnTimeout < 0 {
+		return nil, errors.New("connection pool options cannot be negative")
+	}
+	switch {
+	case pool && opts.HTTPClient != nil:
+		return nil, errors.New("connection pool options cannot be used with a custom HTTP client")
+	case opts.TLSConfig != nil && opts.HTTPClient != nil:
+		return nil, errors.New("TLS config cannot be used with a custom HTTP client")
+	case pool || opts.TLSConfig != nil:
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = opts.TLSConfig
+		if opts.MaxIdleConnsPerHost > 0 {
+			t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+			if t.MaxIdleConns > 0 && t.MaxIdleConns < opts.MaxIdleConnsPerHost {
+				t.MaxIdleConns = opts.MaxIdleConnsPerHost
+			}
+		}
+		if opts.MaxConnsPerHost > 0 {
+			t.MaxConnsPerHost = opts.MaxConnsPerHost
+		}
+		if opts.IdleConnTimeout > 0 {
+			t.IdleConnTimeout = opts.IdleConnTimeout
+		}
+		opts.HTTPClient = &http.Client{Transport: t}
+	case opts.HTTPClient == nil:
+		opts.HTTPClient = http.DefaultClient
+	}
+	return &HTTP{opts: opts}, nil
+}
+
+// Call implements the Transport interface.
+func (h *HTTP) Call(ctx context.Context, result any, method string, args ...any) (err error) {
+	if h.opts.OnRequest != nil {
+		h.opts.OnRequest(method)
+	}
+	if h.opts.OnResponse != nil {
+		defer func(t time.Time) { h.opts.OnResponse(method, time.Since(t), err) }(time.Now())
+	}
+	id := atomic.AddUint64(&h.id, 1)
+	rpcReq, err := newRPCRequest(&id, method, args)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC request: %w", err)
+	}
+	httpRes, err := h.send(ctx, rpcReq)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	rpcRes := &rpcResponse{}
+	if err := json.NewDecoder(httpRes.Body).Decode(rpcRes); err != nil {
+		// If the response is not a valid JSON-RPC response, return the HTTP
+		// status code as the error code.
+		return &HTTPError{Code: httpRes.StatusCode}
+	}
+	if rpcRes.Error != nil {
+		return &RPCError{
+			Code:    rpcRes.Error.Code,
+			Message: rpcRes.Error.Message,
+			Data:    rpcRes.Error.Data,
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcRes.Result, result); err != nil {
+		return fmt.Errorf("failed to unmarshal RPC result: %w", err)
+	}
+	return nil
+}
+
+// BatchElem is a single request in a batch call.
+type BatchElem struct {
+	// Method is the JSON-RPC method name.
+	Method string
+
+	// Args are the method arguments.
+	Args []any
+
+	// Result is a pointer to a value into which the result is unmarshaled.
+	// If nil, the result is ignored.
+	Result any
+
+	// Error is set if the request failed. It is either an RPCError, if the
+	// server returned an error, or an error that occurred while processing
+	// the response.
+	Error error
+}
+
+// BatchCall implements the BatchTransport interface.
+//
+// All requests are sent in a single HTTP request. The errors of individual
+// requests are stored in the Error field of the corresponding elements. The
+// returned error is only non-nil if the whole batch failed, e.g. because of
+// a network error.
+//
+// The OnRequest and OnResponse hooks are called for every element.
+func (h *HTTP) BatchCall(ctx context.Context, batch []BatchElem) (err error) {
+	if len(batch) == 0 {
+		return nil
+	}
+	if h.opts.OnRequest != nil {
+		for _, elem := range batch {
+			h.opts.OnRequest(elem.Method)
+		}
+	}
+	if h.opts.OnResponse != nil {
+		defer func(t time.Time) {
+			for _, elem := range batch {
+				if err != nil {
+					h.opts.OnResponse(elem.Method, time.Since(t), err)
+					continue
+				}
+				h.opts.OnResponse(elem.Method, time.Since(t), elem.Error)
+			}
+		}(time.Now())
+	}
+	rpcReqs := make([]rpcRequest, len(batch))
+	elems := make(map[uint64]*BatchElem, len(batch))
+	for i := range batch {
+		id := atomic.AddUint64(&h.id, 1)
+		rpcReq, err := newRPCRequest(&id, batch[i].Method, batch[i].Args)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC request: %w", err)
+		}
+		rpcReqs[i] = rpcReq
+		elems[id] = &batch[i]
+		batch[i].Error = nil
+	}
+	httpRes, err := h.send(ctx, rpcReqs)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	body, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read HTTP response: %w", err)
+	}
+	var rpcRes []rpcResponse
+	if err := json.Unmarshal(body, &rpcRes); err != nil {
+		// Servers that do not support batch requests may return a single
+		// error response.
+		single := &rpcResponse{}
+		if err := json.Unmarshal(body, single); err == nil && single.Error != nil {
+			return &RPCError{
+				Code:    single.Error.Code,
+				Message: single.Error.Message,
+				Data:    single.Error.Data,
+			}
+		}
+		return &HTTPError{Code: httpRes.StatusCode}
+	}
+	for _, res := range rpcRes {
+		if res.ID == nil {
+			continue
+		}
+		elem, ok := elems[*res.ID]
+		if !ok {
+			continue
+		}
+		delete(elems, *res.ID)
+		switch {
+		case res.Error != nil:
+			elem.Error = &RPCError{
+				Code:    res.Error.Code,
+				Message: res.Error.Message,
+				Data:    res.Error.Data,
+			}
+		case elem.Result != nil:
+			if err := json.Unmarshal(res.Result, elem.Result); err != nil {
+				elem.Error = fmt.Errorf("failed to unmarshal RPC result: %w", err)
+			}
+		}
+	}
+	for _, elem := range elems {
+		elem.Error = errors.New("missing response in RPC batch")
+	}
+	return nil
+}
+
+// send sends the given request body to the HTTP endpoint.
+func (h *HTTP) send(ctx context.Context, body any) (*http.Response, error) {
+	httpBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal RPC request: %w", err)
+	}
+	if h.opts.CompressRequests {
+		buf := &bytes.Buffer{}
+		zw := gzip.NewWriter(buf)
+		if _, err := zw.Write(httpBody); err != nil {
+			return nil, fmt.Errorf("failed to compress RPC request: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress RPC request: %w", err)
+		}
+		httpBody = buf.Bytes()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", h.opts.URL, bytes.NewReader(httpBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if h.opts.CompressRequests {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+	if h.opts.DisableResponseDecompression {
+		// Otherwise, the Go HTTP client would request compressed responses
+		// on its own.
+		httpReq.Header.Set("Accept-Encoding", "identity")
+	} else {
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
+	for k, v := range h.opts.HTTPHeader {
+		httpReq.Header[k] = v
+	}
+	if h.opts.HTTPHeaderFunc != nil {
+		if err := h.opts.HTTPHeaderFunc(ctx, httpReq.Header); err != nil {
+			return nil, fmt.Errorf("failed to set HTTP headers: %w", err)
+		}
+	}
+	httpRes, err := h.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	// The Go HTTP client decompresses responses only if the Accept-Encoding
+	// header was not set explicitly, so it must be done here.
+	if !h.opts.DisableResponseDecompression && strings.EqualFold(httpRes.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(httpRes.Body)
+		if err != nil {
+			httpRes.Body.Close()
+			return nil, fmt.Errorf("failed to decompress HTTP response: %w", err)
+		}
+		httpRes.Body = &gzipBody{Reader: zr, body: httpRes.Body}
+		httpRes.Header.Del("Content-Encoding")
+		httpRes.ContentLength = -1
+	}
+	return httpRes, nil
+}
+
+// gzipBody decompresses the response body and closes the underlying body
+// when closed.
+type gzipBody struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+// Close implements the io.Closer interface.
+func (b *gzipBody) Close() error {
+	err := b.Reader.Close()
+	if cerr := b.body.Close(); cerr != nil {
+		return cerr
+	}
+	return err
+}
@@ -0,0 +1,493 @@
+package transport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/defiweb/go-eth/types"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type httpMock struct {
+	*HTTP
+	Request  *http.Request
+	Response *http.Response
+}
+
+func TestHTTP(t *testing.T) {
+	tests := []struct {
+		asserts func(t *testing.T, h *httpMock)
+	}{
+		// Simple request:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				h.Response = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))),
+				}
+				result := types.Number{}
+				require.NoError(t, h.Call(context.Background(), &result, "eth_getBalance", "0x1111111111111111111111111111111111111111", "latest"))
+				assert.Equal(t, h.Request.URL.String(), "http://localhost")
+				assert.Equal(t, h.Request.Method, "POST")
+				assert.Equal(t, h.Request.Header.Get("X-Test"), "test")
+				assert.Equal(t, h.Request.Header.Get("Content-Type"), "application/json")
+				requestBody, err := io.ReadAll(h.Request.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"id":1, "jsonrpc":"2.0", "method":"eth_getBalance", "params":["0x1111111111111111111111111111111111111111", "latest"]}`, string(requestBody))
+				assert.Equal(t, result.Big().String(), "1")
+			},
+		},
+		// ID must increment:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				// First request:
+				h.Response = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))),
+				}
+				require.NoError(t, h.Call(context.Background(), nil, "eth_a"))
+				requestBody, err := io.ReadAll(h.Request.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"id":1, "jsonrpc":"2.0", "method":"eth_a", "params":[]}`, string(requestBody))
+
+				// Second request:
+				h.Response = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":2, "jsonrpc":"2.0", "result":"0x2"}`))),
+				}
+				require.NoError(t, h.Call(context.Background(), nil, "eth_b"))
+				requestBody, err = io.ReadAll(h.Request.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"id":2, "jsonrpc":"2.0", "method":"eth_b", "params":[]}`, string(requestBody))
+			},
+		},
+		// Error response:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				h.Response = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":1, "jsonrpc":"2.0", "error":{"code":-32601, "message":"Method not found"}}`))),
+				}
+				result := types.Number{}
+				err := h.Call(context.Background(), &result, "eth_a")
+				assert.Error(t, err)
+				assert.Equal(t, "RPC error: -32601 Method not found", err.Error())
+			},
+		},
+		// Error response with non-200 status code:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				h.Response = &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"id":1, "jsonrpc":"2.0", "error":{"code":-32005, "message":"Limit exceeded"}}`))),
+				}
+				result := types.Number{}
+				err := h.Call(context.Background(), &result, "eth_a")
+				assert.Error(t, err)
+				assert.Equal(t, "RPC error: -32005 Limit exceeded", err.Error())
+			},
+		},
+		// Error response with non-200 status code and empty body:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				h.Response = &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Body:       io.NopCloser(bytes.NewReader([]byte(``))),
+				}
+				result := types.Number{}
+				err := h.Call(context.Background(), &result, "eth_a")
+				assert.Error(t, err)
+				assert.Equal(t, "HTTP error: 429 Too Many Requests", err.Error())
+			},
+		},
+		// Invalid response:
+		{
+			asserts: func(t *testing.T, h *httpMock) {
+				h.Response = &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{`))),
+				}
+				result := types.Number{}
+				err := h.Call(context.Background(), &result, "eth_a")
+				assert.Error(t, err)
+			},
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			h := &httpMock{}
+			h.HTTP, _ = NewHTTP(HTTPOptions{
+				URL: "http://localhost",
+				HTTPHeader: http.Header{
+					"X-Test": []string{"test"},
+				},
+				HTTPClient: &http.Client{
+					Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+						h.Request = req
+						return h.Response, nil
+					}),
+				},
+			})
+			tt.asserts(t, h)
+		})
+	}
+}
+
+func newHTTPMock(t *testing.T, opts HTTPOptions, res func() *http.Response) *httpMock {
+	h := &httpMock{}
+	opts.URL = "http://localhost"
+	opts.HTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			h.Request = req
+			return res(), nil
+		}),
+	}
+	var err error
+	h.HTTP, err = NewHTTP(opts)
+	require.NoError(t, err)
+	return h
+}
+
+func okResponse(body string) func() *http.Response {
+	return func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+}
+
+func TestHTTP_HTTPHeaderFunc(t *testing.T) {
+	t.Run("set-header", func(t *testing.T) {
+		calls := 0
+		h := newHTTPMock(t, HTTPOptions{
+			HTTPHeader: http.Header{"X-Static": []string{"static"}},
+			HTTPHeaderFunc: func(ctx context.Context, header http.Header) error {
+				calls++
+				// Static headers are applied before the function is called.
+				assert.Equal(t, "static", header.Get("X-Static"))
+				header.Set("Authorization", fmt.Sprintf("Bearer token-%d", calls))
+				return nil
+			},
+		}, okResponse(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+		require.NoError(t, h.Call(context.Background(), nil, "eth_a"))
+		assert.Equal(t, "Bearer token-1", h.Request.Header.Get("Authorization"))
+		require.NoError(t, h.Call(context.Background(), nil, "eth_a"))
+		assert.Equal(t, "Bearer token-2", h.Request.Header.Get("Authorization"))
+	})
+	t.Run("error", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{
+			HTTPHeaderFunc: func(ctx context.Context, header http.Header) error {
+				return errors.New("no token")
+			},
+		}, okResponse(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+		err := h.Call(context.Background(), nil, "eth_a")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no token")
+		assert.Nil(t, h.Request, "request must not be sent")
+	})
+}
+
+func TestHTTP_Hooks(t *testing.T) {
+	var requests, responses []string
+	h := newHTTPMock(t, HTTPOptions{
+		OnRequest: func(method string) {
+			// The request is not sent yet when the hook is called.
+			requests = append(requests, method)
+		},
+		OnResponse: func(method string, latency time.Duration, err error) {
+			assert.GreaterOrEqual(t, latency, time.Duration(0))
+			responses = append(responses, fmt.Sprintf("%s:%v", method, err))
+		},
+	}, okResponse(`{"id":1, "jsonrpc":"2.0", "error":{"code":-32000, "message":"failed"}}`))
+	require.Error(t, h.Call(context.Background(), nil, "eth_a"))
+	assert.Equal(t, []string{"eth_a"}, requests)
+	assert.Equal(t, []string{"eth_a:RPC error: -32000 failed"}, responses)
+
+	// The OnResponse hook is also called if the request cannot be sent.
+	h.opts.HTTPClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}),
+	}
+	require.Error(t, h.Call(context.Background(), nil, "eth_b"))
+	assert.Equal(t, []string{"eth_a", "eth_b"}, requests)
+	require.Len(t, responses, 2)
+	assert.Contains(t, responses[1], "connection refused")
+}
+
+func TestHTTP_BatchCall(t *testing.T) {
+	t.Run("results-and-errors", func(t *testing.T) {
+		// Responses are returned out of order, as allowed by the JSON-RPC
+		// specification.
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(`[
+			{"id":3, "jsonrpc":"2.0", "result":"0x3"},
+			{"id":1, "jsonrpc":"2.0", "result":"0x1"},
+			{"id":2, "jsonrpc":"2.0", "error":{"code":-32000, "message":"execution reverted"}}
+		]`))
+		var res1, res3 types.Number
+		batch := []BatchElem{
+			{Method: "eth_a", Args: []any{"foo"}, Result: &res1},
+			{Method: "eth_b"},
+			{Method: "eth_c", Result: &res3},
+		}
+		require.NoError(t, h.BatchCall(context.Background(), batch))
+		requestBody, err := io.ReadAll(h.Request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"id":1, "jsonrpc":"2.0", "method":"eth_a", "params":["foo"]},
+			{"id":2, "jsonrpc":"2.0", "method":"eth_b", "params":[]},
+			{"id":3, "jsonrpc":"2.0", "method":"eth_c", "params":[]}
+		]`, string(requestBody))
+		assert.NoError(t, batch[0].Error)
+		assert.Equal(t, "1", res1.Big().String())
+		assert.Equal(t, &RPCError{Code: -32000, Message: "execution reverted"}, batch[1].Error)
+		assert.NoError(t, batch[2].Error)
+		assert.Equal(t, "3", res3.Big().String())
+	})
+	t.Run("missing-response", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(`[{"id":1, "jsonrpc":"2.0", "result":"0x1"}]`))
+		batch := []BatchElem{{Method: "eth_a"}, {Method: "eth_b"}}
+		require.NoError(t, h.BatchCall(context.Background(), batch))
+		assert.NoError(t, batch[0].Error)
+		assert.Error(t, batch[1].Error)
+	})
+	t.Run("invalid-result", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(`[{"id":1, "jsonrpc":"2.0", "result":"foo"}]`))
+		var res types.Number
+		batch := []BatchElem{{Method: "eth_a", Result: &res}}
+		require.NoError(t, h.BatchCall(context.Background(), batch))
+		assert.Error(t, batch[0].Error)
+	})
+	t.Run("batch-not-supported", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(`{"id":null, "jsonrpc":"2.0", "error":{"code":-32600, "message":"batch not supported"}}`))
+		err := h.BatchCall(context.Background(), []BatchElem{{Method: "eth_a"}})
+		assert.Equal(t, &RPCError{Code: -32600, Message: "batch not supported"}, err)
+	})
+	t.Run("invalid-response", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader(`bad gateway`)),
+			}
+		})
+		err := h.BatchCall(context.Background(), []BatchElem{{Method: "eth_a"}})
+		assert.Equal(t, &HTTPError{Code: http.StatusBadGateway}, err)
+	})
+	t.Run("hooks", func(t *testing.T) {
+		var requests, responses []string
+		h := newHTTPMock(t, HTTPOptions{
+			OnRequest: func(method string) { requests = append(requests, method) },
+			OnResponse: func(method string, _ time.Duration, err error) {
+				responses = append(responses, fmt.Sprintf("%s:%v", method, err != nil))
+			},
+		}, okResponse(`[{"id":1, "jsonrpc":"2.0", "result":"0x1"}, {"id":2, "jsonrpc":"2.0", "error":{"code":1, "message":"err"}}]`))
+		require.NoError(t, h.BatchCall(context.Background(), []BatchElem{{Method: "eth_a"}, {Method: "eth_b"}}))
+		assert.Equal(t, []string{"eth_a", "eth_b"}, requests)
+		assert.Equal(t, []string{"eth_a:false", "eth_b:true"}, responses)
+	})
+	t.Run("empty", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(``))
+		require.NoError(t, h.BatchCall(context.Background(), nil))
+		assert.Nil(t, h.Request)
+	})
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func gzipBytes(t *testing.T, data string) []byte {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	_, err := zw.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestHTTP_Compress(t *testing.T) {
+	t.Run("compressed-response", func(t *testing.T) {
+		body := &closeRecorder{Reader: bytes.NewReader(gzipBytes(t, `{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))}
+		h := newHTTPMock(t, HTTPOptions{CompressRequests: true}, func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Body:       body,
+			}
+		})
+		result := types.Number{}
+		require.NoError(t, h.Call(context.Background(), &result, "eth_a", "0x1"))
+		assert.Equal(t, "1", result.Big().String())
+		assert.True(t, body.closed, "underlying body must be closed")
+
+		// The request body must be compressed.
+		assert.Equal(t, "gzip", h.Request.Header.Get("Content-Encoding"))
+		assert.Equal(t, "gzip", h.Request.Header.Get("Accept-Encoding"))
+		assert.Equal(t, "application/json", h.Request.Header.Get("Content-Type"))
+		zr, err := gzip.NewReader(h.Request.Body)
+		require.NoError(t, err)
+		requestBody, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1, "jsonrpc":"2.0", "method":"eth_a", "params":["0x1"]}`, string(requestBody))
+	})
+	t.Run("uncompressed-response", func(t *testing.T) {
+		// Servers may ignore the Accept-Encoding header.
+		h := newHTTPMock(t, HTTPOptions{}, okResponse(`{"id":1, "jsonrpc":"2.0", "result":"0x2"}`))
+		result := types.Number{}
+		require.NoError(t, h.Call(context.Background(), &result, "eth_a"))
+		assert.Equal(t, "2", result.Big().String())
+	})
+	t.Run("compressed-batch", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{}, func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Encoding": []string{"GZIP"}},
+				Body:       io.NopCloser(bytes.NewReader(gzipBytes(t, `[{"id":1, "jsonrpc":"2.0", "result":"0x1"}]`))),
+			}
+		})
+		result := types.Number{}
+		batch := []BatchElem{{Method: "eth_a", Result: &result}}
+		require.NoError(t, h.BatchCall(context.Background(), batch))
+		require.NoError(t, batch[0].Error)
+		assert.Equal(t, "1", result.Big().String())
+	})
+	t.Run("invalid-compressed-response", func(t *testing.T) {
+		body := &closeRecorder{Reader: strings.NewReader(`not gzip`)}
+		h := newHTTPMock(t, HTTPOptions{}, func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Body:       body,
+			}
+		})
+		err := h.Call(context.Background(), nil, "eth_a")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decompress HTTP response")
+		assert.True(t, body.closed, "body must be closed on error")
+	})
+	t.Run("default", func(t *testing.T) {
+		// Responses are decompressed by default, but requests are not
+		// compressed.
+		h := newHTTPMock(t, HTTPOptions{}, func() *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+				Body:       io.NopCloser(bytes.NewReader(gzipBytes(t, `{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))),
+			}
+		})
+		result := types.Number{}
+		require.NoError(t, h.Call(context.Background(), &result, "eth_a"))
+		assert.Equal(t, "1", result.Big().String())
+		assert.Empty(t, h.Request.Header.Get("Content-Encoding"))
+		assert.Equal(t, "gzip", h.Request.Header.Get("Accept-Encoding"))
+		requestBody, err := io.ReadAll(h.Request.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":1, "jsonrpc":"2.0", "method":"eth_a", "params":[]}`, string(requestBody))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		h := newHTTPMock(t, HTTPOptions{DisableResponseDecompression: true}, okResponse(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+		require.NoError(t, h.Call(context.Background(), nil, "eth_a"))
+		assert.Empty(t, h.Request.Header.Get("Content-Encoding"))
+		assert.Equal(t, "identity", h.Request.Header.Get("Accept-Encoding"))
+	})
+}
+
+func TestNewHTTP_ConnectionPool(t *testing.T) {
+	t.Run("options", func(t *testing.T) {
+		h, err := NewHTTP(HTTPOptions{
+			URL:                 "http://localhost",
+			MaxIdleConnsPerHost: 1000,
+			MaxConnsPerHost:     10,
+			IdleConnTimeout:     time.Minute,
+		})
+		require.NoError(t, err)
+		require.NotSame(t, http.DefaultClient, h.opts.HTTPClient)
+		tr, ok := h.opts.HTTPClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, 1000, tr.MaxIdleConnsPerHost)
+		assert.Equal(t, 1000, tr.MaxIdleConns, "MaxIdleConns must not be lower than MaxIdleConnsPerHost")
+		assert.Equal(t, 10, tr.MaxConnsPerHost)
+		assert.Equal(t, time.Minute, tr.IdleConnTimeout)
+
+		// The default transport must not be modified.
+		def := http.DefaultTransport.(*http.Transport)
+		assert.NotEqual(t, 1000, def.MaxIdleConnsPerHost)
+		assert.NotEqual(t, 10, def.MaxConnsPerHost)
+	})
+	t.Run("default-client", func(t *testing.T) {
+		h, err := NewHTTP(HTTPOptions{URL: "http://localhost"})
+		require.NoError(t, err)
+		assert.Same(t, http.DefaultClient, h.opts.HTTPClient)
+	})
+	t.Run("negative", func(t *testing.T) {
+		_, err := NewHTTP(HTTPOptions{URL: "http://localhost", MaxConnsPerHost: -1})
+		assert.Error(t, err)
+		_, err = NewHTTP(HTTPOptions{URL: "http://localhost", MaxIdleConnsPerHost: -1})
+		assert.Error(t, err)
+		_, err = NewHTTP(HTTPOptions{URL: "http://localhost", IdleConnTimeout: -time.Second})
+		assert.Error(t, err)
+	})
+	t.Run("custom-client", func(t *testing.T) {
+		_, err := NewHTTP(HTTPOptions{
+			URL:             "http://localhost",
+			HTTPClient:      &http.Client{},
+			MaxConnsPerHost: 1,
+		})
+		assert.Error(t, err)
+	})
+	t.Run("max-conns-per-host", func(t *testing.T) {
+		var active, maxActive int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+		}))
+		defer srv.Close()
+		h, err := NewHTTP(HTTPOptions{URL: srv.URL, MaxConnsPerHost: 1})
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, h.Call(context.Background(), nil, "eth_a"))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&maxActive))
+	})
+}
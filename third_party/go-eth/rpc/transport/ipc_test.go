@@ -0,0 +1,175 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIPCTestServer starts an IPC server on a unix socket and returns the
+// socket path. The handler is called for every request with an encoder that
+// can be used to send responses and notifications.
+func newIPCTestServer(t *testing.T, handler func(enc *json.Encoder, req rpcRequest)) string {
+	path := filepath.Join(t.TempDir(), "test.ipc")
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	t.Cleanup(func() {
+		_ = ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			if handler == nil {
+				// Do not read requests, as if the node is stuck.
+				continue
+			}
+			go func() {
+				dec := json.NewDecoder(conn)
+				enc := json.NewEncoder(conn)
+				for {
+					var req rpcRequest
+					if err := dec.Decode(&req); err != nil {
+						return
+					}
+					handler(enc, req)
+				}
+			}()
+		}
+	}()
+	return path
+}
+
+func newTestIPC(t *testing.T, opts IPCOptions) *IPC {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	opts.Context = ctx
+	if opts.ErrorCh == nil {
+		opts.ErrorCh = make(chan error, 100)
+	}
+	ipc, err := NewIPC(opts)
+	require.NoError(t, err)
+	return ipc
+}
+
+func TestIPC_Hooks(t *testing.T) {
+	path := newIPCTestServer(t, func(enc *json.Encoder, req rpcRequest) {
+		switch req.Method {
+		case "eth_subscribe":
+			_ = enc.Encode(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, *req.ID)))
+		case "test_notify":
+			_ = enc.Encode(json.RawMessage(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":"foo"}}`))
+			_ = enc.Encode(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":true}`, *req.ID)))
+		case "eth_fail":
+			_ = enc.Encode(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32000,"message":"failed"}}`, *req.ID)))
+		default:
+			_ = enc.Encode(json.RawMessage(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, *req.ID)))
+		}
+	})
+
+	var (
+		mu        sync.Mutex
+		requests  []string
+		responses []string
+	)
+	ipc := newTestIPC(t, IPCOptions{
+		Path:   path,
+		Timout: time.Second,
+		OnRequest: func(method string) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, method)
+		},
+		OnResponse: func(method string, latency time.Duration, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.GreaterOrEqual(t, latency, time.Duration(0))
+			responses = append(responses, fmt.Sprintf("%s:%v", method, err != nil))
+		},
+	})
+
+	require.NoError(t, ipc.Call(context.Background(), nil, "eth_a"))
+	require.Error(t, ipc.Call(context.Background(), nil, "eth_fail"))
+	ch, _, err := ipc.Subscribe(context.Background(), "newHeads")
+	require.NoError(t, err)
+	go func() { _ = ipc.Call(context.Background(), nil, "test_notify") }()
+	select {
+	case msg := <-ch:
+		assert.Equal(t, json.RawMessage(`"foo"`), msg)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for notification")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"eth_a", "eth_fail", "eth_subscribe", "test_notify"}, requests)
+
+	// Notifications are reported with the notification method name.
+	assert.Equal(t, []string{"eth_a:false", "eth_fail:true", "eth_subscribe:false", "eth_subscription:false"}, responses[:4])
+}
+
+func TestIPC_Timeout(t *testing.T) {
+	t.Run("no-response", func(t *testing.T) {
+		path := newIPCTestServer(t, func(enc *json.Encoder, req rpcRequest) {})
+		ipc := newTestIPC(t, IPCOptions{Path: path, Timout: 50 * time.Millisecond})
+		start := time.Now()
+		err := ipc.Call(context.Background(), nil, "eth_a")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+	t.Run("stuck-write", func(t *testing.T) {
+		// The server does not read requests, so after the socket buffer is
+		// full, the writer is blocked. The timeout must still apply to calls
+		// waiting for the writer.
+		path := newIPCTestServer(t, nil)
+		ipc := newTestIPC(t, IPCOptions{Path: path, Timout: 100 * time.Millisecond})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			// The first call blocks the writer, because the request does
+			// not fit into the socket buffer.
+			err := ipc.Call(context.Background(), nil, "eth_a", strings.Repeat("a", 16<<20))
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+			// The second call cannot be passed to the writer.
+			err = ipc.Call(context.Background(), nil, "eth_b")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "call did not time out")
+		}
+	})
+	t.Run("context", func(t *testing.T) {
+		// The context passed to Call can be shorter than the timeout.
+		path := newIPCTestServer(t, func(enc *json.Encoder, req rpcRequest) {})
+		ipc := newTestIPC(t, IPCOptions{Path: path, Timout: time.Minute})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, ipc.Call(ctx, nil, "eth_a"), context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,356 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+)
+
+// stream is a helper for handling JSON-RPC streams.
+type stream struct {
+	mu  sync.RWMutex
+	ctx context.Context
+
+	writerCh chan rpcRequest  // Channel for sending requests used by structs that embed stream.
+	readerCh chan rpcResponse // Channel for receiving responses used by structs that embed stream.
+	errCh    chan error       // Channel to which errors are sent.
+	timeout  time.Duration    // Timeout for requests.
+	onClose  func()           // Callback that is called when the stream is closed.
+
+	onRequest  func(method string)                                   // Optional hook called before a request is sent.
+	onResponse func(method string, latency time.Duration, err error) // Optional hook called after a request is finished.
+
+	// State fields. Should not be accessed by structs that embed stream.
+	id        uint64                          // Request ID counter.
+	calls     map[uint64]chan rpcResponse     // Map of request IDs to channels.
+	subs      map[string]chan json.RawMessage // Map of subscription IDs to channels.
+	subParams map[string][]any                // Map of subscription IDs to eth_subscribe parameters.
+	subIDs    map[string]string               // Map of server-side subscription IDs to subscription IDs.
+}
+
+// ErrConnectionLost is returned by calls that were pending when the
+// connection was lost. Responses to requests sent over the lost connection
+// never arrive, so such calls are failed immediately.
+var ErrConnectionLost = errors.New("connection lost")
+
+// SubscriptionGapError is sent to the error channel when a subscription was
+// registered again after the connection was re-established. Notifications
+// sent while the connection was down are lost, so the subscriber may need
+// to resync.
+type SubscriptionGapError struct {
+	ID string // ID of the subscription, as returned by Subscribe.
+}
+
+// Error implements the error interface.
+func (e *SubscriptionGapError) Error() string {
+	return fmt.Sprintf("subscription %s may have missed notifications", e.ID)
+}
+
+// initStream initializes the stream struct with default values and starts
+// goroutines.
+func (s *stream) initStream() *stream {
+	s.writerCh = make(chan rpcRequest)
+	s.readerCh = make(chan rpcResponse)
+	s.calls = make(map[uint64]chan rpcResponse)
+	s.subs = make(map[string]chan json.RawMessage)
+	s.subParams = make(map[string][]any)
+	s.subIDs = make(map[string]string)
+	go s.streamRoutine()
+	go s.contextHandlerRoutine()
+	return s
+}
+
+// Call implements the Transport interface.
+func (s *stream) Call(ctx context.Context, result any, method string, args ...any) (err error) {
+	ctx, ctxCancel := context.WithTimeout(ctx, s.timeout)
+	defer ctxCancel()
+
+	if s.onRequest != nil {
+		s.onRequest(method)
+	}
+	if s.onResponse != nil {
+		defer func(t time.Time) { s.onResponse(method, time.Since(t), err) }(time.Now())
+	}
+
+	// Prepare the RPC request.
+	id := atomic.AddUint64(&s.id, 1)
+	req, err := newRPCRequest(&id, method, args)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC request: %w", err)
+	}
+
+	// The response is handled by the streamRoutine. It will send the response
+	// to the ch channel. The channel must be registered before the request is
+	// sent, otherwise the response may arrive before it is registered. It is
+	// buffered, so the streamRoutine is not blocked if the call has already
+	// timed out.
+	ch := make(chan rpcResponse, 1)
+	s.addCallCh(id, ch)
+	defer s.delCallCh(id)
+
+	// Send the request. The writer may be blocked if the connection is stuck,
+	// so the timeout must also apply here.
+	select {
+	case s.writerCh <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Wait for the response.
+	select {
+	case res, ok := <-ch:
+		if !ok {
+			// The channel is closed if the transport was closed or the
+			// connection was lost before the response was received.
+			if err := s.ctx.Err(); err != nil {
+				return err
+			}
+			return ErrConnectionLost
+		}
+		if res.Error != nil {
+			return &RPCError{
+				Code:    res.Error.Code,
+				Message: res.Error.Message,
+				Data:    res.Error.Data,
+			}
+		}
+		if result != nil {
+			if err := json.Unmarshal(res.Result, result); err != nil {
+				return fmt.Errorf("failed to unmarshal RPC result: %w", err)
+			}
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// Subscribe implements the SubscriptionTransport interface.
+func (s *stream) Subscribe(ctx context.Context, method string, args ...any) (chan json.RawMessage, string, error) {
+	rawID := types.Number{}
+	params := make([]any, 0, 2)
+	params = append(params, method)
+	if len(args) > 0 {
+		params = append(params, args)
+	}
+	if err := s.Call(ctx, &rawID, "eth_subscribe", params...); err != nil {
+		return nil, "", err
+	}
+	id := rawID.String()
+	ch := make(chan json.RawMessage)
+	s.addSubCh(id, ch, params)
+	return ch, id, nil
+}
+
+// Unsubscribe implements the SubscriptionTransport interface.
+func (s *stream) Unsubscribe(ctx context.Context, id string) error {
+	remoteID, ok := s.delSubCh(id)
+	if !ok {
+		return errors.New("unknown subscription")
+	}
+	num, err := types.NumberFromHex(remoteID)
+	if err != nil {
+		return fmt.Errorf("invalid subscription id: %w", err)
+	}
+	return s.Call(ctx, nil, "eth_unsubscribe", num)
+}
+
+// resubscribe registers all active subscriptions again. It must be called
+// after the connection is re-established, because subscriptions are bound
+// to a connection. Subscribers keep receiving notifications on the same
+// channels and can still use the original subscription IDs. For every
+// registered subscription, a SubscriptionGapError is sent to the error
+// channel. Subscriptions that cannot be registered again are closed.
+func (s *stream) resubscribe() {
+	s.mu.RLock()
+	subs := make(map[string][]any, len(s.subParams))
+	for id, params := range s.subParams {
+		subs[id] = params
+	}
+	s.mu.RUnlock()
+	for id, params := range subs {
+		rawID := types.Number{}
+		if err := s.Call(s.ctx, &rawID, "eth_subscribe", params...); err != nil {
+			s.delSubCh(id)
+			if s.errCh != nil {
+				s.errCh <- fmt.Errorf("failed to resubscribe %s: %w", id, err)
+			}
+			continue
+		}
+		if !s.setRemoteSubID(id, rawID.String()) {
+			continue
+		}
+		if s.errCh != nil {
+			s.errCh <- &SubscriptionGapError{ID: id}
+		}
+	}
+}
+
+// failCalls fails all pending calls with the ErrConnectionLost error. It must
+// be called when the connection is lost.
+func (s *stream) failCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ch := range s.calls {
+		close(ch)
+		delete(s.calls, id)
+	}
+}
+
+// closeSubs closes all subscription channels. It is used to notify
+// subscribers when the connection is lost and cannot be re-established.
+func (s *stream) closeSubs() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.subs {
+		close(ch)
+	}
+	s.subs = make(map[string]chan json.RawMessage)
+	s.subParams = make(map[string][]any)
+	s.subIDs = make(map[string]string)
+}
+
+// readerRoutine reads messages from the stream connection and dispatches
+// them to the appropriate channel.
+func (s *stream) streamRoutine() {
+	for {
+		res, ok := <-s.readerCh
+		if !ok {
+			return
+		}
+		switch {
+		case res.ID == nil:
+			// If the ID is nil, it is a subscription notification.
+			sub := &rpcSubscription{}
+			if err := json.Unmarshal(res.Params, sub); err != nil {
+				if s.errCh != nil {
+					s.errCh <- fmt.Errorf("failed to unmarshal subscription: %w", err)
+				}
+				continue
+			}
+			if s.onResponse != nil {
+				s.onResponse(res.Method, 0, nil)
+			}
+			s.subChSend(sub.Subscription.String(), sub.Result)
+		default:
+			// If the ID is not nil, it is a response to a request.
+			s.callChSend(*res.ID, res)
+		}
+	}
+}
+
+// contextHandlerRoutine closes the connection when the context is canceled.
+func (s *stream) contextHandlerRoutine() {
+	<-s.ctx.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.calls {
+		close(ch)
+	}
+	for _, ch := range s.subs {
+		close(ch)
+	}
+	s.calls = nil
+	s.subs = nil
+	s.subParams = nil
+	s.subIDs = nil
+	if s.onClose != nil {
+		s.onClose()
+	}
+}
+
+// addCallCh adds a channel to the calls map. Incoming response that match the
+// id will be sent to the given channel. Because message ids are unique, the
+// channel must be deleted after the response is received using delCallCh.
+func (s *stream) addCallCh(id uint64, ch chan rpcResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[id] = ch
+}
+
+// addSubCh adds a channel to the subs map. Incoming subscription notifications
+// that match the id will be sent to the given channel. The params are used
+// to register the subscription again after reconnecting.
+func (s *stream) addSubCh(id string, ch chan json.RawMessage, params []any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs[id] = ch
+	s.subParams[id] = params
+	s.subIDs[id] = id
+}
+
+// setRemoteSubID updates the server-side ID of the subscription after it was
+// registered again. It returns false if the subscription no longer exists.
+func (s *stream) setRemoteSubID(id, remoteID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[id]; !ok {
+		return false
+	}
+	for r, i := range s.subIDs {
+		if i == id {
+			delete(s.subIDs, r)
+		}
+	}
+	s.subIDs[remoteID] = id
+	return true
+}
+
+// delCallCh deletes a channel from the calls map.
+func (s *stream) delCallCh(id uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.calls[id]; ok {
+		close(ch)
+		delete(s.calls, id)
+		return true
+	}
+	return false
+}
+
+// delSubCh deletes a channel from the subs map. It returns the server-side
+// ID of the subscription.
+func (s *stream) delSubCh(id string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch, ok := s.subs[id]
+	if !ok {
+		return "", false
+	}
+	close(ch)
+	delete(s.subs, id)
+	delete(s.subParams, id)
+	remoteID := id
+	for r, i := range s.subIDs {
+		if i == id {
+			remoteID = r
+			delete(s.subIDs, r)
+		}
+	}
+	return remoteID, true
+}
+
+// callChSend sends a response to the channel that matches the id.
+func (s *stream) callChSend(id uint64, res rpcResponse) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if ch := s.calls[id]; ch != nil {
+		ch <- res
+	}
+}
+
+// subChSend sends a subscription notification to the channel that matches the
+// id.
+func (s *stream) subChSend(id string, res json.RawMessage) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if ch := s.subs[s.subIDs[id]]; ch != nil {
+		ch <- res
+	}
+}
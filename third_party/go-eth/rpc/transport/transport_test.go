@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	headerCh := make(chan http.Header, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerCh <- r.Header.Clone()
+		if r.Header.Get("Upgrade") == "websocket" {
+			conn, err := websocket.Accept(w, r, nil)
+			if err != nil {
+				return
+			}
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	opts := Options{
+		HTTPHeader: http.Header{"X-Static": []string{"static"}},
+		HTTPHeaderFunc: func(ctx context.Context, header http.Header) error {
+			header.Set("X-Dynamic", "dynamic")
+			return nil
+		},
+	}
+	t.Run("http", func(t *testing.T) {
+		tr, err := NewWithOptions(context.Background(), srv.URL, opts)
+		require.NoError(t, err)
+		require.IsType(t, &HTTP{}, tr)
+		require.NoError(t, tr.Call(context.Background(), nil, "eth_a"))
+		h := <-headerCh
+		assert.Equal(t, "static", h.Get("X-Static"))
+		assert.Equal(t, "dynamic", h.Get("X-Dynamic"))
+	})
+	t.Run("websocket", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tr, err := NewWithOptions(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), opts)
+		require.NoError(t, err)
+		require.IsType(t, &Websocket{}, tr)
+		h := <-headerCh
+		// Static headers are sent with the handshake request. The header
+		// function is used only by the HTTP transport.
+		assert.Equal(t, "static", h.Get("X-Static"))
+		assert.Empty(t, h.Get("X-Dynamic"))
+	})
+	t.Run("unsupported-scheme", func(t *testing.T) {
+		_, err := NewWithOptions(context.Background(), "ftp://localhost", opts)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewWithOptions_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") == "websocket" {
+			conn, err := websocket.Accept(w, r, nil)
+			if err != nil {
+				return
+			}
+			var req struct {
+				ID uint64 `json:"id"`
+			}
+			if err := wsjson.Read(r.Context(), conn, &req); err != nil {
+				return
+			}
+			_ = wsjson.Write(r.Context(), conn, json.RawMessage(fmt.Sprintf(`{"id":%d, "jsonrpc":"2.0", "result":"0x1"}`, req.ID)))
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1, "jsonrpc":"2.0", "result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	// The server uses a self-signed certificate.
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	tlsConfig := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	httpsURL := srv.URL
+	wssURL := "wss" + strings.TrimPrefix(srv.URL, "https")
+
+	t.Run("https", func(t *testing.T) {
+		tr, err := NewWithOptions(context.Background(), httpsURL, Options{TLSConfig: tlsConfig})
+		require.NoError(t, err)
+		require.NoError(t, tr.Call(context.Background(), nil, "eth_a"))
+	})
+	t.Run("https-untrusted", func(t *testing.T) {
+		tr, err := NewWithOptions(context.Background(), httpsURL, Options{})
+		require.NoError(t, err)
+		err = tr.Call(context.Background(), nil, "eth_a")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "certificate")
+	})
+	t.Run("wss", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tr, err := NewWithOptions(ctx, wssURL, Options{TLSConfig: tlsConfig})
+		require.NoError(t, err)
+		require.NoError(t, tr.Call(context.Background(), nil, "eth_a"))
+	})
+	t.Run("wss-untrusted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := NewWithOptions(ctx, wssURL, Options{})
+		require.Error(t, err)
+	})
+	t.Run("custom-client", func(t *testing.T) {
+		_, err := NewHTTP(HTTPOptions{URL: httpsURL, HTTPClient: &http.Client{}, TLSConfig: tlsConfig})
+		assert.Error(t, err)
+		_, err = NewWebsocket(WebsocketOptions{
+			Context:    context.Background(),
+			URL:        wssURL,
+			HTTPClient: &http.Client{},
+			TLSConfig:  tlsConfig,
+		})
+		assert.Error(t, err)
+	})
+}
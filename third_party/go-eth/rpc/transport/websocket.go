@@ -0,0 +1,258 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+// Websocket is a Transport implementation that uses the websocket
+// protocol.
+type Websocket struct {
+	*stream
+	opts WebsocketOptions
+
+	connMu sync.RWMutex
+	conn   *websocket.Conn
+	closed bool
+}
+
+// WebsocketOptions contains options for the websocket transport.
+type WebsocketOptions struct {
+	// Context used to close the connection.
+	Context context.Context
+
+	// URL of the websocket endpoint.
+	URL string
+
+	// HTTPClient is the HTTP client to use. If nil, http.DefaultClient is
+	// used.
+	HTTPClient *http.Client
+
+	// HTTPHeader specifies the HTTP headers to be included in the
+	// websocket handshake request.
+	HTTPHeader http.Header
+
+	// TLSConfig is an optional TLS configuration used for wss connections,
+	// e.g. to trust a self-signed certificate. It cannot be used together
+	// with HTTPClient.
+	TLSConfig *tls.Config
+
+	// Timeout is the timeout for the websocket requests. Default is 60s.
+	Timout time.Duration
+
+	// ErrorCh is an optional channel used to report errors.
+	ErrorCh chan error
+
+	// MaxReconnectAttempts is the maximum number of attempts to re-establish
+	// the connection after it is lost. If zero, the connection is not
+	// re-established.
+	//
+	// After reconnecting, active subscriptions are registered again and
+	// a SubscriptionGapError is sent to ErrorCh for each of them. If the
+	// connection cannot be re-established, subscription channels are closed.
+	MaxReconnectAttempts int
+
+	// ReconnectBackoff returns the delay before the given reconnect attempt,
+	// starting from zero. Default is an exponential backoff starting at 1s,
+	// up to 30s.
+	ReconnectBackoff func(int) time.Duration
+
+	// OnRequest is an optional function called before a request is sent.
+	OnRequest func(method string)
+
+	// OnResponse is an optional function called after a response is
+	// received or the request failed. It is also called for every
+	// subscription notification, with zero latency.
+	OnResponse func(method string, latency time.Duration, err error)
+
+	// OnReconnect is an optional function called after the connection is
+	// re-established.
+	OnReconnect func()
+}
+
+// NewWebsocket creates a new Websocket instance.
+func NewWebsocket(opts WebsocketOptions) (*Websocket, error) {
+	if opts.URL == "" {
+		return nil, errors.New("URL cannot be empty")
+	}
+	if opts.Context == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+	if opts.Timout == 0 {
+		opts.Timout = 60 * time.Second
+	}
+	if opts.TLSConfig != nil {
+		if opts.HTTPClient != nil {
+			return nil, errors.New("TLS config cannot be used with a custom HTTP client")
+		}
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.TLSClientConfig = opts.TLSConfig
+		opts.HTTPClient = &http.Client{Transport: t}
+	}
+	if opts.MaxReconnectAttempts < 0 {
+		return nil, errors.New("max reconnect attempts cannot be negative")
+	}
+	if opts.ReconnectBackoff == nil {
+		opts.ReconnectBackoff = ExponentialBackoff(ExponentialBackoffOptions{
+			BaseDelay:         time.Second,
+			MaxDelay:          30 * time.Second,
+			ExponentialFactor: 2,
+		})
+	}
+	conn, err := dialWebsocket(opts)
+	if err != nil {
+		return nil, err
+	}
+	i := &Websocket{
+		stream: &stream{
+			ctx:        opts.Context,
+			errCh:      opts.ErrorCh,
+			timeout:    opts.Timout,
+			onRequest:  opts.OnRequest,
+			onResponse: opts.OnResponse,
+		},
+		opts: opts,
+		conn: conn,
+	}
+	i.onClose = i.close
+	i.stream.initStream()
+	go i.readerRoutine()
+	go i.writerRoutine()
+	return i, nil
+}
+
+func (ws *Websocket) readerRoutine() {
+	// The background context is used here because closing context will
+	// cause the nhooyr.io/websocket package to close a connection with
+	// a close code of 1008 (policy violation) which is not what we want.
+	ctx := context.Background()
+	for {
+		res := rpcResponse{}
+		if err := wsjson.Read(ctx, ws.getConn(), &res); err != nil {
+			if ws.ctx.Err() != nil {
+				return
+			}
+			if ws.opts.MaxReconnectAttempts == 0 || isJSONError(err) {
+				if errors.As(err, &websocket.CloseError{}) {
+					ws.failCalls()
+					return
+				}
+				if ws.errCh != nil {
+					ws.errCh <- fmt.Errorf("websocket reading error: %w", err)
+				}
+				continue
+			}
+			if ws.errCh != nil {
+				ws.errCh <- fmt.Errorf("websocket connection lost: %w", err)
+			}
+			// Responses to pending calls will never arrive, so they are
+			// failed before reconnecting instead of waiting for the timeout.
+			ws.failCalls()
+			if !ws.reconnect() {
+				ws.closeSubs()
+				return
+			}
+			go ws.resubscribe()
+			continue
+		}
+		ws.readerCh <- res
+	}
+}
+
+// reconnect re-establishes the connection. It returns false if the
+// connection could not be established within MaxReconnectAttempts attempts
+// or the transport was closed.
+func (ws *Websocket) reconnect() bool {
+	for i := 0; i < ws.opts.MaxReconnectAttempts; i++ {
+		t := time.NewTimer(ws.opts.ReconnectBackoff(i))
+		select {
+		case <-ws.ctx.Done():
+			t.Stop()
+			return false
+		case <-t.C:
+		}
+		conn, err := dialWebsocket(ws.opts)
+		if err != nil {
+			if ws.errCh != nil {
+				ws.errCh <- fmt.Errorf("websocket reconnect attempt %d failed: %w", i+1, err)
+			}
+			continue
+		}
+		ws.connMu.Lock()
+		if ws.closed {
+			ws.connMu.Unlock()
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return false
+		}
+		old := ws.conn
+		ws.conn = conn
+		ws.connMu.Unlock()
+		_ = old.Close(websocket.StatusGoingAway, "")
+		if ws.opts.OnReconnect != nil {
+			ws.opts.OnReconnect()
+		}
+		return true
+	}
+	return false
+}
+
+func (ws *Websocket) writerRoutine() {
+	for {
+		select {
+		case <-ws.ctx.Done():
+			return
+		case req := <-ws.writerCh:
+			if err := wsjson.Write(ws.ctx, ws.getConn(), req); err != nil {
+				if ws.errCh != nil {
+					ws.errCh <- fmt.Errorf("websocket writing error: %w", err)
+				}
+				continue
+			}
+		}
+	}
+}
+
+func (ws *Websocket) close() {
+	ws.connMu.Lock()
+	ws.closed = true
+	conn := ws.conn
+	ws.connMu.Unlock()
+	err := conn.Close(websocket.StatusNormalClosure, "")
+	if err != nil && ws.errCh != nil {
+		ws.errCh <- fmt.Errorf("websocket closing error: %w", err)
+	}
+}
+
+func (ws *Websocket) getConn() *websocket.Conn {
+	ws.connMu.RLock()
+	defer ws.connMu.RUnlock()
+	return ws.conn
+}
+
+func dialWebsocket(opts WebsocketOptions) (*websocket.Conn, error) {
+	conn, _, err := websocket.Dial(opts.Context, opts.URL, &websocket.DialOptions{
+		HTTPClient: opts.HTTPClient,
+		HTTPHeader: opts.HTTPHeader,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial websocket: %w", err)
+	}
+	return conn, nil
+}
+
+// isJSONError returns true if the error was caused by an invalid message
+// rather than a broken connection.
+func isJSONError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
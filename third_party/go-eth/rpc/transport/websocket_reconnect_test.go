@@ -0,0 +1,332 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"nhooyr.io/websocket"
+	"nhooyr.io/websocket/wsjson"
+)
+
+// wsTestServer is a websocket JSON-RPC server that handles eth_subscribe
+// and eth_unsubscribe requests. Every eth_subscribe request returns a new
+// subscription ID, so it is possible to verify that subscriptions are
+// registered again after reconnecting.
+type wsTestServer struct {
+	srv *httptest.Server
+
+	mu            sync.Mutex
+	conns         []*websocket.Conn
+	subID         int
+	failSubscribe bool
+
+	subscribeCh   chan json.RawMessage // Params of eth_subscribe requests.
+	unsubscribeCh chan json.RawMessage // Params of eth_unsubscribe requests.
+	hangCh        chan struct{}        // Receives eth_hang requests, which are never answered.
+}
+
+func newWSTestServer(t *testing.T) *wsTestServer {
+	s := &wsTestServer{
+		subscribeCh:   make(chan json.RawMessage, 10),
+		unsubscribeCh: make(chan json.RawMessage, 10),
+		hangCh:        make(chan struct{}, 10),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Errorf("accept: %v", err)
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		for {
+			var req struct {
+				ID     uint64          `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := wsjson.Read(context.Background(), conn, &req); err != nil {
+				return
+			}
+			var res string
+			switch req.Method {
+			case "eth_subscribe":
+				s.subscribeCh <- req.Params
+				s.mu.Lock()
+				if s.failSubscribe {
+					res = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32000,"message":"failed"}}`, req.ID)
+				} else {
+					s.subID++
+					res = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x%x"}`, req.ID, s.subID)
+				}
+				s.mu.Unlock()
+			case "eth_unsubscribe":
+				s.unsubscribeCh <- req.Params
+				res = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":true}`, req.ID)
+			case "eth_hang":
+				// Never respond, so the call stays pending.
+				s.hangCh <- struct{}{}
+				continue
+			default:
+				res = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, req.ID)
+			}
+			if err := wsjson.Write(context.Background(), conn, json.RawMessage(res)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(s.close)
+	return s
+}
+
+func (s *wsTestServer) url() string {
+	return "ws" + strings.TrimPrefix(s.srv.URL, "http")
+}
+
+// connCount returns the number of accepted connections.
+func (s *wsTestServer) connCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// notify sends a subscription notification using the latest connection.
+func (s *wsTestServer) notify(t *testing.T, subID, result string) {
+	s.mu.Lock()
+	conn := s.conns[len(s.conns)-1]
+	s.mu.Unlock()
+	msg := fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":%q,"result":%s}}`, subID, result)
+	require.NoError(t, wsjson.Write(context.Background(), conn, json.RawMessage(msg)))
+}
+
+// drop closes the latest connection, as if the node went away.
+func (s *wsTestServer) drop() {
+	s.mu.Lock()
+	conn := s.conns[len(s.conns)-1]
+	s.mu.Unlock()
+	_ = conn.Close(websocket.StatusGoingAway, "")
+}
+
+func (s *wsTestServer) setFailSubscribe(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failSubscribe = fail
+}
+
+func (s *wsTestServer) close() {
+	s.srv.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	}
+}
+
+// waitForError waits for an error on errCh that matches the given function.
+// Other errors are ignored.
+func waitForError(t *testing.T, errCh chan error, match func(error) bool) error {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-errCh:
+			if match(err) {
+				return err
+			}
+		case <-timeout:
+			require.Fail(t, "timeout waiting for error")
+			return nil
+		}
+	}
+}
+
+func receive(t *testing.T, ch chan json.RawMessage) (json.RawMessage, bool) {
+	select {
+	case msg, ok := <-ch:
+		return msg, ok
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for notification")
+		return nil, false
+	}
+}
+
+func newReconnectingWebsocket(t *testing.T, url string, errCh chan error, onReconnect func()) *Websocket {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ws, err := NewWebsocket(WebsocketOptions{
+		Context:              ctx,
+		URL:                  url,
+		Timout:               time.Second,
+		ErrorCh:              errCh,
+		MaxReconnectAttempts: 3,
+		ReconnectBackoff:     func(int) time.Duration { return 10 * time.Millisecond },
+		OnReconnect:          onReconnect,
+	})
+	require.NoError(t, err)
+	return ws
+}
+
+func TestWebsocket_ReconnectAndResubscribe(t *testing.T) {
+	srv := newWSTestServer(t)
+	errCh := make(chan error, 100)
+	var reconnects int32
+	ws := newReconnectingWebsocket(t, srv.url(), errCh, func() { atomic.AddInt32(&reconnects, 1) })
+
+	ch, id, err := ws.Subscribe(context.Background(), "logs", map[string]any{"address": "0x01"})
+	require.NoError(t, err)
+	assert.Equal(t, "0x1", id)
+	assert.JSONEq(t, `["logs", [{"address": "0x01"}]]`, string(<-srv.subscribeCh))
+
+	srv.notify(t, "0x1", `"foo"`)
+	msg, ok := receive(t, ch)
+	require.True(t, ok)
+	assert.Equal(t, json.RawMessage(`"foo"`), msg)
+
+	// Drop the connection. The transport must reconnect and register the
+	// subscription again with the same parameters.
+	srv.drop()
+	waitForError(t, errCh, func(err error) bool {
+		return strings.Contains(err.Error(), "websocket connection lost")
+	})
+	assert.JSONEq(t, `["logs", [{"address": "0x01"}]]`, string(<-srv.subscribeCh))
+	gapErr := waitForError(t, errCh, func(err error) bool {
+		var gap *SubscriptionGapError
+		return errors.As(err, &gap)
+	})
+	assert.Equal(t, &SubscriptionGapError{ID: "0x1"}, gapErr)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&reconnects))
+	assert.Equal(t, 2, srv.connCount())
+
+	// Notifications for the new server-side ID are delivered to the
+	// original channel.
+	srv.notify(t, "0x2", `"bar"`)
+	msg, ok = receive(t, ch)
+	require.True(t, ok)
+	assert.Equal(t, json.RawMessage(`"bar"`), msg)
+
+	// Unsubscribing with the original ID must use the new server-side ID.
+	require.NoError(t, ws.Unsubscribe(context.Background(), id))
+	assert.JSONEq(t, `["0x2"]`, string(<-srv.unsubscribeCh))
+	_, ok = receive(t, ch)
+	assert.False(t, ok)
+}
+
+func TestWebsocket_ResubscribeFailure(t *testing.T) {
+	srv := newWSTestServer(t)
+	errCh := make(chan error, 100)
+	ws := newReconnectingWebsocket(t, srv.url(), errCh, nil)
+
+	ch, id, err := ws.Subscribe(context.Background(), "newHeads")
+	require.NoError(t, err)
+	<-srv.subscribeCh
+
+	// The subscription cannot be registered again after reconnecting, so
+	// its channel must be closed.
+	srv.setFailSubscribe(true)
+	srv.drop()
+	err = waitForError(t, errCh, func(err error) bool {
+		return strings.Contains(err.Error(), "failed to resubscribe")
+	})
+	assert.Contains(t, err.Error(), id)
+	_, ok := receive(t, ch)
+	assert.False(t, ok)
+	assert.Error(t, ws.Unsubscribe(context.Background(), id))
+}
+
+func TestWebsocket_ReconnectFailure(t *testing.T) {
+	srv := newWSTestServer(t)
+	errCh := make(chan error, 100)
+	ws := newReconnectingWebsocket(t, srv.url(), errCh, func() {
+		t.Error("unexpected reconnect")
+	})
+
+	ch, _, err := ws.Subscribe(context.Background(), "newHeads")
+	require.NoError(t, err)
+
+	// Stop the server, so reconnect attempts fail. After all attempts fail,
+	// subscription channels must be closed.
+	srv.close()
+	for i := 1; i <= 3; i++ {
+		waitForError(t, errCh, func(err error) bool {
+			return strings.Contains(err.Error(), fmt.Sprintf("websocket reconnect attempt %d failed", i))
+		})
+	}
+	_, ok := receive(t, ch)
+	assert.False(t, ok)
+}
+
+func TestWebsocket_FailPendingCalls(t *testing.T) {
+	srv := newWSTestServer(t)
+	errCh := make(chan error, 100)
+	reconnectCh := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ws, err := NewWebsocket(WebsocketOptions{
+		Context:              ctx,
+		URL:                  srv.url(),
+		Timout:               time.Minute,
+		ErrorCh:              errCh,
+		MaxReconnectAttempts: 3,
+		ReconnectBackoff:     func(int) time.Duration { return 10 * time.Millisecond },
+		OnReconnect:          func() { reconnectCh <- struct{}{} },
+	})
+	require.NoError(t, err)
+
+	callErrCh := make(chan error, 1)
+	go func() {
+		callErrCh <- ws.Call(context.Background(), nil, "eth_hang")
+	}()
+	<-srv.hangCh
+
+	// The response to the pending call will never arrive, so the call must
+	// fail as soon as the connection is lost instead of waiting for the
+	// timeout.
+	srv.drop()
+	select {
+	case err := <-callErrCh:
+		assert.ErrorIs(t, err, ErrConnectionLost)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "pending call was not failed")
+	}
+
+	// Calls made after reconnecting must succeed.
+	select {
+	case <-reconnectCh:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for reconnect")
+	}
+	require.NoError(t, ws.Call(context.Background(), nil, "eth_a"))
+}
+
+func TestWebsocket_NoReconnect(t *testing.T) {
+	srv := newWSTestServer(t)
+	errCh := make(chan error, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ws, err := NewWebsocket(WebsocketOptions{
+		Context: ctx,
+		URL:     srv.url(),
+		Timout:  time.Second,
+		ErrorCh: errCh,
+	})
+	require.NoError(t, err)
+
+	_, _, err = ws.Subscribe(context.Background(), "newHeads")
+	require.NoError(t, err)
+
+	// Without MaxReconnectAttempts, the transport must not reconnect.
+	srv.drop()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, srv.connCount())
+}